@@ -3,16 +3,64 @@ package api
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
 	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
 	"github.com/lordofthemind/backendMasterGo/token"
+	"github.com/lordofthemind/backendMasterGo/utils"
 )
 
 type CreateAccountRequest struct {
 	Currency string `json:"currency" binding:"required,currency"`
+	// system accounts belong to the bank and cannot be opened through the API
+	Type string `json:"type" binding:"omitempty,oneof=checking savings"`
+}
+
+type accountResponse struct {
+	ID        int64         `json:"id"`
+	Owner     string        `json:"owner"`
+	Balance   utils.Money   `json:"balance"`
+	Currency  string        `json:"currency"`
+	CreatedAt time.Time     `json:"created_at"`
+	ClosedAt  sql.NullTime  `json:"closed_at"`
+	Status    string        `json:"status"`
+	Type      string        `json:"type"`
+	Purpose   string        `json:"purpose"`
+	OrgID     sql.NullInt64 `json:"org_id"`
+}
+
+func newAccountResponse(account db.Account) (accountResponse, error) {
+	balance, err := utils.NewMoney(account.Balance, account.Currency)
+	if err != nil {
+		return accountResponse{}, err
+	}
+	return accountResponse{
+		ID:        account.ID,
+		Owner:     account.Owner,
+		Balance:   balance,
+		Currency:  account.Currency,
+		CreatedAt: account.CreatedAt,
+		ClosedAt:  account.ClosedAt,
+		Status:    account.Status,
+		Type:      account.Type,
+		Purpose:   account.Purpose,
+		OrgID:     account.OrgID,
+	}, nil
+}
+
+func newAccountsResponse(accounts []db.Account) ([]accountResponse, error) {
+	rsp := make([]accountResponse, len(accounts))
+	for i, account := range accounts {
+		var err error
+		if rsp[i], err = newAccountResponse(account); err != nil {
+			return nil, err
+		}
+	}
+	return rsp, nil
 }
 
 func (server *Server) createAccount(ctx *gin.Context) {
@@ -24,10 +72,25 @@ func (server *Server) createAccount(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
+	accountType := req.Type
+	if accountType == "" {
+		accountType = db.AccountTypeChecking
+	}
+
 	arg := db.CreateAccountParams{
 		Owner:    authPayload.Username,
 		Currency: req.Currency,
-		Balance:  0,
+		Type:     accountType,
+	}
+
+	// acting for an organization opens an account owned by the organization
+	if member, ok := orgMember(ctx); ok {
+		if !member.Can(db.AccountPermissionManage) {
+			err := fmt.Errorf("only admins can open accounts for organization %d", member.OrgID)
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		arg.OrgID = orgID(ctx)
 	}
 
 	account, err := server.store.CreateAccount(ctx, arg)
@@ -42,7 +105,13 @@ func (server *Server) createAccount(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, account)
+
+	rsp, err := newAccountResponse(account)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 type getAccountRequest struct {
@@ -56,49 +125,269 @@ func (server *Server) getAccount(ctx *gin.Context) {
 		return
 	}
 
-	account, err := server.store.GetAccount(ctx, req.ID)
+	account, valid := server.authorizedAccount(ctx, req.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	rsp, err := newAccountResponse(account)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type listAccountRequest struct {
+	pageRequest
+}
+
+func (server *Server) listAccounts(ctx *gin.Context) {
+	var req listAccountRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	p, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	accounts, err := keysetPage(ctx, p, func(account db.Account) int64 { return account.ID },
+		func(after bool, cursorID int64, limit int32) ([]db.Account, error) {
+			if p.Legacy {
+				return server.store.ListAccounts(ctx, db.ListAccountsParams{
+					OrgID:    orgID(ctx),
+					Username: authPayload.Username,
+					Limit:    limit,
+					Offset:   p.Offset,
+				})
+			}
+			if after {
+				return server.store.ListAccountsAfter(ctx, db.ListAccountsAfterParams{
+					OrgID:     orgID(ctx),
+					Username:  authPayload.Username,
+					CursorID:  cursorID,
+					PageLimit: limit,
+				})
+			}
+			return server.store.ListAccountsBefore(ctx, db.ListAccountsBeforeParams{
+				OrgID:     orgID(ctx),
+				Username:  authPayload.Username,
+				CursorID:  cursorID,
+				PageLimit: limit,
+			})
+		})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp, err := newAccountsResponse(accounts)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type closeAccountRequest struct {
+	SweepAccountID int64 `form:"sweep_account_id" binding:"omitempty,min=1"`
+}
+
+type closeAccountResponse struct {
+	Account accountResponse     `json:"account"`
+	Sweep   *transferTxResponse `json:"sweep"`
+}
+
+func newCloseAccountResponse(result db.CloseAccountTxResult, currency string) (closeAccountResponse, error) {
+	var rsp closeAccountResponse
+	var err error
+	if rsp.Account, err = newAccountResponse(result.Account); err != nil {
+		return rsp, err
+	}
+	if result.Sweep != nil {
+		sweep, err := newTransferTxResponse(*result.Sweep, currency)
+		if err != nil {
+			return rsp, err
+		}
+		rsp.Sweep = &sweep
+	}
+	return rsp, nil
+}
+
+func (server *Server) closeAccount(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req closeAccountRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uri.ID, db.AccountPermissionManage)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	// the remaining balance can only be swept to another open account the user owns
+	if req.SweepAccountID != 0 {
+		if req.SweepAccountID == account.ID {
+			err := errors.New("cannot sweep an account into itself")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		sweepAccount, valid := server.validAccount(ctx, req.SweepAccountID, account.Currency, db.AccountCredit)
+		if !valid {
+			return
+		}
+		if !server.authorizeAccount(ctx, sweepAccount, db.AccountPermissionManage) {
+			return
+		}
+	}
+
+	result, err := server.store.CloseAccountTx(ctx, db.CloseAccountTxParams{
+		AccountID:      account.ID,
+		SweepAccountID: req.SweepAccountID,
+		Actor:          authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrAccountClosed) || errors.Is(err, db.ErrAccountNotEmpty) ||
+			errors.Is(err, db.ErrAccountStatusViolation) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
 			return
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	rsp, err := newCloseAccountResponse(result, account.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type getAccountLimitsResponse struct {
+	AccountID int64            `json:"account_id"`
+	Currency  string           `json:"currency"`
+	Limits    []db.LimitStatus `json:"limits"`
+}
+
+func (server *Server) getAccountLimits(ctx *gin.Context) {
+	var req getAccountRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, req.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if account.Owner != authPayload.Username {
-		err := errors.New("account doesn't belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	limits, err := server.store.AccountLimits(ctx, account.ID, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, account)
+
+	rsp := getAccountLimitsResponse{
+		AccountID: account.ID,
+		Currency:  account.Currency,
+		Limits:    limits,
+	}
+	ctx.JSON(http.StatusOK, rsp)
 }
 
-type listAccountRequest struct {
-	PageID   int32 `form:"page_is" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+type getAccountBalanceRequest struct {
+	At time.Time `form:"at" time_format:"2006-01-02T15:04:05Z07:00"`
 }
 
-func (server *Server) listAccounts(ctx *gin.Context) {
-	var req listAccountRequest
+type accountBalanceResponse struct {
+	AccountID int64       `json:"account_id"`
+	At        time.Time   `json:"at"`
+	Balance   utils.Money `json:"balance"`
+	// SnapshotDay is the end of day snapshot the balance builds on, empty when it was recomputed from the current balance
+	SnapshotDay string `json:"snapshot_day"`
+}
+
+// getAccountBalance returns the balance of the account at a point in time, now by default
+func (server *Server) getAccountBalance(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req getAccountBalanceRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	if req.At.IsZero() {
+		req.At = time.Now()
+	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	account, valid := server.authorizedAccount(ctx, uri.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
 
-	arg := db.ListAccountsParams{
-		Owner:  authPayload.Username,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+	balance, err := server.store.AccountBalanceAt(ctx, account.ID, req.At)
+	if err != nil {
+		if errors.Is(err, db.ErrAccountNotOpen) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
-	accounts, err := server.store.ListAccounts(ctx, arg)
+	amount, err := utils.NewMoney(balance.Balance, account.Currency)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, accounts)
+
+	rsp := accountBalanceResponse{
+		AccountID: account.ID,
+		At:        balance.At,
+		Balance:   amount,
+	}
+	if balance.Snapshot != nil {
+		rsp.SnapshotDay = balance.Snapshot.Day.Format(time.DateOnly)
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// authorizedAccount loads the account and checks that the authenticated user is an
+// active member whose role grants the permission
+func (server *Server) authorizedAccount(ctx *gin.Context, accountID int64, permission string) (db.Account, bool) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return account, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return account, false
+	}
+
+	if !server.authorizeAccount(ctx, account, permission) {
+		return account, false
+	}
+	return account, true
 }
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/token"
+)
+
+type addAccountMemberRequest struct {
+	Username string `json:"username" binding:"required,alphanum"`
+	Role     string `json:"role" binding:"required,oneof=owner spender viewer"`
+}
+
+// addAccountMember invites a user to the account, the membership is inactive until the user accepts it
+func (server *Server) addAccountMember(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req addAccountMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uri.ID, db.AccountPermissionManage)
+	if !valid {
+		return
+	}
+	if account.OrgID.Valid {
+		err := errors.New("organization accounts are shared through organization members")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+	if account.Status == db.AccountStatusClosed {
+		ctx.JSON(http.StatusForbidden, errorResponse(db.ErrAccountClosed))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	member, err := server.store.CreateAccountMember(ctx, db.CreateAccountMemberParams{
+		AccountID: account.ID,
+		Username:  req.Username,
+		Role:      req.Role,
+		InvitedBy: authPayload.Username,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation", "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, member)
+}
+
+func (server *Server) listAccountMembers(ctx *gin.Context) {
+	var req getAccountRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, req.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	members, err := server.store.ListAccountMembers(ctx, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, members)
+}
+
+// acceptAccountMember activates the invitation of the authenticated user
+func (server *Server) acceptAccountMember(ctx *gin.Context) {
+	var req getAccountRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	member, err := server.store.AcceptAccountMember(ctx, db.AcceptAccountMemberParams{
+		AccountID: req.ID,
+		Username:  authPayload.Username,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := fmt.Errorf("no pending invitation to account %d", req.ID)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, member)
+}
+
+type removeAccountMemberRequest struct {
+	ID       int64  `uri:"id" binding:"required,min=1"`
+	Username string `uri:"username" binding:"required,alphanum"`
+}
+
+// removeAccountMember lets owners remove any member and members leave the account themselves
+func (server *Server) removeAccountMember(ctx *gin.Context) {
+	var req removeAccountMemberRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if req.Username != authPayload.Username {
+		if _, valid := server.authorizedAccount(ctx, req.ID, db.AccountPermissionManage); !valid {
+			return
+		}
+	}
+
+	err := server.store.RemoveAccountMemberTx(ctx, db.RemoveAccountMemberTxParams{
+		AccountID: req.ID,
+		Username:  req.Username,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrLastAccountOwner) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// accountAccess checks whether the authenticated user may act on the account with the permission.
+// Organization accounts are reached through the organization the request acts for (X-Org-ID),
+// the other accounts through account_members. On failure it also returns the status to reply with.
+func (server *Server) accountAccess(ctx *gin.Context, account db.Account, permission string) (int, error) {
+	if account.OrgID.Valid {
+		member, ok := orgMember(ctx)
+		if !ok || member.OrgID != account.OrgID.Int64 {
+			err := fmt.Errorf("account %d belongs to organization %d, act on its behalf with the %s header",
+				account.ID, account.OrgID.Int64, orgIDHeaderKey)
+			return http.StatusUnauthorized, err
+		}
+		if !member.Can(permission) {
+			err := fmt.Errorf("%s role in organization %d does not allow to %s", member.Role, member.OrgID, permission)
+			return http.StatusForbidden, err
+		}
+		return http.StatusOK, nil
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	member, err := server.store.GetAccountMember(ctx, db.GetAccountMemberParams{
+		AccountID: account.ID,
+		Username:  authPayload.Username,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return http.StatusUnauthorized, fmt.Errorf("account %d doesn't belong to the authenticated user", account.ID)
+		}
+		return http.StatusInternalServerError, err
+	}
+	if !member.Can(permission) {
+		err := fmt.Errorf("%s membership of account %d does not allow to %s", member.Role, account.ID, permission)
+		return http.StatusForbidden, err
+	}
+	return http.StatusOK, nil
+}
+
+// authorizeAccount replies with an error unless the authenticated user may act on the account with the permission
+func (server *Server) authorizeAccount(ctx *gin.Context, account db.Account, permission string) bool {
+	if status, err := server.accountAccess(ctx, account, permission); err != nil {
+		ctx.JSON(status, errorResponse(err))
+		return false
+	}
+	return true
+}
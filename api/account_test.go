@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -47,8 +48,8 @@ func TestCreateAccountAPI(t *testing.T) {
 				// Stub the CreateAccount method to return the generated account
 				store.EXPECT().CreateAccount(gomock.Any(), db.CreateAccountParams{
 					Owner:    account.Owner,
-					Balance:  0,
 					Currency: account.Currency,
+					Type:     db.AccountTypeChecking,
 				}).Times(1).Return(account, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -57,6 +58,98 @@ func TestCreateAccountAPI(t *testing.T) {
 				requireBodyMatchAccount(t, recorder.Body, account)
 			},
 		},
+		{
+			name: "OkSavings",
+			body: gin.H{
+				"currency": account.Currency,
+				"type":     db.AccountTypeSavings,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				savings := account
+				savings.Type = db.AccountTypeSavings
+
+				store.EXPECT().CreateAccount(gomock.Any(), db.CreateAccountParams{
+					Owner:    account.Owner,
+					Currency: account.Currency,
+					Type:     db.AccountTypeSavings,
+				}).Times(1).Return(savings, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got accountResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, db.AccountTypeSavings, got.Type)
+			},
+		},
+		{
+			name: "SystemTypeNotAllowed",
+			body: gin.H{
+				"currency": account.Currency,
+				"type":     db.AccountTypeSystem,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "OrganizationAdmin",
+			body: gin.H{
+				"currency": account.Currency,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				req.Header.Set(orgIDHeaderKey, "3")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOrgMember(store, 3, user.Username, db.OrgRoleAdmin)
+
+				orgAccount := account
+				orgAccount.OrgID = sql.NullInt64{Int64: 3, Valid: true}
+
+				store.EXPECT().CreateAccount(gomock.Any(), db.CreateAccountParams{
+					Owner:    account.Owner,
+					Currency: account.Currency,
+					Type:     db.AccountTypeChecking,
+					OrgID:    orgAccount.OrgID,
+				}).Times(1).Return(orgAccount, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got accountResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, sql.NullInt64{Int64: 3, Valid: true}, got.OrgID)
+			},
+		},
+		{
+			name: "OrganizationApproverCannotOpen",
+			body: gin.H{
+				"currency": account.Currency,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				req.Header.Set(orgIDHeaderKey, "3")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOrgMember(store, 3, user.Username, db.OrgRoleApprover)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 	}
 	// Iterate through test cases
 	for i := range testCases {
@@ -126,6 +219,7 @@ func TestGetAccountAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				// Stub the GetAccount method to return the generated account
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				// Check if the HTTP status code is OK (200) and the response body matches the generated account
@@ -142,6 +236,7 @@ func TestGetAccountAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				// Stub the GetAccount method to return the generated account
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, "unauthorised_user", "")
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				// Check if the HTTP status code is OK (200) and the response body matches the generated account
@@ -250,6 +345,653 @@ func TestGetAccountAPI(t *testing.T) {
 }
 
 func TestListAccountsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	n := 11
+	accounts := make([]db.Account, n)
+	for i := 0; i < n; i++ {
+		accounts[i] = randomAccount(user.Username)
+		accounts[i].ID = int64(i + 1)
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "FirstPage",
+			query: "",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAccountsAfterParams{
+					Username:  user.Username,
+					CursorID:  0,
+					PageLimit: defaultPageSizeDefault + 1,
+				}
+				store.EXPECT().ListAccountsAfter(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccounts(t, recorder.Body, accounts[:defaultPageSizeDefault])
+
+				link := recorder.Header().Get("Link")
+				require.Contains(t, link, encodeCursor(pageCursor{ID: 10, Direction: cursorNext}))
+				require.NotContains(t, link, `rel="prev"`)
+			},
+		},
+		{
+			name:  "NextCursor",
+			query: "page_size=5&cursor=" + encodeCursor(pageCursor{ID: 8, Direction: cursorNext}),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAccountsAfterParams{
+					Username:  user.Username,
+					CursorID:  8,
+					PageLimit: 6,
+				}
+				store.EXPECT().ListAccountsAfter(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts[8:], nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccounts(t, recorder.Body, accounts[8:])
+
+				link := recorder.Header().Get("Link")
+				require.NotContains(t, link, `rel="next"`)
+				require.Contains(t, link, encodeCursor(pageCursor{ID: 9, Direction: cursorPrev}))
+			},
+		},
+		{
+			name:  "PrevCursor",
+			query: "page_size=5&cursor=" + encodeCursor(pageCursor{ID: 9, Direction: cursorPrev}),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAccountsBeforeParams{
+					Username:  user.Username,
+					CursorID:  9,
+					PageLimit: 6,
+				}
+				descending := []db.Account{accounts[7], accounts[6], accounts[5], accounts[4], accounts[3], accounts[2]}
+				store.EXPECT().ListAccountsBefore(gomock.Any(), gomock.Eq(arg)).Times(1).Return(descending, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccounts(t, recorder.Body, accounts[3:8])
+
+				link := recorder.Header().Get("Link")
+				require.Contains(t, link, encodeCursor(pageCursor{ID: 8, Direction: cursorNext}))
+				require.Contains(t, link, encodeCursor(pageCursor{ID: 4, Direction: cursorPrev}))
+			},
+		},
+		{
+			name:  "LegacyOffsetPaging",
+			query: "page_is=2&page_size=5",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAccountsParams{
+					Username: user.Username,
+					Limit:    5,
+					Offset:   5,
+				}
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts[5:10], nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccounts(t, recorder.Body, accounts[5:10])
+				require.Equal(t, "true", recorder.Header().Get("Deprecation"))
+			},
+		},
+		{
+			name:  "InvalidCursor",
+			query: "cursor=not-a-cursor",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAccountsAfter(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListAccountsBefore(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "PageSizeTooLarge",
+			query: fmt.Sprintf("page_size=%d", defaultPageSizeMax+1),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAccountsAfter(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "NoAuthorization",
+			query: "",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAccountsAfter(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/accounts?"+tc.query, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetAccountLimitsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	limits := []db.LimitStatus{
+		{
+			LimitID:   1,
+			Scope:     db.LimitScopeAccount,
+			Period:    db.LimitPeriodDaily,
+			Kind:      db.LimitKindAmount,
+			Limit:     1000,
+			Used:      400,
+			Remaining: 600,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		accountID     int64
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().AccountLimits(gomock.Any(), gomock.Eq(account.ID), gomock.Eq(user.Username)).Times(1).Return(limits, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp getAccountLimitsResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, account.ID, rsp.AccountID)
+				require.Equal(t, limits, rsp.Limits)
+			},
+		},
+		{
+			name:      "UnauthorizedUser",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, "unauthorized_user", "")
+				store.EXPECT().AccountLimits(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "NotFound",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().AccountLimits(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "InternalError",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().AccountLimits(gomock.Any(), gomock.Eq(account.ID), gomock.Eq(user.Username)).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/limits", tc.accountID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetAccountBalanceAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	account.Currency = utils.USD
+
+	at := time.Date(2026, 3, 5, 10, 30, 0, 0, time.UTC)
+	snapshot := db.BalanceSnapshot{
+		AccountID: account.ID,
+		Day:       time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+		Balance:   10000,
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "at=2026-03-05T10:30:00Z",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleViewer)
+				store.EXPECT().AccountBalanceAt(gomock.Any(), gomock.Eq(account.ID), gomock.Eq(at)).Times(1).
+					Return(db.AccountBalance{AccountID: account.ID, At: at, Balance: 12345, Snapshot: &snapshot}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp accountBalanceResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, account.ID, rsp.AccountID)
+				require.True(t, at.Equal(rsp.At))
+				require.Equal(t, "123.45", rsp.Balance.Decimal())
+				require.Equal(t, "2026-03-04", rsp.SnapshotDay)
+			},
+		},
+		{
+			name: "DefaultsToNow",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().AccountBalanceAt(gomock.Any(), gomock.Eq(account.ID), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, accountID int64, at time.Time) (db.AccountBalance, error) {
+						require.WithinDuration(t, time.Now(), at, time.Second)
+						return db.AccountBalance{AccountID: accountID, At: at, Balance: account.Balance}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp accountBalanceResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, account.Balance, rsp.Balance.Amount())
+				require.Empty(t, rsp.SnapshotDay)
+			},
+		},
+		{
+			name:  "InvalidTime",
+			query: "at=yesterday",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().AccountBalanceAt(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "BeforeOpening",
+			query: "at=2000-01-01T00:00:00Z",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().AccountBalanceAt(gomock.Any(), gomock.Eq(account.ID), gomock.Any()).Times(1).
+					Return(db.AccountBalance{}, db.ErrAccountNotOpen)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "UnauthorizedUser",
+			query: "at=2026-03-05T10:30:00Z",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, "unauthorized_user", "")
+				store.EXPECT().AccountBalanceAt(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: "at=2026-03-05T10:30:00Z",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().AccountBalanceAt(gomock.Any(), gomock.Eq(account.ID), gomock.Any()).Times(1).
+					Return(db.AccountBalance{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/balance?%s", account.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCloseAccountAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	sweepAccount := randomAccount(user.Username)
+	sweepAccount.Currency = account.Currency
+
+	otherAccount := randomAccount("other_user")
+	otherAccount.Currency = account.Currency
+
+	closed := account
+	closed.Balance = 0
+	closed.ClosedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	closed.Status = db.AccountStatusClosed
+
+	sweep := db.TransferTxResult{
+		Transfer:    db.Transfer{FromAccountID: account.ID, ToAccountID: sweepAccount.ID, Amount: account.Balance},
+		FromAccount: closed,
+		ToAccount:   sweepAccount,
+	}
+
+	testCases := []struct {
+		name          string
+		accountID     int64
+		query         string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+
+				arg := db.CloseAccountTxParams{
+					AccountID: account.ID,
+					Actor:     user.Username,
+				}
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.CloseAccountTxResult{Account: closed}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp closeAccountResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.True(t, rsp.Account.ClosedAt.Valid)
+				require.Nil(t, rsp.Sweep)
+			},
+		},
+		{
+			name:      "OKWithSweep",
+			accountID: account.ID,
+			query:     fmt.Sprintf("sweep_account_id=%d", sweepAccount.ID),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(sweepAccount.ID)).Times(1).Return(sweepAccount, nil)
+				stubMember(store, sweepAccount.ID, user.Username, db.MemberRoleOwner)
+
+				arg := db.CloseAccountTxParams{
+					AccountID:      account.ID,
+					SweepAccountID: sweepAccount.ID,
+					Actor:          user.Username,
+				}
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.CloseAccountTxResult{Account: closed, Sweep: &sweep}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp closeAccountResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.NotNil(t, rsp.Sweep)
+				require.Equal(t, account.Balance, rsp.Sweep.Transfer.Amount.Amount())
+				require.Equal(t, account.Currency, rsp.Sweep.Transfer.Amount.Currency())
+			},
+		},
+		{
+			name:      "BalanceNotZero",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.CloseAccountTxResult{}, db.ErrAccountNotEmpty)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "AlreadyClosed",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(closed, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.CloseAccountTxResult{}, db.ErrAccountClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "SweepAccountOfAnotherUser",
+			accountID: account.ID,
+			query:     fmt.Sprintf("sweep_account_id=%d", otherAccount.ID),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(otherAccount.ID)).Times(1).Return(otherAccount, nil)
+				stubMember(store, otherAccount.ID, user.Username, "")
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "SweepIntoItself",
+			accountID: account.ID,
+			query:     fmt.Sprintf("sweep_account_id=%d", account.ID),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "UnauthorizedUser",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, "unauthorized_user", "")
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "InternalError",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.CloseAccountTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d?%s", tc.accountID, tc.query)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
 }
 
 func randomAccount(owner string) db.Account {
@@ -259,6 +1001,32 @@ func randomAccount(owner string) db.Account {
 		Owner:    owner,
 		Balance:  rg.RandomMoney(),
 		Currency: rg.RandomCurrency(),
+		Status:   db.AccountStatusActive,
+		Type:     db.AccountTypeChecking,
+	}
+}
+
+// stubMember makes GetAccountMember return an active membership with the role,
+// or sql.ErrNoRows when role is empty
+func stubMember(store *mockdb.MockStore, accountID int64, username string, role string) {
+	arg := db.GetAccountMemberParams{AccountID: accountID, Username: username}
+	if role == "" {
+		store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+		return
+	}
+	store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(arg)).Times(1).Return(randomMember(accountID, username, role), nil)
+}
+
+func randomMember(accountID int64, username string, role string) db.AccountMember {
+	rg := utils.NewRandomGenerator()
+	return db.AccountMember{
+		ID:         rg.RandomInt(1, 1000),
+		AccountID:  accountID,
+		Username:   username,
+		Role:       role,
+		Status:     db.MemberStatusActive,
+		InvitedBy:  username,
+		AcceptedAt: sql.NullTime{Time: time.Now(), Valid: true},
 	}
 }
 
@@ -266,19 +1034,24 @@ func requireBodyMatchAccount(t *testing.T, body *bytes.Buffer, account db.Accoun
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var gotAccount db.Account
-	err = json.Unmarshal(data, &gotAccount)
+	want, err := newAccountResponse(account)
 	require.NoError(t, err)
-	require.Equal(t, account, gotAccount)
 
+	var gotAccount accountResponse
+	err = json.Unmarshal(data, &gotAccount)
+	require.NoError(t, err)
+	require.Equal(t, want, gotAccount)
 }
 
 func requireBodyMatchAccounts(t *testing.T, body *bytes.Buffer, accounts []db.Account) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var gotAccounts []db.Account
+	want, err := newAccountsResponse(accounts)
+	require.NoError(t, err)
+
+	var gotAccounts []accountResponse
 	err = json.Unmarshal(data, &gotAccounts)
 	require.NoError(t, err)
-	require.Equal(t, accounts, gotAccounts)
+	require.Equal(t, want, gotAccounts)
 }
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCurrenciesAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	rg := utils.NewRandomGenerator()
+	config := utils.Config{
+		TokenSymmetricKey:   rg.RandomString(32),
+		AccessTokenDuration: time.Minute,
+		EnabledCurrencies:   []string{"JPY", "KWD", utils.EUR},
+	}
+	server, err := NewServer(config, store)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/currencies", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var currencies []utils.Currency
+	err = json.Unmarshal(recorder.Body.Bytes(), &currencies)
+	require.NoError(t, err)
+	require.Len(t, currencies, 3)
+	require.Equal(t, utils.EUR, currencies[0].Code)
+	require.Equal(t, "JPY", currencies[1].Code)
+	require.Equal(t, 0, currencies[1].MinorUnits)
+	require.Equal(t, "KWD", currencies[2].Code)
+	require.Equal(t, 3, currencies[2].MinorUnits)
+}
+
+func TestNewServerUnknownCurrency(t *testing.T) {
+	rg := utils.NewRandomGenerator()
+	config := utils.Config{
+		TokenSymmetricKey: rg.RandomString(32),
+		EnabledCurrencies: []string{"XYZ"},
+	}
+	server, err := NewServer(config, nil)
+	require.Error(t, err)
+	require.Nil(t, server)
+}
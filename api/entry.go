@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+type entryResponse struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// negative for money leaving the account
+	Amount      utils.Money     `json:"amount"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Description string          `json:"description"`
+	Reference   string          `json:"reference"`
+	Metadata    json.RawMessage `json:"metadata"`
+	// hex SHA-256 chaining the entry to the previous entry of the account, empty for entries older than the chain
+	Hash     string `json:"hash"`
+	PrevHash string `json:"prev_hash"`
+}
+
+func newEntryResponse(entry db.Entry, currency string) (entryResponse, error) {
+	amount, err := utils.NewMoney(entry.Amount, currency)
+	if err != nil {
+		return entryResponse{}, err
+	}
+	return entryResponse{
+		ID:          entry.ID,
+		AccountID:   entry.AccountID,
+		Amount:      amount,
+		CreatedAt:   entry.CreatedAt,
+		Description: entry.Description,
+		Reference:   entry.Reference,
+		Metadata:    entry.Metadata,
+		Hash:        hex.EncodeToString(entry.Hash),
+		PrevHash:    hex.EncodeToString(entry.PrevHash),
+	}, nil
+}
+
+func (server *Server) listAccountEntries(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req historyFilterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := req.validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	p, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uri.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	minAmount, maxAmount, err := req.amountRange(account.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	entries, err := keysetPage(ctx, p, func(entry db.Entry) int64 { return entry.ID },
+		func(after bool, cursorID int64, limit int32) ([]db.Entry, error) {
+			if p.Legacy {
+				return server.store.ListAccountEntries(ctx, db.ListAccountEntriesParams{
+					AccountID:  account.ID,
+					Direction:  req.direction(),
+					StartTime:  req.startTime(),
+					EndTime:    req.endTime(),
+					MinAmount:  minAmount,
+					MaxAmount:  maxAmount,
+					PageLimit:  limit,
+					PageOffset: p.Offset,
+				})
+			}
+			if after {
+				return server.store.ListAccountEntriesAfter(ctx, db.ListAccountEntriesAfterParams{
+					AccountID: account.ID,
+					CursorID:  cursorID,
+					Direction: req.direction(),
+					StartTime: req.startTime(),
+					EndTime:   req.endTime(),
+					MinAmount: minAmount,
+					MaxAmount: maxAmount,
+					PageLimit: limit,
+				})
+			}
+			return server.store.ListAccountEntriesBefore(ctx, db.ListAccountEntriesBeforeParams{
+				AccountID: account.ID,
+				CursorID:  cursorID,
+				Direction: req.direction(),
+				StartTime: req.startTime(),
+				EndTime:   req.endTime(),
+				MinAmount: minAmount,
+				MaxAmount: maxAmount,
+				PageLimit: limit,
+			})
+		})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]entryResponse, len(entries))
+	for i, entry := range entries {
+		if rsp[i], err = newEntryResponse(entry, account.Currency); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
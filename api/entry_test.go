@@ -0,0 +1,202 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/token"
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAccountEntriesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	n := 5
+	entries := make([]db.Entry, n)
+	for i := 0; i < n; i++ {
+		entries[i] = randomEntry(account.ID)
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: fmt.Sprintf("page_id=1&page_size=%d&direction=in&min_amount=0.10&start_time=2024-01-01T00:00:00Z", n),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+
+				arg := db.ListAccountEntriesParams{
+					AccountID:  account.ID,
+					Direction:  sql.NullString{String: "in", Valid: true},
+					StartTime:  sql.NullTime{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+					MinAmount:  sql.NullInt64{Int64: 10, Valid: true},
+					PageLimit:  int32(n),
+					PageOffset: 0,
+				}
+				store.EXPECT().ListAccountEntries(gomock.Any(), eqListAccountEntriesParams(arg)).Times(1).Return(entries, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []entryResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got, n)
+				require.Equal(t, entries[0].Amount, got[0].Amount.Amount())
+				require.Equal(t, account.Currency, got[0].Amount.Currency())
+			},
+		},
+		{
+			name:  "InvalidDirection",
+			query: fmt.Sprintf("page_id=1&page_size=%d&direction=sideways", n),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidAmountRange",
+			query: fmt.Sprintf("page_id=1&page_size=%d&min_amount=1.00&max_amount=0.10", n),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "TooManyDecimals",
+			query: fmt.Sprintf("page_id=1&page_size=%d&min_amount=0.105", n),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "UnauthorizedUser",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, "unauthorized_user", "")
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Any()).Times(1).Return([]db.Entry{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/entries?%s", account.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+type eqListAccountEntriesParamsMatcher struct {
+	arg db.ListAccountEntriesParams
+}
+
+// Matches compares times with Equal because query parsing yields a different location
+func (e eqListAccountEntriesParamsMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.ListAccountEntriesParams)
+	if !ok {
+		return false
+	}
+	if !arg.StartTime.Time.Equal(e.arg.StartTime.Time) || !arg.EndTime.Time.Equal(e.arg.EndTime.Time) {
+		return false
+	}
+	arg.StartTime.Time = e.arg.StartTime.Time
+	arg.EndTime.Time = e.arg.EndTime.Time
+	return arg == e.arg
+}
+
+func (e eqListAccountEntriesParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v", e.arg)
+}
+
+func eqListAccountEntriesParams(arg db.ListAccountEntriesParams) gomock.Matcher {
+	return eqListAccountEntriesParamsMatcher{arg: arg}
+}
+
+func randomEntry(accountID int64) db.Entry {
+	rg := utils.NewRandomGenerator()
+	return db.Entry{
+		ID:        rg.RandomInt(1, 1000),
+		AccountID: accountID,
+		Amount:    rg.RandomMoney(),
+		Metadata:  json.RawMessage(`{}`),
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/ledger"
+)
+
+// getAccountChain verifies the hash chain of the account's entries and reports where it breaks
+func (server *Server) getAccountChain(ctx *gin.Context) {
+	var req getAccountRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, req.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	verification, err := ledger.VerifyAccountChain(ctx, server.store, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, verification)
+}
+
+type listLedgerCheckpointsRequest struct {
+	Limit int32 `form:"limit,default=100" binding:"min=1,max=1000"`
+}
+
+// listLedgerCheckpoints exports the signed checkpoints, newest first, for anchoring outside the database
+func (server *Server) listLedgerCheckpoints(ctx *gin.Context) {
+	var req listLedgerCheckpointsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	checkpoints, err := server.store.ListLedgerCheckpoints(ctx, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]ledger.Checkpoint, len(checkpoints))
+	for i, checkpoint := range checkpoints {
+		rsp[i] = ledger.NewCheckpoint(checkpoint)
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
@@ -16,6 +16,7 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 	config := utils.Config{
 		TokenSymmetricKey:   rg.RandomString(32),
 		AccessTokenDuration: time.Minute,
+		BlobStorageDir:      t.TempDir(),
 	}
 
 	server, err := NewServer(config, store)
@@ -1,12 +1,15 @@
 package api
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
 	"github.com/lordofthemind/backendMasterGo/token"
 )
 
@@ -14,6 +17,8 @@ const (
 	authorizationHeaderKey  = "Authorization"
 	authorizationTypeBearer = "bearer"
 	authorizationPayloadKey = "authorization_payload"
+	orgIDHeaderKey          = "X-Org-ID"
+	orgMemberKey            = "org_member"
 )
 
 func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
@@ -50,3 +55,81 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// staffMiddleware only lets staff users through, it must run after authMiddleware
+func staffMiddleware(store db.Store) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+		user, err := store.GetUser(ctx, authPayload.Username)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(errors.New("user not found")))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if user.Role != db.UserRoleStaff {
+			err := fmt.Errorf("user %s is not a staff member", user.Username)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// orgMiddleware lets users act on behalf of an organization they belong to by sending
+// its id in the X-Org-ID header, it must run after authMiddleware
+func orgMiddleware(store db.Store) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		orgIDHeader := ctx.GetHeader(orgIDHeaderKey)
+		if len(orgIDHeader) == 0 {
+			ctx.Next()
+			return
+		}
+
+		orgID, err := strconv.ParseInt(orgIDHeader, 10, 64)
+		if err != nil || orgID < 1 {
+			err := fmt.Errorf("invalid %s header %q", orgIDHeaderKey, orgIDHeader)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+		member, err := store.GetOrganizationMember(ctx, db.GetOrganizationMemberParams{
+			OrgID:    orgID,
+			Username: authPayload.Username,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				err := fmt.Errorf("user %s is not a member of organization %d", authPayload.Username, orgID)
+				ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.Set(orgMemberKey, member)
+		ctx.Next()
+	}
+}
+
+// orgMember returns the organization membership the request acts under, if any
+func orgMember(ctx *gin.Context) (db.OrganizationMember, bool) {
+	value, ok := ctx.Get(orgMemberKey)
+	if !ok {
+		return db.OrganizationMember{}, false
+	}
+	return value.(db.OrganizationMember), true
+}
+
+// orgID is the organization the request acts under, invalid for personal requests
+func orgID(ctx *gin.Context) sql.NullInt64 {
+	member, ok := orgMember(ctx)
+	return sql.NullInt64{Int64: member.OrgID, Valid: ok}
+}
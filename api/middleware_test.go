@@ -8,6 +8,9 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
 	"github.com/lordofthemind/backendMasterGo/token"
 	"github.com/stretchr/testify/require"
 )
@@ -102,3 +105,86 @@ func TestAuthMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestOrgMiddleware(t *testing.T) {
+	testCases := []struct {
+		name          string
+		orgID         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Member",
+			orgID: "5",
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOrgMember(store, 5, "user", db.OrgRoleViewer)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, `{"org_id":5}`, recorder.Body.String())
+			},
+		},
+		{
+			name: "NoHeader",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, `{"org_id":0}`, recorder.Body.String())
+			},
+		},
+		{
+			name:  "NotMember",
+			orgID: "5",
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOrgMember(store, 5, "user", "")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidHeader",
+			orgID: "acme",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			orgPath := "/org"
+			server.router.GET(orgPath, authMiddleware(server.tokenMaker), orgMiddleware(store),
+				func(ctx *gin.Context) {
+					ctx.JSON(http.StatusOK, gin.H{"org_id": orgID(ctx).Int64})
+				},
+			)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, orgPath, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "user", time.Minute)
+			if tc.orgID != "" {
+				request.Header.Set(orgIDHeaderKey, tc.orgID)
+			}
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/token"
+)
+
+type listNotificationsRequest struct {
+	Unread bool  `form:"unread"`
+	Limit  int32 `form:"limit,default=50" binding:"min=1,max=200"`
+}
+
+// listNotifications returns the notifications of the authenticated user, newest first
+func (server *Server) listNotifications(ctx *gin.Context) {
+	var req listNotificationsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	notifications, err := server.store.ListNotifications(ctx, db.ListNotificationsParams{
+		Username:   authPayload.Username,
+		UnreadOnly: req.Unread,
+		PageLimit:  req.Limit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, notifications)
+}
+
+type readNotificationRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// readNotification marks a notification of the authenticated user as read, reading it again keeps the first read time
+func (server *Server) readNotification(ctx *gin.Context) {
+	var req readNotificationRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	notification, err := server.store.MarkNotificationRead(ctx, db.MarkNotificationReadParams{
+		ID:       req.ID,
+		Username: authPayload.Username,
+	})
+	if err != nil {
+		// someone else's notification is reported as missing
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, notification)
+}
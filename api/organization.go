@@ -0,0 +1,184 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/token"
+)
+
+type createOrganizationRequest struct {
+	Name string `json:"name" binding:"required,max=255"`
+}
+
+// createOrganization creates an organization with the authenticated user as its first admin
+func (server *Server) createOrganization(ctx *gin.Context) {
+	var req createOrganizationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := server.store.CreateOrganizationTx(ctx, db.CreateOrganizationTxParams{
+		Name:      req.Name,
+		CreatedBy: authPayload.Username,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+// listOrganizations lists the organizations the authenticated user is a member of
+func (server *Server) listOrganizations(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	organizations, err := server.store.ListUserOrganizations(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, organizations)
+}
+
+type getOrganizationRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type addOrganizationMemberRequest struct {
+	Username string `json:"username" binding:"required,alphanum"`
+	Role     string `json:"role" binding:"required,oneof=admin approver viewer"`
+}
+
+// addOrganizationMember lets admins add users to the organization
+func (server *Server) addOrganizationMember(ctx *gin.Context) {
+	var uri getOrganizationRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req addOrganizationMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, valid := server.authorizedOrganization(ctx, uri.ID, true); !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	member, err := server.store.CreateOrganizationMember(ctx, db.CreateOrganizationMemberParams{
+		OrgID:    uri.ID,
+		Username: req.Username,
+		Role:     req.Role,
+		AddedBy:  authPayload.Username,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation", "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, member)
+}
+
+func (server *Server) listOrganizationMembers(ctx *gin.Context) {
+	var req getOrganizationRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, valid := server.authorizedOrganization(ctx, req.ID, false); !valid {
+		return
+	}
+
+	members, err := server.store.ListOrganizationMembers(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, members)
+}
+
+type removeOrganizationMemberRequest struct {
+	ID       int64  `uri:"id" binding:"required,min=1"`
+	Username string `uri:"username" binding:"required,alphanum"`
+}
+
+// removeOrganizationMember lets admins remove any member and members leave the organization themselves
+func (server *Server) removeOrganizationMember(ctx *gin.Context) {
+	var req removeOrganizationMemberRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if req.Username != authPayload.Username {
+		if _, valid := server.authorizedOrganization(ctx, req.ID, true); !valid {
+			return
+		}
+	}
+
+	err := server.store.RemoveOrganizationMemberTx(ctx, db.RemoveOrganizationMemberTxParams{
+		OrgID:    req.ID,
+		Username: req.Username,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrLastOrganizationAdmin) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// authorizedOrganization loads the membership of the authenticated user in the organization,
+// replying with an error when there is none or when an admin is required and the user isn't one
+func (server *Server) authorizedOrganization(ctx *gin.Context, orgID int64, admin bool) (db.OrganizationMember, bool) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	member, err := server.store.GetOrganizationMember(ctx, db.GetOrganizationMemberParams{
+		OrgID:    orgID,
+		Username: authPayload.Username,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := fmt.Errorf("user %s is not a member of organization %d", authPayload.Username, orgID)
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return member, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return member, false
+	}
+
+	if admin && member.Role != db.OrgRoleAdmin {
+		err := fmt.Errorf("only admins can manage organization %d", orgID)
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return member, false
+	}
+	return member, true
+}
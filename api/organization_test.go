@@ -0,0 +1,399 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOrganizationAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": "Acme"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateOrganizationTxParams{Name: "Acme", CreatedBy: user.Username}
+				store.EXPECT().CreateOrganizationTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.CreateOrganizationTxResult{
+						Organization: db.Organization{ID: 1, Name: "Acme", CreatedBy: user.Username},
+						Member:       randomOrgMember(1, user.Username, db.OrgRoleAdmin),
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result db.CreateOrganizationTxResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, "Acme", result.Organization.Name)
+				require.Equal(t, db.OrgRoleAdmin, result.Member.Role)
+			},
+		},
+		{
+			name: "MissingName",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateOrganizationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/organizations", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestAddOrganizationMemberAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	user, _ := randomUser(t)
+	orgID := int64(4)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"username": user.Username, "role": db.OrgRoleApprover},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOrgMember(store, orgID, admin.Username, db.OrgRoleAdmin)
+
+				arg := db.CreateOrganizationMemberParams{
+					OrgID:    orgID,
+					Username: user.Username,
+					Role:     db.OrgRoleApprover,
+					AddedBy:  admin.Username,
+				}
+				store.EXPECT().CreateOrganizationMember(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(randomOrgMember(orgID, user.Username, db.OrgRoleApprover), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ApproverCannotAdd",
+			body: gin.H{"username": user.Username, "role": db.OrgRoleViewer},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOrgMember(store, orgID, admin.Username, db.OrgRoleApprover)
+				store.EXPECT().CreateOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotMember",
+			body: gin.H{"username": user.Username, "role": db.OrgRoleViewer},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOrgMember(store, orgID, admin.Username, "")
+				store.EXPECT().CreateOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyMember",
+			body: gin.H{"username": user.Username, "role": db.OrgRoleViewer},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOrgMember(store, orgID, admin.Username, db.OrgRoleAdmin)
+				store.EXPECT().CreateOrganizationMember(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.OrganizationMember{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidRole",
+			body: gin.H{"username": user.Username, "role": db.MemberRoleOwner},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/organizations/%d/members", orgID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRemoveOrganizationMemberAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	user, _ := randomUser(t)
+	orgID := int64(4)
+
+	testCases := []struct {
+		name          string
+		username      string
+		target        string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "AdminRemovesMember",
+			username: admin.Username,
+			target:   user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOrgMember(store, orgID, admin.Username, db.OrgRoleAdmin)
+				arg := db.RemoveOrganizationMemberTxParams{OrgID: orgID, Username: user.Username}
+				store.EXPECT().RemoveOrganizationMemberTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+		{
+			name:     "MemberLeaves",
+			username: user.Username,
+			target:   user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().RemoveOrganizationMemberTx(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+		{
+			name:     "ViewerCannotRemoveOthers",
+			username: user.Username,
+			target:   admin.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOrgMember(store, orgID, user.Username, db.OrgRoleViewer)
+				store.EXPECT().RemoveOrganizationMemberTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "LastAdmin",
+			username: admin.Username,
+			target:   admin.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RemoveOrganizationMemberTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ErrLastOrganizationAdmin)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			username: admin.Username,
+			target:   user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOrgMember(store, orgID, admin.Username, db.OrgRoleAdmin)
+				store.EXPECT().RemoveOrganizationMemberTx(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/organizations/%d/members/%s", orgID, tc.target)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestOrganizationAccountTransferAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	orgID := int64(4)
+
+	orgAccount := randomAccount(user.Username)
+	orgAccount.OrgID = sql.NullInt64{Int64: orgID, Valid: true}
+	recipient := randomAccount("recipient")
+	recipient.Currency = orgAccount.Currency
+
+	testCases := []struct {
+		name          string
+		orgID         int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Approver",
+			orgID: orgID,
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOrgMember(store, orgID, user.Username, db.OrgRoleApprover)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(orgAccount.ID)).Times(1).Return(orgAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(recipient.ID)).Times(1).Return(recipient, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.TransferTxResult{FromAccount: orgAccount, ToAccount: recipient}, nil)
+				stubMember(store, recipient.ID, user.Username, "")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyHidesToAccount(t, recorder.Body.Bytes())
+			},
+		},
+		{
+			name:  "Viewer",
+			orgID: orgID,
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOrgMember(store, orgID, user.Username, db.OrgRoleViewer)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(orgAccount.ID)).Times(1).Return(orgAccount, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "WithoutOrganizationContext",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(orgAccount.ID)).Times(1).Return(orgAccount, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{
+				"from_account_id": orgAccount.ID,
+				"to_account_id":   recipient.ID,
+				"amount":          "1",
+				"currency":        orgAccount.Currency,
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			if tc.orgID != 0 {
+				request.Header.Set(orgIDHeaderKey, fmt.Sprint(tc.orgID))
+			}
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func stubOrgMember(store *mockdb.MockStore, orgID int64, username string, role string) {
+	arg := db.GetOrganizationMemberParams{OrgID: orgID, Username: username}
+	if role == "" {
+		store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.OrganizationMember{}, sql.ErrNoRows)
+		return
+	}
+	store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Eq(arg)).Times(1).Return(randomOrgMember(orgID, username, role), nil)
+}
+
+func randomOrgMember(orgID int64, username string, role string) db.OrganizationMember {
+	return db.OrganizationMember{
+		ID:       orgID*10 + 1,
+		OrgID:    orgID,
+		Username: username,
+		Role:     role,
+		AddedBy:  username,
+	}
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	cursorNext = "next"
+	cursorPrev = "prev"
+
+	defaultPageSizeMin     = 5
+	defaultPageSizeMax     = 50
+	defaultPageSizeDefault = 10
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// pageRequest holds the paging query parameters shared by every list endpoint.
+// page_id (and the misspelled page_is) select the deprecated OFFSET paging.
+type pageRequest struct {
+	Cursor       string `form:"cursor"`
+	PageID       int32  `form:"page_id" binding:"omitempty,min=1"`
+	LegacyPageID int32  `form:"page_is" binding:"omitempty,min=1"`
+	PageSize     int32  `form:"page_size" binding:"omitempty,min=1"`
+}
+
+type pageCursor struct {
+	ID        int64  `json:"id"`
+	Direction string `json:"dir"`
+}
+
+// page is a validated pageRequest
+type page struct {
+	Size   int32
+	Offset int32
+	Cursor pageCursor
+	// Legacy is true when the client asked for OFFSET paging
+	Legacy bool
+}
+
+func encodeCursor(cursor pageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (pageCursor, error) {
+	var cursor pageCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor, errInvalidCursor
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, errInvalidCursor
+	}
+	if cursor.ID < 1 || (cursor.Direction != cursorNext && cursor.Direction != cursorPrev) {
+		return cursor, errInvalidCursor
+	}
+	return cursor, nil
+}
+
+func (server *Server) pageSizeBounds() (min, max, def int32) {
+	min, max, def = server.config.PageSizeMin, server.config.PageSizeMax, server.config.PageSizeDefault
+	if min <= 0 {
+		min = defaultPageSizeMin
+	}
+	if max <= 0 {
+		max = defaultPageSizeMax
+	}
+	if def <= 0 {
+		def = defaultPageSizeDefault
+	}
+	return
+}
+
+func (server *Server) parsePage(req pageRequest) (page, error) {
+	min, max, def := server.pageSizeBounds()
+
+	p := page{Size: req.PageSize}
+	if p.Size == 0 {
+		p.Size = def
+	}
+	if p.Size < min || p.Size > max {
+		return p, fmt.Errorf("page_size must be between %d and %d", min, max)
+	}
+
+	pageID := req.PageID
+	if pageID == 0 {
+		pageID = req.LegacyPageID
+	}
+
+	if pageID > 0 {
+		if req.Cursor != "" {
+			return p, errors.New("cursor and page_id cannot be used together")
+		}
+		p.Legacy = true
+		p.Offset = (pageID - 1) * p.Size
+		return p, nil
+	}
+
+	p.Cursor = pageCursor{Direction: cursorNext}
+	if req.Cursor != "" {
+		cursor, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return p, err
+		}
+		p.Cursor = cursor
+	}
+	return p, nil
+}
+
+// keysetPage loads one page of items ordered by ascending id. fetch must return
+// items after the cursor in ascending order, or before it in descending order.
+// The Link header is set with the cursors of the neighbouring pages.
+func keysetPage[T any](ctx *gin.Context, p page, idOf func(T) int64, fetch func(after bool, cursorID int64, limit int32) ([]T, error)) ([]T, error) {
+	var hasNext, hasPrev bool
+
+	if p.Legacy {
+		items, err := fetch(true, 0, p.Size)
+		if err != nil {
+			return nil, err
+		}
+		ctx.Header("Deprecation", "true")
+		if len(items) == int(p.Size) {
+			setLinkHeader(ctx, idOf(items[0]), idOf(items[len(items)-1]), true, false)
+		}
+		return items, nil
+	}
+
+	after := p.Cursor.Direction == cursorNext
+	items, err := fetch(after, p.Cursor.ID, p.Size+1)
+	if err != nil {
+		return nil, err
+	}
+
+	more := len(items) > int(p.Size)
+	if more {
+		items = items[:p.Size]
+	}
+
+	if after {
+		hasNext = more
+		hasPrev = p.Cursor.ID > 0
+	} else {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+		hasNext = true
+		hasPrev = more
+	}
+
+	if len(items) > 0 {
+		setLinkHeader(ctx, idOf(items[0]), idOf(items[len(items)-1]), hasNext, hasPrev)
+	}
+	return items, nil
+}
+
+func setLinkHeader(ctx *gin.Context, firstID int64, lastID int64, hasNext bool, hasPrev bool) {
+	var links []string
+
+	link := func(cursor pageCursor, rel string) string {
+		query := url.Values{}
+		for key, values := range ctx.Request.URL.Query() {
+			query[key] = values
+		}
+		query.Del("page_id")
+		query.Del("page_is")
+		query.Set("cursor", encodeCursor(cursor))
+
+		u := url.URL{Path: ctx.Request.URL.Path, RawQuery: query.Encode()}
+		return fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel)
+	}
+
+	if hasNext {
+		links = append(links, link(pageCursor{ID: lastID, Direction: cursorNext}, cursorNext))
+	}
+	if hasPrev {
+		links = append(links, link(pageCursor{ID: firstID, Direction: cursorPrev}, cursorPrev))
+	}
+	if len(links) > 0 {
+		ctx.Header("Link", strings.Join(links, ", "))
+	}
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageCursor(t *testing.T) {
+	cursor := pageCursor{ID: 42, Direction: cursorPrev}
+
+	decoded, err := decodeCursor(encodeCursor(cursor))
+	require.NoError(t, err)
+	require.Equal(t, cursor, decoded)
+
+	_, err = decodeCursor("garbage")
+	require.ErrorIs(t, err, errInvalidCursor)
+
+	_, err = decodeCursor(encodeCursor(pageCursor{ID: 0, Direction: cursorNext}))
+	require.ErrorIs(t, err, errInvalidCursor)
+
+	_, err = decodeCursor(encodeCursor(pageCursor{ID: 1, Direction: "sideways"}))
+	require.ErrorIs(t, err, errInvalidCursor)
+}
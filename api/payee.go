@@ -0,0 +1,156 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/token"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+// createPayeeRequest saves either an account or a user, a user is paid on their checking account
+// in the currency of each transfer
+type createPayeeRequest struct {
+	Name      string `json:"name" binding:"required,max=64"`
+	AccountID int64  `json:"account_id" binding:"omitempty,min=1"`
+	Username  string `json:"username" binding:"omitempty,alphanum"`
+}
+
+type payeeResponse struct {
+	db.Payee
+	CoolingOffUntil time.Time `json:"cooling_off_until"`
+}
+
+func (server *Server) newPayeeResponse(payee db.Payee) payeeResponse {
+	return payeeResponse{Payee: payee, CoolingOffUntil: server.coolingOffUntil(payee)}
+}
+
+// coolingOffUntil is when transfers to the payee stop being capped by the cooling-off limits
+func (server *Server) coolingOffUntil(payee db.Payee) time.Time {
+	return payee.CreatedAt.Add(server.config.PayeeCoolingOff)
+}
+
+func (server *Server) createPayee(ctx *gin.Context) {
+	var req createPayeeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if (req.AccountID == 0) == (req.Username == "") {
+		err := errors.New("exactly one of account_id and username is required")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	payee, err := server.store.CreatePayee(ctx, db.CreatePayeeParams{
+		Owner:     authPayload.Username,
+		Name:      req.Name,
+		AccountID: sql.NullInt64{Int64: req.AccountID, Valid: req.AccountID != 0},
+		Username:  sql.NullString{String: req.Username, Valid: req.Username != ""},
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation", "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, server.newPayeeResponse(payee))
+}
+
+func (server *Server) listPayees(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	payees, err := server.store.ListPayees(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]payeeResponse, len(payees))
+	for i, payee := range payees {
+		rsp[i] = server.newPayeeResponse(payee)
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type deletePayeeRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deletePayee(ctx *gin.Context) {
+	var req deletePayeeRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	_, err := server.store.DeletePayee(ctx, db.DeletePayeeParams{
+		ID:    req.ID,
+		Owner: authPayload.Username,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// payeeAccount resolves the account a payee of the authenticated user is paid on for the amount, the store
+// refuses amounts above the cooling-off limit while the payee is new
+func (server *Server) payeeAccount(ctx *gin.Context, payeeID int64, amount utils.Money) (int64, bool) {
+	payee, err := server.store.GetPayee(ctx, payeeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return 0, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return 0, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if payee.Owner != authPayload.Username {
+		err := fmt.Errorf("payee %d doesn't belong to the authenticated user", payeeID)
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return 0, false
+	}
+
+	if payee.AccountID.Valid {
+		return payee.AccountID.Int64, true
+	}
+
+	account, err := server.store.GetOwnerAccount(ctx, db.GetOwnerAccountParams{
+		Owner:    payee.Username.String,
+		Currency: amount.Currency(),
+		Type:     db.AccountTypeChecking,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := fmt.Errorf("payee %d has no %s account", payeeID, amount.Currency())
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return 0, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return 0, false
+	}
+	return account.ID, true
+}
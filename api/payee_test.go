@@ -0,0 +1,459 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func newPayeeTestServer(t *testing.T, store db.Store) *Server {
+	rg := utils.NewRandomGenerator()
+	config := utils.Config{
+		TokenSymmetricKey:   rg.RandomString(32),
+		AccessTokenDuration: time.Minute,
+		PayeeCoolingOff:     24 * time.Hour,
+		PayeeCoolingLimits:  []string{"USD:100.00"},
+	}
+
+	server, err := NewServer(config, store)
+	require.NoError(t, err)
+
+	return server
+}
+
+func TestCreatePayeeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	friend, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Account",
+			body: gin.H{"name": "Landlord", "account_id": 42},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreatePayeeParams{
+					Owner:     user.Username,
+					Name:      "Landlord",
+					AccountID: sql.NullInt64{Int64: 42, Valid: true},
+				}
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.Payee{ID: 1, Owner: user.Username, Name: "Landlord", AccountID: arg.AccountID, CreatedAt: time.Now()}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp payeeResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.WithinDuration(t, time.Now().Add(24*time.Hour), rsp.CoolingOffUntil, time.Minute)
+			},
+		},
+		{
+			name: "Username",
+			body: gin.H{"name": "Friend", "username": friend.Username},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreatePayeeParams{
+					Owner:    user.Username,
+					Name:     "Friend",
+					Username: sql.NullString{String: friend.Username, Valid: true},
+				}
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.Payee{ID: 2, Owner: user.Username, Name: "Friend", Username: arg.Username}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "BothTargets",
+			body: gin.H{"name": "Friend", "username": friend.Username, "account_id": 42},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NoTarget",
+			body: gin.H{"name": "Friend"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownUser",
+			body: gin.H{"name": "Friend", "username": "nobody"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.Payee{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newPayeeTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/payees", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeletePayeeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.DeletePayeeParams{ID: 5, Owner: user.Username}
+				store.EXPECT().DeletePayee(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Payee{ID: 5}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeletePayee(gomock.Any(), gomock.Any()).Times(1).Return(db.Payee{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newPayeeTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, "/payees/5", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestTransferToPayeeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	friend, _ := randomUser(t)
+
+	account := randomAccount(user.Username)
+	friendAccount := randomAccount(friend.Username)
+	account.Currency = utils.USD
+	friendAccount.Currency = utils.USD
+
+	accountPayee := db.Payee{
+		ID:        1,
+		Owner:     user.Username,
+		Name:      "Friend",
+		AccountID: sql.NullInt64{Int64: friendAccount.ID, Valid: true},
+		CreatedAt: time.Now().Add(-48 * time.Hour),
+	}
+	userPayee := db.Payee{
+		ID:        2,
+		Owner:     user.Username,
+		Name:      "Friend",
+		Username:  sql.NullString{String: friend.Username, Valid: true},
+		CreatedAt: time.Now().Add(-48 * time.Hour),
+	}
+	newPayee := accountPayee
+	newPayee.ID = 3
+	newPayee.CreatedAt = time.Now()
+
+	testCases := []struct {
+		name          string
+		payee         db.Payee
+		amount        string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "AccountPayee",
+			payee:  accountPayee,
+			amount: "500.00",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(accountPayee.ID)).Times(1).Return(accountPayee, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(friendAccount.ID)).Times(1).Return(friendAccount, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.TransferTxParams) (db.TransferTxResult, error) {
+						require.Equal(t, friendAccount.ID, arg.ToAccountID)
+						return db.TransferTxResult{FromAccount: account, ToAccount: friendAccount}, nil
+					})
+				stubMember(store, friendAccount.ID, user.Username, "")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyHidesToAccount(t, recorder.Body.Bytes())
+			},
+		},
+		{
+			name:   "UserPayee",
+			payee:  userPayee,
+			amount: "5.00",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(userPayee.ID)).Times(1).Return(userPayee, nil)
+				arg := db.GetOwnerAccountParams{Owner: friend.Username, Currency: utils.USD, Type: db.AccountTypeChecking}
+				store.EXPECT().GetOwnerAccount(gomock.Any(), gomock.Eq(arg)).Times(1).Return(friendAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(friendAccount.ID)).Times(1).Return(friendAccount, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.TransferTxResult{FromAccount: account, ToAccount: friendAccount}, nil)
+				stubMember(store, friendAccount.ID, user.Username, "")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyHidesToAccount(t, recorder.Body.Bytes())
+			},
+		},
+		{
+			name:   "UserPayeeWithoutAccount",
+			payee:  userPayee,
+			amount: "5.00",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(userPayee.ID)).Times(1).Return(userPayee, nil)
+				store.EXPECT().GetOwnerAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "CoolingOff",
+			payee:  newPayee,
+			amount: "100.01",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(newPayee.ID)).Times(1).Return(newPayee, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(friendAccount.ID)).Times(1).Return(friendAccount, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.TransferTxResult{}, &db.PayeeCoolingOffError{PayeeID: newPayee.ID, Until: newPayee.CreatedAt.Add(24 * time.Hour)})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "CoolingOffSmallAmount",
+			payee:  newPayee,
+			amount: "100.00",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(newPayee.ID)).Times(1).Return(newPayee, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(friendAccount.ID)).Times(1).Return(friendAccount, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.TransferTxResult{FromAccount: account, ToAccount: friendAccount}, nil)
+				stubMember(store, friendAccount.ID, user.Username, "")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyHidesToAccount(t, recorder.Body.Bytes())
+			},
+		},
+		{
+			name:   "OtherUsersPayee",
+			payee:  db.Payee{ID: 4, Owner: friend.Username},
+			amount: "5.00",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(int64(4))).Times(1).
+					Return(db.Payee{ID: 4, Owner: friend.Username, AccountID: accountPayee.AccountID}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+			tc.buildStubs(store)
+
+			server := newPayeeTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{
+				"from_account_id": account.ID,
+				"payee_id":        tc.payee.ID,
+				"amount":          tc.amount,
+				"currency":        utils.USD,
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+// TestTransferCoolingOffByAccount covers a transfer straight to the account of a new payee, the store
+// refuses it and the refusal is reported like a limit
+func TestTransferCoolingOffByAccount(t *testing.T) {
+	user, _ := randomUser(t)
+	friend, _ := randomUser(t)
+
+	account := randomAccount(user.Username)
+	friendAccount := randomAccount(friend.Username)
+	account.Currency = utils.USD
+	friendAccount.Currency = utils.USD
+
+	limit, err := utils.ParseMoney("100.00", utils.USD)
+	require.NoError(t, err)
+	coolingOffErr := &db.PayeeCoolingOffError{PayeeID: 1, Limit: limit, Until: time.Now().Add(time.Hour)}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "NewPayee",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.TransferTxResult{}, coolingOffErr)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "payee 1 is new, transfers above 100.00 USD are allowed from")
+			},
+		},
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.TransferTxResult{FromAccount: account, ToAccount: friendAccount}, nil)
+				stubMember(store, friendAccount.ID, user.Username, "")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(friendAccount.ID)).Times(1).Return(friendAccount, nil)
+			tc.buildStubs(store)
+
+			server := newPayeeTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{
+				"from_account_id": account.ID,
+				"to_account_id":   friendAccount.ID,
+				"amount":          "100.01",
+				"currency":        utils.USD,
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestTransferDestinationRequired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+
+	for _, body := range []gin.H{
+		{"from_account_id": 1, "amount": "1.00", "currency": utils.USD},
+		{"from_account_id": 1, "to_account_id": 2, "payee_id": 3, "amount": "1.00", "currency": utils.USD},
+	} {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(data))
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "user", time.Minute)
+		server.router.ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprint(body))
+	}
+}
@@ -0,0 +1,243 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/token"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+// errRecipientUnavailable hides why the recipient cannot be paid, whether they exist, have an account
+// in the currency or the account is blocked would all reveal something about them
+var errRecipientUnavailable = errors.New("the recipient cannot receive this payment")
+
+// createPaymentRequest pays a user by username or email on their checking account in the currency
+type createPaymentRequest struct {
+	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
+	Recipient     string `json:"recipient" binding:"required,max=255"`
+	Amount        string `json:"amount" binding:"required"`
+	Currency      string `json:"currency" binding:"required,currency"`
+	Description   string `json:"description" binding:"max=255"`
+	Reference     string `json:"reference" binding:"max=64"`
+	// CreateRecipientAccount opens the checking account of the recipient in the currency when they have none
+	CreateRecipientAccount bool `json:"create_recipient_account"`
+}
+
+// paymentRecipient is all a payer learns about the recipient
+type paymentRecipient struct {
+	DisplayName string `json:"display_name"`
+}
+
+type paymentResponse struct {
+	Status      string           `json:"status"`
+	TransferID  int64            `json:"transfer_id,omitempty"`
+	ApprovalID  int64            `json:"approval_id,omitempty"`
+	Amount      utils.Money      `json:"amount"`
+	Description string           `json:"description"`
+	Reference   string           `json:"reference"`
+	CreatedAt   time.Time        `json:"created_at"`
+	Recipient   paymentRecipient `json:"recipient"`
+	FromAccount *accountResponse `json:"from_account,omitempty"`
+}
+
+const paymentStatusCompleted = "completed"
+
+func (server *Server) createPayment(ctx *gin.Context) {
+	var req createPaymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	amount, err := utils.ParseMoney(req.Amount, req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !amount.IsPositive() {
+		err := fmt.Errorf("amount %s must be positive", amount)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency, db.AccountDebit)
+	if !valid {
+		return
+	}
+	if !server.authorizeAccount(ctx, fromAccount, db.AccountPermissionSpend) {
+		return
+	}
+
+	recipient, valid := server.paymentRecipient(ctx, req.Recipient)
+	if !valid {
+		return
+	}
+	toAccount, valid := server.recipientAccount(ctx, recipient, req.Currency, req.CreateRecipientAccount)
+	if !valid {
+		return
+	}
+
+	rsp := paymentResponse{
+		Amount:      amount,
+		Description: req.Description,
+		Reference:   req.Reference,
+		Recipient:   paymentRecipient{DisplayName: recipient.FullName},
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.TransferTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        amount.Amount(),
+		Description:   req.Description,
+		Reference:     req.Reference,
+		InitiatedBy:   authPayload.Username,
+	}
+
+	hold, valid := server.approvalHold(ctx, fromAccount, amount)
+	if !valid {
+		return
+	}
+
+	// an account that is not open yet is opened in the transaction of the transfer or of its approval
+	if toAccount.ID == 0 {
+		newAccount := db.PayNewAccountTxParams{
+			Owner:    recipient.Username,
+			Currency: req.Currency,
+			Transfer: arg,
+		}
+		if hold {
+			approval := server.approvalRequest(ctx, arg)
+			newAccount.Hold = &approval
+		}
+		paid, err := server.store.PayNewAccountTx(ctx, newAccount)
+		if errors.Is(err, db.ErrApprovalRequired) {
+			// the store has the last word on the approval threshold
+			if !server.approverAvailable(ctx, fromAccount) {
+				return
+			}
+			approval := server.approvalRequest(ctx, arg)
+			newAccount.Hold = &approval
+			paid, err = server.store.PayNewAccountTx(ctx, newAccount)
+		}
+		if err != nil {
+			paymentError(ctx, err)
+			return
+		}
+		if paid.Approval != nil {
+			rsp.Status = paid.Approval.Status
+			rsp.ApprovalID = paid.Approval.ID
+			rsp.CreatedAt = paid.Approval.CreatedAt
+			ctx.JSON(http.StatusAccepted, rsp)
+			return
+		}
+		server.completedPayment(ctx, rsp, *paid.Transfer)
+		return
+	}
+
+	if !hold {
+		result, err := server.store.TransferTx(ctx, arg)
+		if err == nil {
+			server.completedPayment(ctx, rsp, result)
+			return
+		}
+		// the store has the last word on the approval threshold
+		if !errors.Is(err, db.ErrApprovalRequired) {
+			paymentError(ctx, err)
+			return
+		}
+		if !server.approverAvailable(ctx, fromAccount) {
+			return
+		}
+	}
+
+	approval, valid := server.holdTransfer(ctx, arg)
+	if !valid {
+		return
+	}
+	rsp.Status = approval.Status
+	rsp.ApprovalID = approval.ID
+	rsp.CreatedAt = approval.CreatedAt
+	ctx.JSON(http.StatusAccepted, rsp)
+}
+
+// paymentError replies with the error of a failed payment, status errors name the account and
+// the recipient's must stay hidden
+func paymentError(ctx *gin.Context, err error) {
+	if errors.Is(err, db.ErrAccountClosed) || errors.Is(err, db.ErrAccountStatusViolation) {
+		ctx.JSON(http.StatusForbidden, errorResponse(errRecipientUnavailable))
+		return
+	}
+	transferTxError(ctx, err)
+}
+
+// completedPayment replies with the payment once the transfer went through
+func (server *Server) completedPayment(ctx *gin.Context, rsp paymentResponse, result db.TransferTxResult) {
+	from, err := newAccountResponse(result.FromAccount)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	rsp.Status = paymentStatusCompleted
+	rsp.TransferID = result.Transfer.ID
+	rsp.CreatedAt = result.Transfer.CreatedAt
+	rsp.FromAccount = &from
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// findUser finds a user by email when the value looks like one, by username otherwise
+func (server *Server) findUser(ctx *gin.Context, value string) (db.User, error) {
+	if strings.Contains(value, "@") {
+		return server.store.GetUserByEmail(ctx, value)
+	}
+	return server.store.GetUser(ctx, value)
+}
+
+// paymentRecipient finds the user a payment goes to, an unknown user is unavailable like any other recipient
+func (server *Server) paymentRecipient(ctx *gin.Context, recipient string) (db.User, bool) {
+	user, err := server.findUser(ctx, recipient)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(errRecipientUnavailable))
+			return user, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return user, false
+	}
+	return user, true
+}
+
+// recipientAccount returns the checking account of the recipient in the currency. A recipient without
+// one cannot be paid unless the payer asked to open it, the account returned then has no id and is
+// opened with the payment.
+func (server *Server) recipientAccount(ctx *gin.Context, recipient db.User, currency string, open bool) (db.Account, bool) {
+	account, err := server.store.GetOwnerAccount(ctx, db.GetOwnerAccountParams{
+		Owner:    recipient.Username,
+		Currency: currency,
+		Type:     db.AccountTypeChecking,
+	})
+	if err == sql.ErrNoRows && open {
+		return db.Account{}, true
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(errRecipientUnavailable))
+			return account, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return account, false
+	}
+
+	if err := db.CheckAccountStatus(account, db.AccountCredit); err != nil {
+		ctx.JSON(http.StatusForbidden, errorResponse(errRecipientUnavailable))
+		return account, false
+	}
+	return account, true
+}
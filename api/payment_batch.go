@@ -0,0 +1,483 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/iso20022"
+	"github.com/lordofthemind/backendMasterGo/token"
+)
+
+// maxPaymentBatchSize bounds the size of an uploaded pain.001 file
+const maxPaymentBatchSize = 5 << 20
+
+// batchRejection is why a transaction of a payment batch was not executed, as an ISO 20022 reason code
+type batchRejection struct {
+	code string
+	err  error
+}
+
+func (r *batchRejection) Error() string {
+	return r.err.Error()
+}
+
+func reject(code string, format string, args ...interface{}) error {
+	return &batchRejection{code: code, err: fmt.Errorf(format, args...)}
+}
+
+type paymentBatchTransactionResponse struct {
+	ID              int64         `json:"id"`
+	PaymentInfoID   string        `json:"payment_info_id"`
+	InstructionID   string        `json:"instruction_id"`
+	EndToEndID      string        `json:"end_to_end_id"`
+	DebtorAccount   string        `json:"debtor_account"`
+	CreditorAccount string        `json:"creditor_account"`
+	Amount          string        `json:"amount"`
+	Currency        string        `json:"currency"`
+	Status          string        `json:"status"`
+	ReasonCode      string        `json:"reason_code"`
+	Reason          string        `json:"reason"`
+	TransferID      sql.NullInt64 `json:"transfer_id"`
+	ApprovalID      sql.NullInt64 `json:"approval_id"`
+	CreatedAt       time.Time     `json:"created_at"`
+}
+
+// newPaymentBatchTransactionResponse reports a transaction held for approval with the outcome of the approval
+func newPaymentBatchTransactionResponse(transaction db.ListPaymentBatchTransactionsRow) paymentBatchTransactionResponse {
+	rsp := paymentBatchTransactionResponse{
+		ID:              transaction.ID,
+		PaymentInfoID:   transaction.PaymentInfoID,
+		InstructionID:   transaction.InstructionID,
+		EndToEndID:      transaction.EndToEndID,
+		DebtorAccount:   transaction.DebtorAccount,
+		CreditorAccount: transaction.CreditorAccount,
+		Amount:          transaction.Amount,
+		Currency:        transaction.Currency,
+		Status:          transaction.Status,
+		ReasonCode:      transaction.ReasonCode,
+		Reason:          transaction.Reason,
+		TransferID:      transaction.TransferID,
+		ApprovalID:      transaction.ApprovalID,
+		CreatedAt:       transaction.CreatedAt,
+	}
+
+	if transaction.Status == db.PaymentBatchStatusPending {
+		switch transaction.ApprovalStatus {
+		case db.ApprovalStatusApproved:
+			rsp.Status = iso20022.StatusSettled
+			rsp.TransferID = transaction.ApprovalTransferID
+		case db.ApprovalStatusRejected:
+			rsp.Status = iso20022.StatusRejected
+			rsp.ReasonCode = iso20022.ReasonNarrative
+			rsp.Reason = "the transfer was rejected by the approver"
+		case db.ApprovalStatusExpired:
+			rsp.Status = iso20022.StatusRejected
+			rsp.ReasonCode = iso20022.ReasonNarrative
+			rsp.Reason = "the transfer was not approved in time"
+		}
+	}
+	return rsp
+}
+
+type paymentBatchResponse struct {
+	db.PaymentBatch
+	Status       string                            `json:"status"`
+	Transactions []paymentBatchTransactionResponse `json:"transactions"`
+}
+
+func (server *Server) paymentBatchResponse(ctx *gin.Context, batch db.PaymentBatch) (paymentBatchResponse, error) {
+	rsp := paymentBatchResponse{PaymentBatch: batch}
+
+	transactions, err := server.store.ListPaymentBatchTransactions(ctx, batch.ID)
+	if err != nil {
+		return rsp, err
+	}
+
+	statuses := make([]string, len(transactions))
+	rsp.Transactions = make([]paymentBatchTransactionResponse, len(transactions))
+	for i, transaction := range transactions {
+		rsp.Transactions[i] = newPaymentBatchTransactionResponse(transaction)
+		statuses[i] = rsp.Transactions[i].Status
+	}
+	rsp.Status = iso20022.GroupStatus(statuses)
+	return rsp, nil
+}
+
+// createPaymentBatch imports a pain.001 credit transfer initiation. Every transaction is executed, held for
+// approval or rejected on its own, a rejected transaction does not hold back the rest of the file,
+// neither does one that failed on an internal error.
+// A file is imported once, its message identification cannot be used again.
+func (server *Server) createPaymentBatch(ctx *gin.Context) {
+	message, err := iso20022.ParsePain001(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxPaymentBatchSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	count := 0
+	for _, payment := range message.PaymentInformation {
+		count += len(payment.Transactions)
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	batch, err := server.store.CreatePaymentBatch(ctx, db.CreatePaymentBatchParams{
+		Owner:            authPayload.Username,
+		MessageID:        message.GroupHeader.MessageID,
+		MessageName:      iso20022.MessageName(message.Namespace),
+		MessageCreatedAt: message.GroupHeader.CreatedAt(),
+		InitiatingParty:  message.GroupHeader.InitiatingParty.Name,
+		Transactions:     int64(count),
+		ControlSum:       message.GroupHeader.ControlSum,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			err := fmt.Errorf("payment file %s was already imported", message.GroupHeader.MessageID)
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	endToEndIDs := make(map[string]bool)
+	for _, payment := range message.PaymentInformation {
+		for _, transaction := range payment.Transactions {
+			if err := server.executeBatchTransaction(ctx, batch, payment, transaction, endToEndIDs); err != nil {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+		}
+	}
+
+	rsp, err := server.paymentBatchResponse(ctx, batch)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// executeBatchTransaction executes or holds a transaction of the batch and records the outcome, a transaction
+// that failed on an internal error was not executed and is recorded as rejected. It only fails when the outcome
+// cannot be recorded.
+func (server *Server) executeBatchTransaction(
+	ctx *gin.Context,
+	batch db.PaymentBatch,
+	payment iso20022.PaymentInformation,
+	transaction iso20022.CreditTransferTransaction,
+	endToEndIDs map[string]bool,
+) error {
+	record := db.CreatePaymentBatchTransactionParams{
+		BatchID:       batch.ID,
+		PaymentInfoID: payment.ID,
+		InstructionID: transaction.PaymentID.InstructionID,
+		EndToEndID:    transaction.PaymentID.EndToEndID,
+		DebtorAccount: payment.DebtorAccount.ID.String(),
+		Amount:        transaction.Amount.Value().Value,
+		Currency:      transaction.Amount.Value().Currency,
+	}
+	if transaction.CreditorAccount != nil {
+		record.CreditorAccount = transaction.CreditorAccount.ID.String()
+	}
+
+	arg, hold, err := server.batchTransfer(ctx, batch, payment, transaction, endToEndIDs)
+	if err == nil {
+		execute := db.ExecutePaymentBatchTransactionTxParams{
+			Transaction: record,
+			Transfer:    arg,
+		}
+		if hold {
+			hold := server.approvalRequest(ctx, arg)
+			execute.Hold = &hold
+		}
+
+		_, err = server.store.ExecutePaymentBatchTransactionTx(ctx, execute)
+		if err == nil {
+			return nil
+		}
+		err = transferRejection(err)
+	}
+
+	var rejection *batchRejection
+	if !errors.As(err, &rejection) {
+		rejection = &batchRejection{code: iso20022.ReasonNarrative, err: errors.New("the transaction could not be processed")}
+	}
+
+	record.Status = db.PaymentBatchStatusRejected
+	record.ReasonCode = rejection.code
+	record.Reason = rejection.Error()
+	_, err = server.store.CreatePaymentBatchTransaction(ctx, record)
+	return err
+}
+
+// batchTransfer checks a transaction of the batch the way createTransfer checks a request and reports whether
+// it is held for approval, a transaction that cannot be executed is a batchRejection
+func (server *Server) batchTransfer(
+	ctx *gin.Context,
+	batch db.PaymentBatch,
+	payment iso20022.PaymentInformation,
+	transaction iso20022.CreditTransferTransaction,
+	endToEndIDs map[string]bool,
+) (db.TransferTxParams, bool, error) {
+	var arg db.TransferTxParams
+
+	endToEndID := transaction.PaymentID.EndToEndID
+	if endToEndID != iso20022.NotProvided {
+		if endToEndIDs[endToEndID] {
+			return arg, false, reject(iso20022.ReasonDuplicate, "end to end identification %s is used by another transaction of the file", endToEndID)
+		}
+		endToEndIDs[endToEndID] = true
+	}
+
+	day, err := payment.RequestedExecutionDate.Day()
+	if err != nil {
+		return arg, false, reject(iso20022.ReasonInvalidDate, "invalid requested execution date: %s", err)
+	}
+	if day.After(time.Now().UTC()) {
+		return arg, false, reject(iso20022.ReasonInvalidDate, "transfers are executed on import, the execution date %s is in the future", day.Format(time.DateOnly))
+	}
+
+	if transaction.Amount.Instructed == nil {
+		return arg, false, reject(iso20022.ReasonNotAllowedCurrency, "currency conversion is not supported, give the instructed amount")
+	}
+	currency := transaction.Amount.Instructed.Currency
+	if !server.currencies.IsEnabled(currency) {
+		return arg, false, reject(iso20022.ReasonNotAllowedCurrency, "currency %s is not supported", currency)
+	}
+	amount, err := iso20022.ParseAmount(transaction.Amount.Instructed.Value, currency)
+	if err != nil {
+		return arg, false, reject(iso20022.ReasonInvalidAmount, "%s", err)
+	}
+	if !amount.IsPositive() {
+		return arg, false, reject(iso20022.ReasonInvalidAmount, "amount %s must be positive", amount)
+	}
+	if payment.DebtorAccount.Currency != "" && payment.DebtorAccount.Currency != currency {
+		return arg, false, reject(iso20022.ReasonNotAllowedCurrency, "debtor account currency %s does not match the amount in %s", payment.DebtorAccount.Currency, currency)
+	}
+
+	fromAccount, err := server.batchAccount(ctx, payment.DebtorAccount.ID, currency, db.AccountDebit)
+	if err != nil {
+		return arg, false, err
+	}
+	if status, err := server.accountAccess(ctx, fromAccount, db.AccountPermissionSpend); err != nil {
+		if status == http.StatusInternalServerError {
+			return arg, false, err
+		}
+		return arg, false, &batchRejection{code: iso20022.ReasonForbidden, err: err}
+	}
+
+	if transaction.CreditorAccount == nil {
+		return arg, false, reject(iso20022.ReasonIncorrectAccount, "the creditor account is missing")
+	}
+	toAccount, err := server.batchAccount(ctx, transaction.CreditorAccount.ID, currency, db.AccountCredit)
+	if err != nil {
+		return arg, false, err
+	}
+
+	hold, err := server.holdForApproval(ctx, fromAccount, amount, batch.Owner)
+	if err != nil {
+		if errors.Is(err, errNoApprover) {
+			return arg, false, &batchRejection{code: iso20022.ReasonNotAllowedAmount, err: err}
+		}
+		return arg, false, err
+	}
+
+	metadata, err := json.Marshal(map[string]string{
+		"payment_batch_id": strconv.FormatInt(batch.ID, 10),
+		"message_id":       batch.MessageID,
+		"payment_info_id":  payment.ID,
+		"end_to_end_id":    endToEndID,
+	})
+	if err != nil {
+		return arg, false, err
+	}
+
+	arg = db.TransferTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        amount.Amount(),
+		Description:   iso20022.Truncate(transaction.RemittanceInformation.String(), 255),
+		Metadata:      metadata,
+		InitiatedBy:   batch.Owner,
+	}
+	if endToEndID != iso20022.NotProvided {
+		arg.Reference = endToEndID
+	}
+	return arg, hold, nil
+}
+
+// batchAccount finds the account of a transaction and checks it the way validAccount does
+func (server *Server) batchAccount(ctx *gin.Context, id iso20022.AccountIdentification, currency string, operation string) (db.Account, error) {
+	number, err := id.AccountNumber()
+	if err != nil {
+		return db.Account{}, &batchRejection{code: iso20022.ReasonIncorrectAccount, err: err}
+	}
+
+	account, err := server.store.GetAccount(ctx, number)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return account, reject(iso20022.ReasonIncorrectAccount, "account %d does not exist", number)
+		}
+		return account, err
+	}
+	if err := db.CheckAccountStatus(account, operation); err != nil {
+		return account, transferRejection(fmt.Errorf("account %d: %w", number, err))
+	}
+	if account.Currency != currency {
+		return account, reject(iso20022.ReasonNotAllowedCurrency, "account %d does not support currency %s", number, currency)
+	}
+	return account, nil
+}
+
+// transferRejection turns the errors transferTxError forbids into rejections, the transaction of a failed transfer
+// was rolled back so any other error rejects it as well
+func transferRejection(err error) error {
+	var limitErr *db.LimitExceededError
+	var coolingOffErr *db.PayeeCoolingOffError
+	switch {
+	case errors.As(err, &limitErr), errors.As(err, &coolingOffErr), errors.Is(err, db.ErrApprovalRequired):
+		return &batchRejection{code: iso20022.ReasonNotAllowedAmount, err: err}
+	case errors.Is(err, db.ErrAccountClosed):
+		return &batchRejection{code: iso20022.ReasonClosedAccount, err: err}
+	case errors.Is(err, db.ErrAccountStatusViolation):
+		return &batchRejection{code: iso20022.ReasonBlockedAccount, err: err}
+	}
+	return &batchRejection{code: iso20022.ReasonNarrative, err: errors.New("the transfer could not be executed")}
+}
+
+type listPaymentBatchesRequest struct {
+	Limit int32 `form:"limit,default=50" binding:"min=1,max=200"`
+}
+
+// listPaymentBatches returns the payment files imported by the authenticated user, newest first
+func (server *Server) listPaymentBatches(ctx *gin.Context) {
+	var req listPaymentBatchesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	batches, err := server.store.ListPaymentBatches(ctx, db.ListPaymentBatchesParams{
+		Owner: authPayload.Username,
+		Limit: req.Limit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, batches)
+}
+
+type getPaymentBatchRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// authorizedPaymentBatch returns the batch when it was imported by the authenticated user
+func (server *Server) authorizedPaymentBatch(ctx *gin.Context) (db.PaymentBatch, bool) {
+	var req getPaymentBatchRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.PaymentBatch{}, false
+	}
+
+	batch, err := server.store.GetPaymentBatch(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return batch, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return batch, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if batch.Owner != authPayload.Username {
+		err := fmt.Errorf("payment batch %d doesn't belong to the authenticated user", batch.ID)
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return batch, false
+	}
+	return batch, true
+}
+
+// getPaymentBatch returns the batch with the current status of every transaction
+func (server *Server) getPaymentBatch(ctx *gin.Context) {
+	batch, valid := server.authorizedPaymentBatch(ctx)
+	if !valid {
+		return
+	}
+
+	rsp, err := server.paymentBatchResponse(ctx, batch)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// getPaymentBatchStatusReport returns the pain.002 status report of the batch as it stands,
+// transactions held for approval are reported settled or rejected once they are decided
+func (server *Server) getPaymentBatchStatusReport(ctx *gin.Context) {
+	batch, valid := server.authorizedPaymentBatch(ctx)
+	if !valid {
+		return
+	}
+
+	rsp, err := server.paymentBatchResponse(ctx, batch)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	now := time.Now()
+	report := iso20022.StatusReport{
+		MessageID:           fmt.Sprintf("STS-%d-%d", batch.ID, now.Unix()),
+		CreatedAt:           now,
+		OriginalMessageID:   batch.MessageID,
+		OriginalMessageName: batch.MessageName,
+		OriginalCreatedAt:   batch.MessageCreatedAt,
+	}
+
+	// the transactions are listed in file order, so each payment information block is contiguous
+	for _, transaction := range rsp.Transactions {
+		if n := len(report.Payments); n == 0 || report.Payments[n-1].PaymentInformationID != transaction.PaymentInfoID {
+			report.Payments = append(report.Payments, iso20022.PaymentStatus{PaymentInformationID: transaction.PaymentInfoID})
+		}
+
+		status := iso20022.TransactionStatus{
+			InstructionID:         transaction.InstructionID,
+			EndToEndID:            transaction.EndToEndID,
+			Status:                transaction.Status,
+			Reason:                transaction.ReasonCode,
+			AdditionalInformation: transaction.Reason,
+		}
+		if transaction.TransferID.Valid {
+			status.AccountServicerReference = strconv.FormatInt(transaction.TransferID.Int64, 10)
+		}
+
+		payment := &report.Payments[len(report.Payments)-1]
+		payment.Transactions = append(payment.Transactions, status)
+	}
+
+	var buf bytes.Buffer
+	if err := iso20022.WritePain002(&buf, report); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="pain002-%d.xml"`, batch.ID))
+	ctx.Data(http.StatusOK, "application/xml", buf.Bytes())
+}
@@ -0,0 +1,738 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/iso20022"
+	"github.com/lordofthemind/backendMasterGo/token"
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// testPain001 pays from account 10 of the user and account 30 of someone else, to the open account 20,
+// the closed account 21 and the missing account 22
+const testPain001 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09">
+  <CstmrCdtTrfInitn>
+    <GrpHdr>
+      <MsgId>PAYROLL-1</MsgId>
+      <CreDtTm>2026-01-05T08:00:00Z</CreDtTm>
+      <NbOfTxs>10</NbOfTxs>
+      <CtrlSum>2646.99</CtrlSum>
+      <InitgPty><Nm>Acme Corp</Nm></InitgPty>
+    </GrpHdr>
+    <PmtInf>
+      <PmtInfId>P-1</PmtInfId>
+      <PmtMtd>TRF</PmtMtd>
+      <ReqdExctnDt><Dt>2026-01-05</Dt></ReqdExctnDt>
+      <Dbtr><Nm>Acme Corp</Nm></Dbtr>
+      <DbtrAcct><Id><Othr><Id>10</Id></Othr></Id></DbtrAcct>
+      <DbtrAgt><FinInstnId/></DbtrAgt>
+      <CdtTrfTxInf>
+        <PmtId><InstrId>I-1</InstrId><EndToEndId>E2E-1</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">100.00</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>20</Id></Othr></Id></CdtrAcct>
+        <RmtInf><Ustrd>Salary January</Ustrd></RmtInf>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-1</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">5.00</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>20</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-3</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">1500.00</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>20</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-4</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">10.00</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>21</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-5</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">10.00</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>22</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-6</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">10.00</InstdAmt></Amt>
+        <CdtrAcct><Id><IBAN>DE89370400440532013000</IBAN></Id></CdtrAcct>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-7</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="EUR">10.00</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>20</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-8</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">999.99</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>20</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+    <PmtInf>
+      <PmtInfId>P-2</PmtInfId>
+      <PmtMtd>TRF</PmtMtd>
+      <ReqdExctnDt><Dt>2026-01-05</Dt></ReqdExctnDt>
+      <Dbtr><Nm>Someone Else</Nm></Dbtr>
+      <DbtrAcct><Id><Othr><Id>30</Id></Othr></Id></DbtrAcct>
+      <DbtrAgt><FinInstnId/></DbtrAgt>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-9</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">1.00</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>20</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+    <PmtInf>
+      <PmtInfId>P-3</PmtInfId>
+      <PmtMtd>TRF</PmtMtd>
+      <ReqdExctnDt><Dt>2999-01-01</Dt></ReqdExctnDt>
+      <Dbtr><Nm>Acme Corp</Nm></Dbtr>
+      <DbtrAcct><Id><Othr><Id>10</Id></Othr></Id></DbtrAcct>
+      <DbtrAgt><FinInstnId/></DbtrAgt>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-10</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">1.00</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>20</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>
+`
+
+func batchAccount(id int64, owner string, status string) db.Account {
+	return db.Account{
+		ID:       id,
+		Owner:    owner,
+		Balance:  1000000,
+		Currency: utils.USD,
+		Status:   status,
+		Type:     db.AccountTypeChecking,
+	}
+}
+
+// batchTransactionRow is a recorded transaction as ListPaymentBatchTransactions returns it
+func batchTransactionRow(id int64, arg db.CreatePaymentBatchTransactionParams) db.ListPaymentBatchTransactionsRow {
+	return db.ListPaymentBatchTransactionsRow{
+		ID:              id,
+		BatchID:         arg.BatchID,
+		PaymentInfoID:   arg.PaymentInfoID,
+		InstructionID:   arg.InstructionID,
+		EndToEndID:      arg.EndToEndID,
+		DebtorAccount:   arg.DebtorAccount,
+		CreditorAccount: arg.CreditorAccount,
+		Amount:          arg.Amount,
+		Currency:        arg.Currency,
+		Status:          arg.Status,
+		ReasonCode:      arg.ReasonCode,
+		Reason:          arg.Reason,
+		TransferID:      arg.TransferID,
+		ApprovalID:      arg.ApprovalID,
+	}
+}
+
+func TestCreatePaymentBatchAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	batch := db.PaymentBatch{
+		ID:               7,
+		Owner:            user.Username,
+		MessageID:        "PAYROLL-1",
+		MessageName:      "pain.001.001.09",
+		MessageCreatedAt: time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC),
+		InitiatingParty:  "Acme Corp",
+		Transactions:     10,
+		ControlSum:       "2646.99",
+	}
+	accounts := map[int64]db.Account{
+		10: batchAccount(10, user.Username, db.AccountStatusActive),
+		20: batchAccount(20, "recipient", db.AccountStatusActive),
+		21: batchAccount(21, "recipient", db.AccountStatusClosed),
+		30: batchAccount(30, "someone_else", db.AccountStatusActive),
+	}
+
+	testCases := []struct {
+		name          string
+		body          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: testPain001,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePaymentBatch(gomock.Any(), gomock.Eq(db.CreatePaymentBatchParams{
+					Owner:            batch.Owner,
+					MessageID:        batch.MessageID,
+					MessageName:      batch.MessageName,
+					MessageCreatedAt: batch.MessageCreatedAt,
+					InitiatingParty:  batch.InitiatingParty,
+					Transactions:     batch.Transactions,
+					ControlSum:       batch.ControlSum,
+				})).Times(1).Return(batch, nil)
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).AnyTimes().
+					DoAndReturn(func(_ context.Context, id int64) (db.Account, error) {
+						account, ok := accounts[id]
+						if !ok {
+							return account, sql.ErrNoRows
+						}
+						return account, nil
+					})
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{AccountID: 10, Username: user.Username})).
+					Times(6).Return(randomMember(10, user.Username, db.MemberRoleOwner), nil)
+				stubMember(store, 30, user.Username, "")
+				stubAccountMembers(store, 10,
+					randomMember(10, user.Username, db.MemberRoleOwner),
+					randomMember(10, "co_owner", db.MemberRoleOwner))
+
+				var recorded []db.ListPaymentBatchTransactionsRow
+				store.EXPECT().ExecutePaymentBatchTransactionTx(gomock.Any(), gomock.Any()).Times(3).
+					DoAndReturn(func(_ context.Context, arg db.ExecutePaymentBatchTransactionTxParams) (db.ExecutePaymentBatchTransactionTxResult, error) {
+						require.Equal(t, int64(10), arg.Transfer.FromAccountID)
+						require.Equal(t, int64(20), arg.Transfer.ToAccountID)
+						require.Equal(t, arg.Transaction.EndToEndID, arg.Transfer.Reference)
+
+						var metadata map[string]string
+						require.NoError(t, json.Unmarshal(arg.Transfer.Metadata, &metadata))
+						require.Equal(t, "7", metadata["payment_batch_id"])
+						require.Equal(t, "P-1", metadata["payment_info_id"])
+
+						transaction := arg.Transaction
+						switch arg.Transfer.Amount {
+						case 99999:
+							return db.ExecutePaymentBatchTransactionTxResult{}, &db.LimitExceededError{Requested: arg.Transfer.Amount}
+						case 150000:
+							require.NotNil(t, arg.Hold)
+							require.Equal(t, user.Username, arg.Hold.InitiatedBy)
+							require.WithinDuration(t, time.Now().Add(time.Hour), arg.Hold.ExpiresAt, time.Minute)
+							transaction.Status = db.PaymentBatchStatusPending
+							transaction.ApprovalID = sql.NullInt64{Int64: 3, Valid: true}
+						default:
+							require.Nil(t, arg.Hold)
+							require.Equal(t, "Salary January", arg.Transfer.Description)
+							transaction.Status = db.PaymentBatchStatusSettled
+							transaction.TransferID = sql.NullInt64{Int64: 41, Valid: true}
+						}
+						recorded = append(recorded, batchTransactionRow(int64(len(recorded)+1), transaction))
+						return db.ExecutePaymentBatchTransactionTxResult{}, nil
+					})
+				store.EXPECT().CreatePaymentBatchTransaction(gomock.Any(), gomock.Any()).Times(8).
+					DoAndReturn(func(_ context.Context, arg db.CreatePaymentBatchTransactionParams) (db.PaymentBatchTransaction, error) {
+						require.Equal(t, batch.ID, arg.BatchID)
+						require.Equal(t, db.PaymentBatchStatusRejected, arg.Status)
+						require.NotEmpty(t, arg.Reason)
+						recorded = append(recorded, batchTransactionRow(int64(len(recorded)+1), arg))
+						return db.PaymentBatchTransaction{}, nil
+					})
+				store.EXPECT().ListPaymentBatchTransactions(gomock.Any(), gomock.Eq(batch.ID)).Times(1).
+					DoAndReturn(func(_ context.Context, _ int64) ([]db.ListPaymentBatchTransactionsRow, error) {
+						return recorded, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp paymentBatchResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, batch.ID, rsp.ID)
+				require.Equal(t, iso20022.StatusPartial, rsp.Status)
+
+				outcomes := make(map[string]string)
+				for _, transaction := range rsp.Transactions {
+					outcome := transaction.Status
+					if transaction.ReasonCode != "" {
+						outcome += " " + transaction.ReasonCode
+					}
+					// the duplicate end to end identification is told apart by its amount
+					outcomes[transaction.EndToEndID+" "+transaction.Amount] = outcome
+				}
+				require.Equal(t, map[string]string{
+					"E2E-1 100.00":  iso20022.StatusSettled,
+					"E2E-1 5.00":    "RJCT AM05",
+					"E2E-3 1500.00": iso20022.StatusPending,
+					"E2E-4 10.00":   "RJCT AC04",
+					"E2E-5 10.00":   "RJCT AC01",
+					"E2E-6 10.00":   "RJCT AC01",
+					"E2E-7 10.00":   "RJCT AM03",
+					"E2E-8 999.99":  "RJCT AM02",
+					"E2E-9 1.00":    "RJCT AG01",
+					"E2E-10 1.00":   "RJCT DT01",
+				}, outcomes)
+			},
+		},
+		{
+			name: "InvalidFile",
+			body: strings.Replace(testPain001, "<NbOfTxs>10</NbOfTxs>", "<NbOfTxs>9</NbOfTxs>", 1),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePaymentBatch(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "number of transactions 9 does not match the 10 transactions")
+			},
+		},
+		{
+			name: "TooLarge",
+			body: testPain001 + strings.Repeat(" ", maxPaymentBatchSize),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePaymentBatch(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyImported",
+			body: testPain001,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePaymentBatch(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.PaymentBatch{}, &pq.Error{Code: "23505"})
+				store.EXPECT().ExecutePaymentBatchTransactionTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreatePaymentBatchTransaction(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "payment file PAYROLL-1 was already imported")
+			},
+		},
+		{
+			name:      "NoAuthorization",
+			body:      testPain001,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePaymentBatch(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newApprovalTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/payment-batches", strings.NewReader(tc.body))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/xml")
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+// testPain001Accounts pays from account 10 to the accounts 20, 23 and 20
+const testPain001Accounts = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09">
+  <CstmrCdtTrfInitn>
+    <GrpHdr>
+      <MsgId>PAYROLL-3</MsgId>
+      <CreDtTm>2026-01-05T08:00:00Z</CreDtTm>
+      <NbOfTxs>3</NbOfTxs>
+      <CtrlSum>30.00</CtrlSum>
+      <InitgPty><Nm>Acme Corp</Nm></InitgPty>
+    </GrpHdr>
+    <PmtInf>
+      <PmtInfId>P-1</PmtInfId>
+      <PmtMtd>TRF</PmtMtd>
+      <ReqdExctnDt><Dt>2026-01-05</Dt></ReqdExctnDt>
+      <Dbtr><Nm>Acme Corp</Nm></Dbtr>
+      <DbtrAcct><Id><Othr><Id>10</Id></Othr></Id></DbtrAcct>
+      <DbtrAgt><FinInstnId/></DbtrAgt>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-1</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">10.00</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>20</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-2</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">10.00</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>23</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-3</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">10.00</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>20</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>
+`
+
+func TestCreatePaymentBatchInternalError(t *testing.T) {
+	user, _ := randomUser(t)
+	batch := db.PaymentBatch{ID: 8, Owner: user.Username, MessageID: "PAYROLL-3", Transactions: 3, ControlSum: "30.00"}
+	accounts := map[int64]db.Account{
+		10: batchAccount(10, user.Username, db.AccountStatusActive),
+		20: batchAccount(20, "recipient", db.AccountStatusActive),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().CreatePaymentBatch(gomock.Any(), gomock.Any()).Times(1).Return(batch, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, id int64) (db.Account, error) {
+			account, ok := accounts[id]
+			if !ok {
+				// the store fails while the second transaction is checked
+				return account, sql.ErrConnDone
+			}
+			return account, nil
+		})
+	store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{AccountID: 10, Username: user.Username})).
+		Times(3).Return(randomMember(10, user.Username, db.MemberRoleOwner), nil)
+
+	var recorded []db.ListPaymentBatchTransactionsRow
+	store.EXPECT().ExecutePaymentBatchTransactionTx(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, arg db.ExecutePaymentBatchTransactionTxParams) (db.ExecutePaymentBatchTransactionTxResult, error) {
+			transaction := arg.Transaction
+			transaction.Status = db.PaymentBatchStatusSettled
+			transaction.TransferID = sql.NullInt64{Int64: int64(40 + len(recorded)), Valid: true}
+			recorded = append(recorded, batchTransactionRow(int64(len(recorded)+1), transaction))
+			return db.ExecutePaymentBatchTransactionTxResult{}, nil
+		})
+	store.EXPECT().CreatePaymentBatchTransaction(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreatePaymentBatchTransactionParams) (db.PaymentBatchTransaction, error) {
+			require.Equal(t, "E2E-2", arg.EndToEndID)
+			require.Equal(t, db.PaymentBatchStatusRejected, arg.Status)
+			require.Equal(t, iso20022.ReasonNarrative, arg.ReasonCode)
+			require.NotContains(t, arg.Reason, sql.ErrConnDone.Error())
+			recorded = append(recorded, batchTransactionRow(int64(len(recorded)+1), arg))
+			return db.PaymentBatchTransaction{}, nil
+		})
+	store.EXPECT().ListPaymentBatchTransactions(gomock.Any(), gomock.Eq(batch.ID)).Times(1).
+		DoAndReturn(func(_ context.Context, _ int64) ([]db.ListPaymentBatchTransactionsRow, error) {
+			return recorded, nil
+		})
+
+	server := newApprovalTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodPost, "/payment-batches", strings.NewReader(testPain001Accounts))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/xml")
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var rsp paymentBatchResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.Equal(t, iso20022.StatusPartial, rsp.Status)
+
+	outcomes := make(map[string]string)
+	for _, transaction := range rsp.Transactions {
+		outcomes[transaction.EndToEndID] = strings.TrimSpace(transaction.Status + " " + transaction.ReasonCode)
+	}
+	require.Equal(t, map[string]string{
+		"E2E-1": iso20022.StatusSettled,
+		"E2E-2": "RJCT NARR",
+		"E2E-3": iso20022.StatusSettled,
+	}, outcomes)
+}
+
+func TestCreatePaymentBatchPayeeCoolingOff(t *testing.T) {
+	user, _ := randomUser(t)
+	batch := db.PaymentBatch{ID: 8, Owner: user.Username, MessageID: "PAYROLL-3", Transactions: 3, ControlSum: "30.00"}
+	accounts := map[int64]db.Account{
+		10: batchAccount(10, user.Username, db.AccountStatusActive),
+		20: batchAccount(20, "recipient", db.AccountStatusActive),
+		23: batchAccount(23, "new_payee", db.AccountStatusActive),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().CreatePaymentBatch(gomock.Any(), gomock.Any()).Times(1).Return(batch, nil)
+	stubPayoutAccounts(store, accounts, 6)
+	store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{AccountID: 10, Username: user.Username})).
+		Times(3).Return(randomMember(10, user.Username, db.MemberRoleOwner), nil)
+
+	var recorded []db.ListPaymentBatchTransactionsRow
+	store.EXPECT().ExecutePaymentBatchTransactionTx(gomock.Any(), gomock.Any()).Times(3).
+		DoAndReturn(func(_ context.Context, arg db.ExecutePaymentBatchTransactionTxParams) (db.ExecutePaymentBatchTransactionTxResult, error) {
+			require.Equal(t, user.Username, arg.Transfer.InitiatedBy)
+			if arg.Transfer.ToAccountID == 23 {
+				return db.ExecutePaymentBatchTransactionTxResult{}, &db.PayeeCoolingOffError{PayeeID: 4, Until: time.Now().Add(time.Hour)}
+			}
+			transaction := arg.Transaction
+			transaction.Status = db.PaymentBatchStatusSettled
+			recorded = append(recorded, batchTransactionRow(int64(len(recorded)+1), transaction))
+			return db.ExecutePaymentBatchTransactionTxResult{}, nil
+		})
+	store.EXPECT().CreatePaymentBatchTransaction(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreatePaymentBatchTransactionParams) (db.PaymentBatchTransaction, error) {
+			require.Equal(t, "E2E-2", arg.EndToEndID)
+			require.Equal(t, db.PaymentBatchStatusRejected, arg.Status)
+			require.Equal(t, iso20022.ReasonNotAllowedAmount, arg.ReasonCode)
+			require.Contains(t, arg.Reason, "payee 4 is new")
+			recorded = append(recorded, batchTransactionRow(int64(len(recorded)+1), arg))
+			return db.PaymentBatchTransaction{}, nil
+		})
+	store.EXPECT().ListPaymentBatchTransactions(gomock.Any(), gomock.Eq(batch.ID)).Times(1).
+		DoAndReturn(func(_ context.Context, _ int64) ([]db.ListPaymentBatchTransactionsRow, error) {
+			return recorded, nil
+		})
+
+	server := newApprovalTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodPost, "/payment-batches", strings.NewReader(testPain001Accounts))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/xml")
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var rsp paymentBatchResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.Equal(t, iso20022.StatusPartial, rsp.Status)
+	require.Len(t, rsp.Transactions, 3)
+}
+
+func TestGetPaymentBatchAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	batch := db.PaymentBatch{ID: 7, Owner: user.Username, MessageID: "PAYROLL-1", MessageName: "pain.001.001.09"}
+
+	transactions := []db.ListPaymentBatchTransactionsRow{
+		{ID: 1, BatchID: batch.ID, PaymentInfoID: "P-1", EndToEndID: "E2E-1", Status: db.PaymentBatchStatusSettled,
+			TransferID: sql.NullInt64{Int64: 41, Valid: true}},
+		{ID: 2, BatchID: batch.ID, PaymentInfoID: "P-1", EndToEndID: "E2E-2", Status: db.PaymentBatchStatusPending,
+			ApprovalID: sql.NullInt64{Int64: 3, Valid: true}, ApprovalStatus: db.ApprovalStatusApproved,
+			ApprovalTransferID: sql.NullInt64{Int64: 52, Valid: true}},
+		{ID: 3, BatchID: batch.ID, PaymentInfoID: "P-1", EndToEndID: "E2E-3", Status: db.PaymentBatchStatusPending,
+			ApprovalID: sql.NullInt64{Int64: 4, Valid: true}, ApprovalStatus: db.ApprovalStatusExpired},
+		{ID: 4, BatchID: batch.ID, PaymentInfoID: "P-2", EndToEndID: "E2E-4", Status: db.PaymentBatchStatusPending,
+			ApprovalID: sql.NullInt64{Int64: 5, Valid: true}, ApprovalStatus: db.ApprovalStatusPending},
+	}
+
+	testCases := []struct {
+		name          string
+		batchID       int64
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			batchID:  batch.ID,
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+				store.EXPECT().ListPaymentBatchTransactions(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(transactions, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp paymentBatchResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, iso20022.StatusPartial, rsp.Status)
+				require.Len(t, rsp.Transactions, 4)
+
+				// approved transfers are settled by the transfer of the approval
+				require.Equal(t, iso20022.StatusSettled, rsp.Transactions[1].Status)
+				require.Equal(t, int64(52), rsp.Transactions[1].TransferID.Int64)
+				require.Equal(t, iso20022.StatusRejected, rsp.Transactions[2].Status)
+				require.Equal(t, iso20022.ReasonNarrative, rsp.Transactions[2].ReasonCode)
+				require.Equal(t, iso20022.StatusPending, rsp.Transactions[3].Status)
+			},
+		},
+		{
+			name:     "NotFound",
+			batchID:  batch.ID,
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(db.PaymentBatch{}, sql.ErrNoRows)
+				store.EXPECT().ListPaymentBatchTransactions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			batchID:  batch.ID,
+			username: "unauthorized_user",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+				store.EXPECT().ListPaymentBatchTransactions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidID",
+			batchID:  0,
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentBatch(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/payment-batches/%d", tc.batchID), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+
+	t.Run("StatusReport", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetPaymentBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+		store.EXPECT().ListPaymentBatchTransactions(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(transactions, nil)
+
+		server := newTestServer(t, store)
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/payment-batches/%d/status-report", batch.ID), nil)
+		require.NoError(t, err)
+
+		addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+		server.router.ServeHTTP(recorder, request)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, "application/xml", recorder.Header().Get("Content-Type"))
+		require.Equal(t, `attachment; filename="pain002-7.xml"`, recorder.Header().Get("Content-Disposition"))
+
+		namespace, err := iso20022.Pain002.Validate(bytes.NewReader(recorder.Body.Bytes()))
+		require.NoError(t, err)
+		require.Equal(t, iso20022.Pain002V10, namespace)
+
+		report := recorder.Body.String()
+		require.Contains(t, report, "<OrgnlMsgId>PAYROLL-1</OrgnlMsgId>")
+		require.Contains(t, report, "<GrpSts>PART</GrpSts>")
+		require.Equal(t, 2, strings.Count(report, "<OrgnlPmtInfAndSts>"))
+		require.Contains(t, report, "<AcctSvcrRef>52</AcctSvcrRef>")
+		require.Contains(t, report, "<PmtInfSts>PDNG</PmtInfSts>")
+	})
+}
+
+func TestListPaymentBatchesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	batches := []db.PaymentBatch{
+		{ID: 8, Owner: user.Username, MessageID: "PAYROLL-2"},
+		{ID: 7, Owner: user.Username, MessageID: "PAYROLL-1"},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPaymentBatches(gomock.Any(), gomock.Eq(db.ListPaymentBatchesParams{
+					Owner: user.Username,
+					Limit: 50,
+				})).Times(1).Return(batches, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp []db.PaymentBatch
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, batches, rsp)
+			},
+		},
+		{
+			name:  "InvalidLimit",
+			query: "?limit=500",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPaymentBatches(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/payment-batches"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,526 @@
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image/png"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/token"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+const (
+	// a token is the link id followed by the first paymentLinkMACSize bytes of its HMAC-SHA256
+	paymentLinkMACSize = 16
+
+	// paymentLinkExpired is shown for active links past their expiry
+	paymentLinkExpired = "expired"
+)
+
+const (
+	// paymentQRGUI names this service in the merchant account template of the QR payload
+	paymentQRGUI     = "com.lordofthemind.backendmastergo"
+	paymentQRSize    = 256
+	defaultQRCountry = "US"
+	defaultQRCity    = "Online"
+)
+
+var errPaymentLinkNotFound = errors.New("payment link not found")
+
+// paymentLinkToken signs the link id, links can only be reached through the token
+func (server *Server) paymentLinkToken(linkID int64) string {
+	data := make([]byte, 8, 8+paymentLinkMACSize)
+	binary.BigEndian.PutUint64(data, uint64(linkID))
+	return base64.RawURLEncoding.EncodeToString(append(data, server.paymentLinkMAC(data)...))
+}
+
+// parsePaymentLinkToken returns the id of the link the token was signed for
+func (server *Server) parsePaymentLinkToken(s string) (int64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(data) != 8+paymentLinkMACSize {
+		return 0, errPaymentLinkNotFound
+	}
+	if !hmac.Equal(data[8:], server.paymentLinkMAC(data[:8])) {
+		return 0, errPaymentLinkNotFound
+	}
+	return int64(binary.BigEndian.Uint64(data[:8])), nil
+}
+
+func (server *Server) paymentLinkMAC(data []byte) []byte {
+	mac := hmac.New(sha256.New, server.paymentLinkKey)
+	mac.Write(data)
+	return mac.Sum(nil)[:paymentLinkMACSize]
+}
+
+// paymentLinkURL is the public address of the link, on PAYMENT_LINK_BASE_URL or the host of the request
+func (server *Server) paymentLinkURL(ctx *gin.Context, linkToken string) string {
+	base := server.config.PaymentLinkBaseURL
+	if base == "" {
+		scheme := "http"
+		if ctx.Request.TLS != nil {
+			scheme = "https"
+		}
+		base = scheme + "://" + ctx.Request.Host
+	}
+	return strings.TrimRight(base, "/") + "/pay/" + linkToken
+}
+
+// paymentLinkStatus is the status of the link, an active link past its expiry is expired
+func paymentLinkStatus(link db.PaymentLink) string {
+	if link.Status == db.PaymentLinkStatusActive && link.ExpiresAt.Valid && !link.ExpiresAt.Time.After(time.Now()) {
+		return paymentLinkExpired
+	}
+	return link.Status
+}
+
+// paymentLinkAmount is the fixed amount of the link, nil when the payer chooses it
+func paymentLinkAmount(link db.PaymentLink) (*utils.Money, error) {
+	if !link.Amount.Valid {
+		return nil, nil
+	}
+	amount, err := utils.NewMoney(link.Amount.Int64, link.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return &amount, nil
+}
+
+type paymentLinkResponse struct {
+	ID          int64        `json:"id"`
+	ToAccountID int64        `json:"to_account_id"`
+	Amount      *utils.Money `json:"amount,omitempty"`
+	Currency    string       `json:"currency"`
+	Description string       `json:"description"`
+	SingleUse   bool         `json:"single_use"`
+	Status      string       `json:"status"`
+	ExpiresAt   sql.NullTime `json:"expires_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	Token       string       `json:"token"`
+	URL         string       `json:"url"`
+	QRURL       string       `json:"qr_url"`
+}
+
+func (server *Server) newPaymentLinkResponse(ctx *gin.Context, link db.PaymentLink) (paymentLinkResponse, error) {
+	amount, err := paymentLinkAmount(link)
+	if err != nil {
+		return paymentLinkResponse{}, err
+	}
+
+	linkToken := server.paymentLinkToken(link.ID)
+	url := server.paymentLinkURL(ctx, linkToken)
+	return paymentLinkResponse{
+		ID:          link.ID,
+		ToAccountID: link.ToAccountID,
+		Amount:      amount,
+		Currency:    link.Currency,
+		Description: link.Description,
+		SingleUse:   link.SingleUse,
+		Status:      paymentLinkStatus(link),
+		ExpiresAt:   link.ExpiresAt,
+		CreatedAt:   link.CreatedAt,
+		Token:       linkToken,
+		URL:         url,
+		QRURL:       url + "/qr",
+	}, nil
+}
+
+// createPaymentLinkRequest creates a link paying into an account the user manages, without an amount the payer chooses it
+type createPaymentLinkRequest struct {
+	ToAccountID int64      `json:"to_account_id" binding:"required,min=1"`
+	Amount      string     `json:"amount"`
+	Currency    string     `json:"currency" binding:"required,currency"`
+	Description string     `json:"description" binding:"max=255"`
+	SingleUse   bool       `json:"single_use"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+func (server *Server) createPaymentLink(ctx *gin.Context) {
+	var req createPaymentLinkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var amount sql.NullInt64
+	if req.Amount != "" {
+		money, err := utils.ParseMoney(req.Amount, req.Currency)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if !money.IsPositive() {
+			err := fmt.Errorf("amount %s must be positive", money)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		amount = sql.NullInt64{Int64: money.Amount(), Valid: true}
+	}
+
+	var expiresAt sql.NullTime
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(time.Now()) {
+			err := errors.New("expires_at must be in the future")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		expiresAt = sql.NullTime{Time: *req.ExpiresAt, Valid: true}
+	}
+
+	toAccount, valid := server.validAccount(ctx, req.ToAccountID, req.Currency, db.AccountCredit)
+	if !valid {
+		return
+	}
+	if !server.authorizeAccount(ctx, toAccount, db.AccountPermissionManage) {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	link, err := server.store.CreatePaymentLink(ctx, db.CreatePaymentLinkParams{
+		Owner:       authPayload.Username,
+		ToAccountID: toAccount.ID,
+		Amount:      amount,
+		Currency:    toAccount.Currency,
+		Description: req.Description,
+		SingleUse:   req.SingleUse,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp, err := server.newPaymentLinkResponse(ctx, link)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+func (server *Server) listPaymentLinks(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	links, err := server.store.ListPaymentLinks(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]paymentLinkResponse, len(links))
+	for i, link := range links {
+		if rsp[i], err = server.newPaymentLinkResponse(ctx, link); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type disablePaymentLinkRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// disablePaymentLink stops an active link from being paid, its token stays valid for previews
+func (server *Server) disablePaymentLink(ctx *gin.Context) {
+	var req disablePaymentLinkRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	link, err := server.store.GetPaymentLink(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if link.Owner != authPayload.Username {
+		err := fmt.Errorf("payment link %d doesn't belong to the authenticated user", req.ID)
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	_, err = server.store.UpdatePaymentLinkStatus(ctx, db.UpdatePaymentLinkStatusParams{
+		ID:     link.ID,
+		Status: db.PaymentLinkStatusDisabled,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(db.ErrPaymentLinkInactive))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+type paymentLinkTokenRequest struct {
+	Token string `uri:"token" binding:"required"`
+}
+
+// paymentLinkPreviewResponse is what anyone holding the token learns about the link
+type paymentLinkPreviewResponse struct {
+	Merchant    paymentRecipient `json:"merchant"`
+	Amount      *utils.Money     `json:"amount,omitempty"`
+	Currency    string           `json:"currency"`
+	Description string           `json:"description"`
+	SingleUse   bool             `json:"single_use"`
+	Status      string           `json:"status"`
+	ExpiresAt   sql.NullTime     `json:"expires_at"`
+}
+
+// previewPaymentLink is public, it shows the link without the account it pays into
+func (server *Server) previewPaymentLink(ctx *gin.Context) {
+	link, owner, valid := server.paymentLink(ctx)
+	if !valid {
+		return
+	}
+
+	amount, err := paymentLinkAmount(link)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, paymentLinkPreviewResponse{
+		Merchant:    paymentRecipient{DisplayName: owner.FullName},
+		Amount:      amount,
+		Currency:    link.Currency,
+		Description: link.Description,
+		SingleUse:   link.SingleUse,
+		Status:      paymentLinkStatus(link),
+		ExpiresAt:   link.ExpiresAt,
+	})
+}
+
+// paymentLinkQR is public, it renders the link as an EMVCo merchant-presented QR code
+func (server *Server) paymentLinkQR(ctx *gin.Context) {
+	link, owner, valid := server.paymentLink(ctx)
+	if !valid {
+		return
+	}
+
+	amount, err := paymentLinkAmount(link)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	country, city := server.config.PaymentQRCountry, server.config.PaymentQRCity
+	if country == "" {
+		country = defaultQRCountry
+	}
+	if city == "" {
+		city = defaultQRCity
+	}
+
+	payload, err := utils.MerchantQR{
+		Reusable:     !link.SingleUse,
+		GUI:          paymentQRGUI,
+		URL:          server.paymentLinkURL(ctx, ctx.Param("token")),
+		Currency:     link.Currency,
+		Amount:       amount,
+		CountryCode:  country,
+		MerchantName: owner.FullName,
+		MerchantCity: city,
+	}.Payload()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	code, err := qr.Encode(payload, qr.M, qr.Auto)
+	if err == nil {
+		code, err = barcode.Scale(code, paymentQRSize, paymentQRSize)
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var image bytes.Buffer
+	if err := png.Encode(&image, code); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.Data(http.StatusOK, "image/png", image.Bytes())
+}
+
+// payPaymentLinkRequest pays a link, the amount is required unless the link fixes it
+type payPaymentLinkRequest struct {
+	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
+	Amount        string `json:"amount"`
+}
+
+func (server *Server) payPaymentLink(ctx *gin.Context) {
+	var req payPaymentLinkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	link, owner, valid := server.paymentLink(ctx)
+	if !valid {
+		return
+	}
+
+	amount, valid := paymentLinkPayAmount(ctx, link, req.Amount)
+	if !valid {
+		return
+	}
+
+	switch paymentLinkStatus(link) {
+	case db.PaymentLinkStatusActive:
+	case paymentLinkExpired:
+		ctx.JSON(http.StatusForbidden, errorResponse(db.ErrPaymentLinkExpired))
+		return
+	default:
+		ctx.JSON(http.StatusForbidden, errorResponse(db.ErrPaymentLinkInactive))
+		return
+	}
+
+	// paying directly would skip the maker-checker approval of large transfers
+	if server.requiresApproval(amount) {
+		err := fmt.Errorf("payment links cannot be paid above %s, send a transfer instead",
+			server.approvalThresholds[amount.Currency()])
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, link.Currency, db.AccountDebit)
+	if !valid {
+		return
+	}
+	if !server.authorizeAccount(ctx, fromAccount, db.AccountPermissionSpend) {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.PayPaymentLinkTx(ctx, db.PayPaymentLinkTxParams{
+		LinkID:        link.ID,
+		Payer:         authPayload.Username,
+		FromAccountID: fromAccount.ID,
+		Amount:        amount.Amount(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrPaymentLinkInactive) || errors.Is(err, db.ErrPaymentLinkExpired) ||
+			errors.Is(err, db.ErrPaymentLinkAmount) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		// status errors name the account, the merchant's must stay hidden
+		if errors.Is(err, db.ErrAccountClosed) || errors.Is(err, db.ErrAccountStatusViolation) {
+			ctx.JSON(http.StatusForbidden, errorResponse(errRecipientUnavailable))
+			return
+		}
+		transferTxError(ctx, err)
+		return
+	}
+
+	from, err := newAccountResponse(result.Transfer.FromAccount)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, paymentResponse{
+		Status:      paymentStatusCompleted,
+		TransferID:  result.Transfer.Transfer.ID,
+		Amount:      amount,
+		Description: result.Transfer.Transfer.Description,
+		Reference:   result.Transfer.Transfer.Reference,
+		CreatedAt:   result.Transfer.Transfer.CreatedAt,
+		Recipient:   paymentRecipient{DisplayName: owner.FullName},
+		FromAccount: &from,
+	})
+}
+
+// paymentLinkPayAmount is the fixed amount of the link or the amount chosen by the payer
+func paymentLinkPayAmount(ctx *gin.Context, link db.PaymentLink, value string) (utils.Money, bool) {
+	fixed, err := paymentLinkAmount(link)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return utils.Money{}, false
+	}
+	if fixed != nil && value == "" {
+		return *fixed, true
+	}
+	if value == "" {
+		err := errors.New("amount is required, the payment link has no fixed amount")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return utils.Money{}, false
+	}
+
+	amount, err := utils.ParseMoney(value, link.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return amount, false
+	}
+	if !amount.IsPositive() {
+		err := fmt.Errorf("amount %s must be positive", amount)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return amount, false
+	}
+	if fixed != nil && amount.Amount() != fixed.Amount() {
+		ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrPaymentLinkAmount))
+		return amount, false
+	}
+	return amount, true
+}
+
+// paymentLink loads the link of the token in the uri and its owner,
+// tokens that were not signed here are not found
+func (server *Server) paymentLink(ctx *gin.Context) (db.PaymentLink, db.User, bool) {
+	var req paymentLinkTokenRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.PaymentLink{}, db.User{}, false
+	}
+
+	linkID, err := server.parsePaymentLinkToken(req.Token)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return db.PaymentLink{}, db.User{}, false
+	}
+
+	link, err := server.store.GetPaymentLink(ctx, linkID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errPaymentLinkNotFound))
+			return link, db.User{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return link, db.User{}, false
+	}
+
+	owner, err := server.store.GetUser(ctx, link.Owner)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return link, owner, false
+	}
+	return link, owner, true
+}
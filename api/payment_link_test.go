@@ -0,0 +1,503 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func randomPaymentLink(owner string, toAccountID int64) db.PaymentLink {
+	return db.PaymentLink{
+		ID:          utils.NewRandomGenerator().RandomInt(1, 1000),
+		Owner:       owner,
+		ToAccountID: toAccountID,
+		Amount:      sql.NullInt64{Int64: 1250, Valid: true},
+		Currency:    utils.USD,
+		Description: "coffee beans",
+		Status:      db.PaymentLinkStatusActive,
+		CreatedAt:   time.Now(),
+	}
+}
+
+func TestPaymentLinkToken(t *testing.T) {
+	server := newTestServer(t, nil)
+
+	linkToken := server.paymentLinkToken(42)
+	id, err := server.parsePaymentLinkToken(linkToken)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), id)
+
+	// a token signed with another key is not found
+	other := newTestServer(t, nil)
+	_, err = other.parsePaymentLinkToken(linkToken)
+	require.ErrorIs(t, err, errPaymentLinkNotFound)
+
+	// nor is a token for another id reusing the signature
+	forged := []byte(linkToken)
+	forged[5] ^= 1
+	_, err = server.parsePaymentLinkToken(string(forged))
+	require.ErrorIs(t, err, errPaymentLinkNotFound)
+
+	_, err = server.parsePaymentLinkToken("not-a-token")
+	require.ErrorIs(t, err, errPaymentLinkNotFound)
+}
+
+func TestCreatePaymentLinkAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	account.Currency = utils.USD
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "FixedAmount",
+			body: gin.H{"amount": "12.50", "single_use": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+
+				arg := db.CreatePaymentLinkParams{
+					Owner:       user.Username,
+					ToAccountID: account.ID,
+					Amount:      sql.NullInt64{Int64: 1250, Valid: true},
+					Currency:    utils.USD,
+					SingleUse:   true,
+				}
+				link := randomPaymentLink(user.Username, account.ID)
+				link.SingleUse = true
+				store.EXPECT().CreatePaymentLink(gomock.Any(), gomock.Eq(arg)).Times(1).Return(link, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp paymentLinkResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.NotEmpty(t, rsp.Token)
+				require.Equal(t, "http://example.com/pay/"+rsp.Token, rsp.URL)
+				require.Equal(t, rsp.URL+"/qr", rsp.QRURL)
+				require.Equal(t, "12.50", rsp.Amount.Decimal())
+			},
+		},
+		{
+			name: "OpenAmount",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+
+				link := randomPaymentLink(user.Username, account.ID)
+				link.Amount = sql.NullInt64{}
+				store.EXPECT().CreatePaymentLink(gomock.Any(), gomock.Any()).Times(1).Return(link, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp map[string]interface{}
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.NotContains(t, rsp, "amount")
+			},
+		},
+		{
+			name: "SpenderCannotCreate",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleSpender)
+				store.EXPECT().CreatePaymentLink(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "ViewerCannotCreate",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleViewer)
+				store.EXPECT().CreatePaymentLink(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "ExpiresInThePast",
+			body: gin.H{"expires_at": time.Now().Add(-time.Hour)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePaymentLink(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body := gin.H{"to_account_id": account.ID, "currency": utils.USD}
+			for key, value := range tc.body {
+				body[key] = value
+			}
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "http://example.com/payment-links", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestPreviewPaymentLinkAPI(t *testing.T) {
+	merchant, _ := randomUser(t)
+	link := randomPaymentLink(merchant.Username, 7)
+
+	testCases := []struct {
+		name          string
+		path          func(server *Server) string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Preview",
+			path: func(server *Server) string {
+				return "/pay/" + server.paymentLinkToken(link.ID)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentLink(gomock.Any(), gomock.Eq(link.ID)).Times(1).Return(link, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(merchant.Username)).Times(1).Return(merchant, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp map[string]interface{}
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, map[string]interface{}{"display_name": merchant.FullName}, rsp["merchant"])
+				require.Equal(t, db.PaymentLinkStatusActive, rsp["status"])
+				require.NotContains(t, rsp, "to_account_id")
+			},
+		},
+		{
+			name: "QR",
+			path: func(server *Server) string {
+				return "/pay/" + server.paymentLinkToken(link.ID) + "/qr"
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentLink(gomock.Any(), gomock.Eq(link.ID)).Times(1).Return(link, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(merchant.Username)).Times(1).Return(merchant, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+
+				image, err := png.Decode(recorder.Body)
+				require.NoError(t, err)
+				require.Equal(t, paymentQRSize, image.Bounds().Dx())
+			},
+		},
+		{
+			name: "ForgedToken",
+			path: func(server *Server) string {
+				return "/pay/" + newTestServer(t, nil).paymentLinkToken(link.ID)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentLink(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// no authorization, previews are public
+			request, err := http.NewRequest(http.MethodGet, tc.path(server), nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestPayPaymentLinkAPI(t *testing.T) {
+	merchant, _ := randomUser(t)
+	payer, _ := randomUser(t)
+
+	account := randomAccount(payer.Username)
+	account.Currency = utils.USD
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		link          func() db.PaymentLink
+		buildStubs    func(store *mockdb.MockStore, link db.PaymentLink)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "FixedAmount",
+			body: gin.H{},
+			link: func() db.PaymentLink {
+				return randomPaymentLink(merchant.Username, 7)
+			},
+			buildStubs: func(store *mockdb.MockStore, link db.PaymentLink) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, payer.Username, db.MemberRoleOwner)
+
+				arg := db.PayPaymentLinkTxParams{
+					LinkID:        link.ID,
+					Payer:         payer.Username,
+					FromAccountID: account.ID,
+					Amount:        1250,
+				}
+				store.EXPECT().PayPaymentLinkTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.PayPaymentLinkTxResult{
+						Link: link,
+						Transfer: db.TransferTxResult{
+							Transfer:    db.Transfer{ID: 5, FromAccountID: account.ID, ToAccountID: 7, Amount: 1250},
+							FromAccount: account,
+						},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp paymentResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, int64(5), rsp.TransferID)
+				require.Equal(t, merchant.FullName, rsp.Recipient.DisplayName)
+				require.NotContains(t, recorder.Body.String(), "to_account")
+			},
+		},
+		{
+			name: "AmountDiffersFromFixed",
+			body: gin.H{"amount": "1.00"},
+			link: func() db.PaymentLink {
+				return randomPaymentLink(merchant.Username, 7)
+			},
+			buildStubs: func(store *mockdb.MockStore, link db.PaymentLink) {
+				store.EXPECT().PayPaymentLinkTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "OpenAmountMissing",
+			body: gin.H{},
+			link: func() db.PaymentLink {
+				link := randomPaymentLink(merchant.Username, 7)
+				link.Amount = sql.NullInt64{}
+				return link
+			},
+			buildStubs: func(store *mockdb.MockStore, link db.PaymentLink) {
+				store.EXPECT().PayPaymentLinkTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Used",
+			body: gin.H{},
+			link: func() db.PaymentLink {
+				link := randomPaymentLink(merchant.Username, 7)
+				link.SingleUse = true
+				link.Status = db.PaymentLinkStatusUsed
+				return link
+			},
+			buildStubs: func(store *mockdb.MockStore, link db.PaymentLink) {
+				store.EXPECT().PayPaymentLinkTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "UsedConcurrently",
+			body: gin.H{},
+			link: func() db.PaymentLink {
+				link := randomPaymentLink(merchant.Username, 7)
+				link.SingleUse = true
+				return link
+			},
+			buildStubs: func(store *mockdb.MockStore, link db.PaymentLink) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, payer.Username, db.MemberRoleOwner)
+				store.EXPECT().PayPaymentLinkTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.PayPaymentLinkTxResult{}, db.ErrPaymentLinkInactive)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Expired",
+			body: gin.H{},
+			link: func() db.PaymentLink {
+				link := randomPaymentLink(merchant.Username, 7)
+				link.ExpiresAt = sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+				return link
+			},
+			buildStubs: func(store *mockdb.MockStore, link db.PaymentLink) {
+				store.EXPECT().PayPaymentLinkTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), db.ErrPaymentLinkExpired.Error())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			link := tc.link()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetPaymentLink(gomock.Any(), gomock.Eq(link.ID)).Times(1).Return(link, nil)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(merchant.Username)).Times(1).Return(merchant, nil)
+			tc.buildStubs(store, link)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body := gin.H{"from_account_id": account.ID}
+			for key, value := range tc.body {
+				body[key] = value
+			}
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+
+			url := "/pay/" + server.paymentLinkToken(link.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, payer.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDisablePaymentLinkAPI(t *testing.T) {
+	merchant, _ := randomUser(t)
+	other, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore, link db.PaymentLink)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: merchant.Username,
+			buildStubs: func(store *mockdb.MockStore, link db.PaymentLink) {
+				arg := db.UpdatePaymentLinkStatusParams{ID: link.ID, Status: db.PaymentLinkStatusDisabled}
+				store.EXPECT().UpdatePaymentLinkStatus(gomock.Any(), gomock.Eq(arg)).Times(1).Return(link, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+		{
+			name:     "NotOwner",
+			username: other.Username,
+			buildStubs: func(store *mockdb.MockStore, link db.PaymentLink) {
+				store.EXPECT().UpdatePaymentLinkStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "NotActive",
+			username: merchant.Username,
+			buildStubs: func(store *mockdb.MockStore, link db.PaymentLink) {
+				store.EXPECT().UpdatePaymentLinkStatus(gomock.Any(), gomock.Any()).Times(1).Return(db.PaymentLink{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			link := randomPaymentLink(merchant.Username, 7)
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetPaymentLink(gomock.Any(), gomock.Eq(link.ID)).Times(1).Return(link, nil)
+			tc.buildStubs(store, link)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/payment-links/%d", link.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
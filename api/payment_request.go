@@ -0,0 +1,414 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/token"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+// defaultPaymentRequestExpiry applies when PAYMENT_REQUEST_EXPIRY is not configured
+const defaultPaymentRequestExpiry = 7 * 24 * time.Hour
+
+const (
+	paymentRequestsIncoming = "incoming"
+	paymentRequestsOutgoing = "outgoing"
+
+	paymentRequestRequester = "requester"
+	paymentRequestPayer     = "payer"
+)
+
+type paymentRequestResponse struct {
+	ID        int64  `json:"id"`
+	Requester string `json:"requester"`
+	Payer     string `json:"payer"`
+	// ToAccountID is only shown to the requester
+	ToAccountID *int64        `json:"to_account_id,omitempty"`
+	Amount      utils.Money   `json:"amount"`
+	Description string        `json:"description"`
+	Status      string        `json:"status"`
+	TransferID  sql.NullInt64 `json:"transfer_id"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+	DecidedAt   sql.NullTime  `json:"decided_at"`
+}
+
+// newPaymentRequestResponse shows the request to viewer, a pending request past its expiry is shown as expired
+func newPaymentRequestResponse(request db.PaymentRequest, viewer string) (paymentRequestResponse, error) {
+	amount, err := utils.NewMoney(request.Amount, request.Currency)
+	if err != nil {
+		return paymentRequestResponse{}, err
+	}
+
+	rsp := paymentRequestResponse{
+		ID:          request.ID,
+		Requester:   request.Requester,
+		Payer:       request.Payer,
+		Amount:      amount,
+		Description: request.Description,
+		Status:      request.Status,
+		TransferID:  request.TransferID,
+		ExpiresAt:   request.ExpiresAt,
+		CreatedAt:   request.CreatedAt,
+		DecidedAt:   request.DecidedAt,
+	}
+	if viewer == request.Requester {
+		rsp.ToAccountID = &request.ToAccountID
+	}
+	if paymentRequestExpired(request) {
+		rsp.Status = db.PaymentRequestStatusExpired
+	}
+	return rsp, nil
+}
+
+// paymentRequestExpired reports whether the request is still pending past its expiry
+func paymentRequestExpired(request db.PaymentRequest) bool {
+	return request.Status == db.PaymentRequestStatusPending && !request.ExpiresAt.After(time.Now())
+}
+
+// createPaymentRequestRequest asks the payer, by username or email, to pay the amount into an account of the requester
+type createPaymentRequestRequest struct {
+	Payer       string `json:"payer" binding:"required,max=255"`
+	ToAccountID int64  `json:"to_account_id" binding:"required,min=1"`
+	Amount      string `json:"amount" binding:"required"`
+	Currency    string `json:"currency" binding:"required,currency"`
+	Description string `json:"description" binding:"max=255"`
+}
+
+func (server *Server) createPaymentRequest(ctx *gin.Context) {
+	var req createPaymentRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	amount, err := utils.ParseMoney(req.Amount, req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !amount.IsPositive() {
+		err := fmt.Errorf("amount %s must be positive", amount)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	toAccount, valid := server.validAccount(ctx, req.ToAccountID, req.Currency, db.AccountCredit)
+	if !valid {
+		return
+	}
+	if !server.authorizeAccount(ctx, toAccount, db.AccountPermissionView) {
+		return
+	}
+	if !server.allowPaymentRequestAmount(ctx, amount) {
+		return
+	}
+
+	payer, err := server.findUser(ctx, req.Payer)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := fmt.Errorf("user %q not found", req.Payer)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if payer.Username == authPayload.Username {
+		err := errors.New("cannot request a payment from yourself")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	expiry := server.config.PaymentRequestExpiry
+	if expiry <= 0 {
+		expiry = defaultPaymentRequestExpiry
+	}
+
+	request, err := server.store.CreatePaymentRequest(ctx, db.CreatePaymentRequestParams{
+		Requester:   authPayload.Username,
+		Payer:       payer.Username,
+		ToAccountID: toAccount.ID,
+		Amount:      amount.Amount(),
+		Currency:    amount.Currency(),
+		Description: req.Description,
+		ExpiresAt:   time.Now().Add(expiry),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp, err := newPaymentRequestResponse(request, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type listPaymentRequestsRequest struct {
+	Direction string `form:"direction" binding:"required,oneof=incoming outgoing"`
+}
+
+// listPaymentRequests lists the requests the user has to pay (incoming) or has sent (outgoing), newest first
+func (server *Server) listPaymentRequests(ctx *gin.Context) {
+	var req listPaymentRequestsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	var requests []db.PaymentRequest
+	var err error
+	if req.Direction == paymentRequestsIncoming {
+		requests, err = server.store.ListIncomingPaymentRequests(ctx, authPayload.Username)
+	} else {
+		requests, err = server.store.ListOutgoingPaymentRequests(ctx, authPayload.Username)
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]paymentRequestResponse, len(requests))
+	for i, request := range requests {
+		if rsp[i], err = newPaymentRequestResponse(request, authPayload.Username); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type getPaymentRequestRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getPaymentRequest(ctx *gin.Context) {
+	var req getPaymentRequestRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	request, valid := server.authorizedPaymentRequest(ctx, req.ID, "")
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	rsp, err := newPaymentRequestResponse(request, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type acceptPaymentRequestRequest struct {
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+}
+
+type acceptPaymentRequestResponse struct {
+	Request     paymentRequestResponse `json:"request"`
+	FromAccount accountResponse        `json:"from_account"`
+}
+
+// acceptPaymentRequest pays a pending request addressed to the user from one of their accounts
+func (server *Server) acceptPaymentRequest(ctx *gin.Context) {
+	var uri getPaymentRequestRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req acceptPaymentRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	request, valid := server.authorizedPaymentRequest(ctx, uri.ID, paymentRequestPayer)
+	if !valid {
+		return
+	}
+	if request.Status != db.PaymentRequestStatusPending {
+		ctx.JSON(http.StatusForbidden, errorResponse(db.ErrPaymentRequestNotPending))
+		return
+	}
+
+	amount, err := utils.NewMoney(request.Amount, request.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	// the threshold may have been lowered since the request was made
+	if !server.allowPaymentRequestAmount(ctx, amount) {
+		return
+	}
+
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, request.Currency, db.AccountDebit)
+	if !valid {
+		return
+	}
+	if !server.authorizeAccount(ctx, fromAccount, db.AccountPermissionSpend) {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.PayPaymentRequestTx(ctx, db.PayPaymentRequestTxParams{
+		RequestID:     request.ID,
+		Payer:         authPayload.Username,
+		FromAccountID: fromAccount.ID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrPaymentRequestNotPending) || errors.Is(err, db.ErrPaymentRequestExpired) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		transferTxError(ctx, err)
+		return
+	}
+
+	var rsp acceptPaymentRequestResponse
+	if rsp.Request, err = newPaymentRequestResponse(result.Request, authPayload.Username); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rsp.FromAccount, err = newAccountResponse(result.Transfer.FromAccount); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// declinePaymentRequest lets the payer refuse a pending request
+func (server *Server) declinePaymentRequest(ctx *gin.Context) {
+	server.closePaymentRequest(ctx, db.PaymentRequestStatusDeclined)
+}
+
+// cancelPaymentRequest lets the requester withdraw a pending request
+func (server *Server) cancelPaymentRequest(ctx *gin.Context) {
+	server.closePaymentRequest(ctx, db.PaymentRequestStatusCancelled)
+}
+
+// closePaymentRequest moves a pending request to status without paying it,
+// declining is up to the payer and cancelling up to the requester
+func (server *Server) closePaymentRequest(ctx *gin.Context, status string) {
+	var uri getPaymentRequestRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	role := paymentRequestPayer
+	if status == db.PaymentRequestStatusCancelled {
+		role = paymentRequestRequester
+	}
+
+	request, valid := server.authorizedPaymentRequest(ctx, uri.ID, role)
+	if !valid {
+		return
+	}
+	if request.Status != db.PaymentRequestStatusPending {
+		ctx.JSON(http.StatusForbidden, errorResponse(db.ErrPaymentRequestNotPending))
+		return
+	}
+
+	// the expiry is recorded so the request stops showing as pending anywhere
+	expired := paymentRequestExpired(request)
+	if expired {
+		status = db.PaymentRequestStatusExpired
+	}
+
+	request, err := server.store.DecidePaymentRequest(ctx, db.DecidePaymentRequestParams{
+		ID:     request.ID,
+		Status: status,
+	})
+	if err != nil {
+		// answered since it was read
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(db.ErrPaymentRequestNotPending))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if expired {
+		ctx.JSON(http.StatusForbidden, errorResponse(db.ErrPaymentRequestExpired))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	rsp, err := newPaymentRequestResponse(request, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// authorizedPaymentRequest loads the request and checks the user is its requester or payer,
+// or exactly the given role when one is set
+func (server *Server) authorizedPaymentRequest(ctx *gin.Context, requestID int64, role string) (db.PaymentRequest, bool) {
+	request, err := server.store.GetPaymentRequest(ctx, requestID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return request, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return request, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	isRequester := request.Requester == authPayload.Username
+	isPayer := request.Payer == authPayload.Username
+
+	var allowed bool
+	switch role {
+	case paymentRequestRequester:
+		allowed = isRequester
+	case paymentRequestPayer:
+		allowed = isPayer
+	default:
+		allowed = isRequester || isPayer
+	}
+	if !allowed {
+		err := fmt.Errorf("payment request %d is not addressed to the authenticated user", requestID)
+		if role == paymentRequestRequester {
+			err = fmt.Errorf("payment request %d wasn't sent by the authenticated user", requestID)
+		}
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return request, false
+	}
+	return request, true
+}
+
+// allowPaymentRequestAmount replies with an error when the amount is above the approval threshold,
+// paying a request directly would skip the maker-checker approval of large transfers
+func (server *Server) allowPaymentRequestAmount(ctx *gin.Context, amount utils.Money) bool {
+	if server.requiresApproval(amount) {
+		err := fmt.Errorf("payment requests above %s are not allowed, send a transfer instead",
+			server.approvalThresholds[amount.Currency()])
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return false
+	}
+	return true
+}
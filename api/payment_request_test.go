@@ -0,0 +1,469 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func randomPaymentRequest(requester, payer string, toAccountID int64) db.PaymentRequest {
+	return db.PaymentRequest{
+		ID:          utils.NewRandomGenerator().RandomInt(1, 1000),
+		Requester:   requester,
+		Payer:       payer,
+		ToAccountID: toAccountID,
+		Amount:      2500,
+		Currency:    utils.USD,
+		Description: "dinner",
+		Status:      db.PaymentRequestStatusPending,
+		ExpiresAt:   time.Now().Add(time.Hour),
+		CreatedAt:   time.Now(),
+	}
+}
+
+func TestCreatePaymentRequestAPI(t *testing.T) {
+	requester, _ := randomUser(t)
+	payer, _ := randomUser(t)
+
+	account := randomAccount(requester.Username)
+	account.Currency = utils.USD
+
+	testCases := []struct {
+		name          string
+		payer         string
+		amount        string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			payer:  payer.Email,
+			amount: "25.00",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(payer.Email)).Times(1).Return(payer, nil)
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreatePaymentRequestParams) (db.PaymentRequest, error) {
+						require.Equal(t, requester.Username, arg.Requester)
+						require.Equal(t, payer.Username, arg.Payer)
+						require.Equal(t, account.ID, arg.ToAccountID)
+						require.Equal(t, int64(2500), arg.Amount)
+						require.WithinDuration(t, time.Now().Add(defaultPaymentRequestExpiry), arg.ExpiresAt, time.Minute)
+						return randomPaymentRequest(arg.Requester, arg.Payer, arg.ToAccountID), nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp paymentRequestResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, db.PaymentRequestStatusPending, rsp.Status)
+				require.NotNil(t, rsp.ToAccountID)
+				require.Equal(t, account.ID, *rsp.ToAccountID)
+			},
+		},
+		{
+			name:   "AboveApprovalThreshold",
+			payer:  payer.Email,
+			amount: "1000.01",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Self",
+			payer:  requester.Username,
+			amount: "25.00",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(requester.Username)).Times(1).Return(requester, nil)
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "UnknownPayer",
+			payer:  "nobody",
+			amount: "25.00",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq("nobody")).Times(1).Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			stubMember(store, account.ID, requester.Username, db.MemberRoleOwner)
+			tc.buildStubs(store)
+
+			server := newApprovalTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{
+				"payer":         tc.payer,
+				"to_account_id": account.ID,
+				"amount":        tc.amount,
+				"currency":      utils.USD,
+				"description":   "dinner",
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/payment-requests", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, requester.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListPaymentRequestsAPI(t *testing.T) {
+	requester, _ := randomUser(t)
+	payer, _ := randomUser(t)
+
+	paymentRequest := randomPaymentRequest(requester.Username, payer.Username, 7)
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Incoming",
+			query: "direction=incoming",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListIncomingPaymentRequests(gomock.Any(), gomock.Eq(payer.Username)).Times(1).
+					Return([]db.PaymentRequest{paymentRequest}, nil)
+				store.EXPECT().ListOutgoingPaymentRequests(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp []paymentRequestResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Len(t, rsp, 1)
+				// the account of the requester is not shown to the payer
+				require.Nil(t, rsp[0].ToAccountID)
+			},
+		},
+		{
+			name:  "InvalidDirection",
+			query: "direction=sideways",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListIncomingPaymentRequests(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListOutgoingPaymentRequests(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/payment-requests?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, payer.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestAcceptPaymentRequestAPI(t *testing.T) {
+	requester, _ := randomUser(t)
+	payer, _ := randomUser(t)
+
+	account := randomAccount(payer.Username)
+	account.Currency = utils.USD
+
+	testCases := []struct {
+		name          string
+		username      string
+		request       func() db.PaymentRequest
+		buildStubs    func(store *mockdb.MockStore, paymentRequest db.PaymentRequest)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: payer.Username,
+			request: func() db.PaymentRequest {
+				return randomPaymentRequest(requester.Username, payer.Username, 7)
+			},
+			buildStubs: func(store *mockdb.MockStore, paymentRequest db.PaymentRequest) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, payer.Username, db.MemberRoleOwner)
+
+				arg := db.PayPaymentRequestTxParams{
+					RequestID:     paymentRequest.ID,
+					Payer:         payer.Username,
+					FromAccountID: account.ID,
+				}
+				paid := paymentRequest
+				paid.Status = db.PaymentRequestStatusPaid
+				paid.TransferID = sql.NullInt64{Int64: 9, Valid: true}
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.PayPaymentRequestTxResult{
+						Request:  paid,
+						Transfer: db.TransferTxResult{FromAccount: account},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp acceptPaymentRequestResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, db.PaymentRequestStatusPaid, rsp.Request.Status)
+				require.Equal(t, int64(9), rsp.Request.TransferID.Int64)
+				require.Equal(t, account.ID, rsp.FromAccount.ID)
+			},
+		},
+		{
+			name:     "Requester",
+			username: requester.Username,
+			request: func() db.PaymentRequest {
+				return randomPaymentRequest(requester.Username, payer.Username, 7)
+			},
+			buildStubs: func(store *mockdb.MockStore, paymentRequest db.PaymentRequest) {
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "AlreadyPaid",
+			username: payer.Username,
+			request: func() db.PaymentRequest {
+				paymentRequest := randomPaymentRequest(requester.Username, payer.Username, 7)
+				paymentRequest.Status = db.PaymentRequestStatusPaid
+				return paymentRequest
+			},
+			buildStubs: func(store *mockdb.MockStore, paymentRequest db.PaymentRequest) {
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "PaidConcurrently",
+			username: payer.Username,
+			request: func() db.PaymentRequest {
+				return randomPaymentRequest(requester.Username, payer.Username, 7)
+			},
+			buildStubs: func(store *mockdb.MockStore, paymentRequest db.PaymentRequest) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, payer.Username, db.MemberRoleOwner)
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.PayPaymentRequestTxResult{}, db.ErrPaymentRequestNotPending)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "AboveApprovalThreshold",
+			username: payer.Username,
+			request: func() db.PaymentRequest {
+				paymentRequest := randomPaymentRequest(requester.Username, payer.Username, 7)
+				paymentRequest.Amount = 100001
+				return paymentRequest
+			},
+			buildStubs: func(store *mockdb.MockStore, paymentRequest db.PaymentRequest) {
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			paymentRequest := tc.request()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(paymentRequest.ID)).Times(1).Return(paymentRequest, nil)
+			tc.buildStubs(store, paymentRequest)
+
+			server := newApprovalTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"from_account_id": account.ID})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/payment-requests/%d/accept", paymentRequest.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestClosePaymentRequestAPI(t *testing.T) {
+	requester, _ := randomUser(t)
+	payer, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		action        string
+		username      string
+		expiresAt     time.Time
+		buildStubs    func(store *mockdb.MockStore, paymentRequest db.PaymentRequest)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "Decline",
+			action:    "decline",
+			username:  payer.Username,
+			expiresAt: time.Now().Add(time.Hour),
+			buildStubs: func(store *mockdb.MockStore, paymentRequest db.PaymentRequest) {
+				arg := db.DecidePaymentRequestParams{ID: paymentRequest.ID, Status: db.PaymentRequestStatusDeclined}
+				declined := paymentRequest
+				declined.Status = db.PaymentRequestStatusDeclined
+				store.EXPECT().DecidePaymentRequest(gomock.Any(), gomock.Eq(arg)).Times(1).Return(declined, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp paymentRequestResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, db.PaymentRequestStatusDeclined, rsp.Status)
+			},
+		},
+		{
+			name:      "Cancel",
+			action:    "cancel",
+			username:  requester.Username,
+			expiresAt: time.Now().Add(time.Hour),
+			buildStubs: func(store *mockdb.MockStore, paymentRequest db.PaymentRequest) {
+				arg := db.DecidePaymentRequestParams{ID: paymentRequest.ID, Status: db.PaymentRequestStatusCancelled}
+				cancelled := paymentRequest
+				cancelled.Status = db.PaymentRequestStatusCancelled
+				store.EXPECT().DecidePaymentRequest(gomock.Any(), gomock.Eq(arg)).Times(1).Return(cancelled, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "PayerCannotCancel",
+			action:    "cancel",
+			username:  payer.Username,
+			expiresAt: time.Now().Add(time.Hour),
+			buildStubs: func(store *mockdb.MockStore, paymentRequest db.PaymentRequest) {
+				store.EXPECT().DecidePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "Expired",
+			action:    "decline",
+			username:  payer.Username,
+			expiresAt: time.Now().Add(-time.Minute),
+			buildStubs: func(store *mockdb.MockStore, paymentRequest db.PaymentRequest) {
+				arg := db.DecidePaymentRequestParams{ID: paymentRequest.ID, Status: db.PaymentRequestStatusExpired}
+				store.EXPECT().DecidePaymentRequest(gomock.Any(), gomock.Eq(arg)).Times(1).Return(paymentRequest, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), db.ErrPaymentRequestExpired.Error())
+			},
+		},
+		{
+			name:      "AnsweredConcurrently",
+			action:    "decline",
+			username:  payer.Username,
+			expiresAt: time.Now().Add(time.Hour),
+			buildStubs: func(store *mockdb.MockStore, paymentRequest db.PaymentRequest) {
+				store.EXPECT().DecidePaymentRequest(gomock.Any(), gomock.Any()).Times(1).Return(db.PaymentRequest{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			paymentRequest := randomPaymentRequest(requester.Username, payer.Username, 7)
+			paymentRequest.ExpiresAt = tc.expiresAt
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(paymentRequest.ID)).Times(1).Return(paymentRequest, nil)
+			tc.buildStubs(store, paymentRequest)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/payment-requests/%d/%s", paymentRequest.ID, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
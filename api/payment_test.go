@@ -0,0 +1,292 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePaymentAPI(t *testing.T) {
+	sender, _ := randomUser(t)
+	recipient, _ := randomUser(t)
+
+	account := randomAccount(sender.Username)
+	recipientAccount := randomAccount(recipient.Username)
+	account.Currency = utils.USD
+	recipientAccount.Currency = utils.USD
+
+	ownerAccount := db.GetOwnerAccountParams{Owner: recipient.Username, Currency: utils.USD, Type: db.AccountTypeChecking}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "ByUsername",
+			body: gin.H{"recipient": recipient.Username},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(recipient.Username)).Times(1).Return(recipient, nil)
+				store.EXPECT().GetOwnerAccount(gomock.Any(), gomock.Eq(ownerAccount)).Times(1).Return(recipientAccount, nil)
+
+				arg := db.TransferTxParams{
+					FromAccountID: account.ID,
+					ToAccountID:   recipientAccount.ID,
+					Amount:        250,
+					InitiatedBy:   sender.Username,
+				}
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.TransferTxResult{
+						Transfer:    db.Transfer{ID: 11, FromAccountID: account.ID, ToAccountID: recipientAccount.ID, Amount: 250},
+						FromAccount: account,
+						ToAccount:   recipientAccount,
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp paymentResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, paymentStatusCompleted, rsp.Status)
+				require.Equal(t, int64(11), rsp.TransferID)
+				require.Equal(t, recipient.FullName, rsp.Recipient.DisplayName)
+
+				// nothing identifies the recipient's account
+				var raw map[string]interface{}
+				err = json.Unmarshal(recorder.Body.Bytes(), &raw)
+				require.NoError(t, err)
+				require.NotContains(t, raw, "to_account")
+				require.NotContains(t, raw, "to_account_id")
+				require.Equal(t, map[string]interface{}{"display_name": recipient.FullName}, raw["recipient"])
+			},
+		},
+		{
+			name: "ByEmail",
+			body: gin.H{"recipient": recipient.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(recipient.Email)).Times(1).Return(recipient, nil)
+				store.EXPECT().GetOwnerAccount(gomock.Any(), gomock.Eq(ownerAccount)).Times(1).Return(recipientAccount, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.TransferTxResult{FromAccount: account, ToAccount: recipientAccount}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NoRecipientAccount",
+			body: gin.H{"recipient": recipient.Username},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(recipient.Username)).Times(1).Return(recipient, nil)
+				store.EXPECT().GetOwnerAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PayNewAccountTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: requireRecipientUnavailable,
+		},
+		{
+			name: "OpensRecipientAccount",
+			body: gin.H{"recipient": recipient.Username, "create_recipient_account": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(recipient.Username)).Times(1).Return(recipient, nil)
+				store.EXPECT().GetOwnerAccount(gomock.Any(), gomock.Eq(ownerAccount)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+				arg := db.PayNewAccountTxParams{
+					Owner:    recipient.Username,
+					Currency: utils.USD,
+					Transfer: db.TransferTxParams{
+						FromAccountID: account.ID,
+						Amount:        250,
+						InitiatedBy:   sender.Username,
+					},
+				}
+				store.EXPECT().PayNewAccountTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.PayNewAccountTxResult{
+						Account: recipientAccount,
+						Transfer: &db.TransferTxResult{
+							Transfer:    db.Transfer{ID: 12, FromAccountID: account.ID, ToAccountID: recipientAccount.ID, Amount: 250},
+							FromAccount: account,
+							ToAccount:   recipientAccount,
+						},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp paymentResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, paymentStatusCompleted, rsp.Status)
+				require.Equal(t, int64(12), rsp.TransferID)
+				require.NotContains(t, recorder.Body.String(), "to_account")
+			},
+		},
+		{
+			name: "OpensRecipientAccountFails",
+			body: gin.H{"recipient": recipient.Username, "create_recipient_account": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(recipient.Username)).Times(1).Return(recipient, nil)
+				store.EXPECT().GetOwnerAccount(gomock.Any(), gomock.Eq(ownerAccount)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().PayNewAccountTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.PayNewAccountTxResult{}, db.ErrAccountStatusViolation)
+			},
+			checkResponse: requireRecipientUnavailable,
+		},
+		{
+			name: "RecipientAccountAlreadyOpen",
+			body: gin.H{"recipient": recipient.Username, "create_recipient_account": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(recipient.Username)).Times(1).Return(recipient, nil)
+				store.EXPECT().GetOwnerAccount(gomock.Any(), gomock.Eq(ownerAccount)).Times(1).Return(recipientAccount, nil)
+				store.EXPECT().PayNewAccountTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.TransferTxResult{FromAccount: account, ToAccount: recipientAccount}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownRecipient",
+			body: gin.H{"recipient": "nobody@example.com"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: requireRecipientUnavailable,
+		},
+		{
+			name: "FrozenRecipientAccount",
+			body: gin.H{"recipient": recipient.Username},
+			buildStubs: func(store *mockdb.MockStore) {
+				frozen := recipientAccount
+				frozen.Status = db.AccountStatusFrozen
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(recipient.Username)).Times(1).Return(recipient, nil)
+				store.EXPECT().GetOwnerAccount(gomock.Any(), gomock.Any()).Times(1).Return(frozen, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: requireRecipientUnavailable,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			stubMember(store, account.ID, sender.Username, db.MemberRoleOwner)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body := gin.H{
+				"from_account_id": account.ID,
+				"amount":          "2.50",
+				"currency":        utils.USD,
+			}
+			for key, value := range tc.body {
+				body[key] = value
+			}
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/payments", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, sender.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+// requireRecipientUnavailable checks the payer cannot tell an unknown recipient from one without a usable account
+func requireRecipientUnavailable(t *testing.T, recorder *httptest.ResponseRecorder) {
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+
+	var rsp gin.H
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.Equal(t, gin.H{"error": errRecipientUnavailable.Error()}, rsp)
+}
+
+// TestPaymentHidesRecipientAccount checks the payer cannot look up the account of the recipient
+// through the transfer of the payment
+func TestPaymentHidesRecipientAccount(t *testing.T) {
+	sender, _ := randomUser(t)
+	recipient, _ := randomUser(t)
+
+	account := randomAccount(sender.Username)
+	recipientAccount := randomAccount(recipient.Username)
+	recipientAccount.ID = account.ID + 1
+	transfer := db.Transfer{ID: 11, FromAccountID: account.ID, ToAccountID: recipientAccount.ID, Amount: 250, Metadata: json.RawMessage(`{}`)}
+
+	testCases := []struct {
+		name       string
+		url        string
+		buildStubs func(store *mockdb.MockStore)
+	}{
+		{
+			name: "GetTransfer",
+			url:  fmt.Sprintf("/transfers/%d", transfer.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+			},
+		},
+		{
+			name: "ListAccountTransfers",
+			url:  fmt.Sprintf("/accounts/%d/transfers?page_id=1&page_size=5", account.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAccountTransfers(gomock.Any(), gomock.Any()).Times(1).Return([]db.Transfer{transfer}, nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			stubMember(store, account.ID, sender.Username, db.MemberRoleOwner)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(recipientAccount.ID)).Times(1).Return(recipientAccount, nil)
+			stubMember(store, recipientAccount.ID, sender.Username, "")
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, sender.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.NotContains(t, recorder.Body.String(), "to_account_id")
+		})
+	}
+}
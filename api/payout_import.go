@@ -0,0 +1,672 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/token"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+const (
+	// maxPayoutImportSize bounds the size of an uploaded payout file
+	maxPayoutImportSize = 5 << 20
+	// maxPayoutImportRows bounds the rows of a payout file, the header excluded
+	maxPayoutImportRows = 10000
+)
+
+// Columns of a payout file, description is optional and the order is given by the header
+const (
+	payoutColumnFrom        = "from_account_id"
+	payoutColumnTo          = "to_account_id"
+	payoutColumnAmount      = "amount"
+	payoutColumnCurrency    = "currency"
+	payoutColumnReference   = "reference"
+	payoutColumnDescription = "description"
+)
+
+var payoutColumns = []string{
+	payoutColumnFrom, payoutColumnTo, payoutColumnAmount, payoutColumnCurrency, payoutColumnReference,
+}
+
+var payoutResultColumns = []string{
+	"line", "from_account_id", "to_account_id", "amount", "currency", "reference", "status", "transfer_id", "approval_id", "error",
+}
+
+// payoutRecord is a row of a payout file as it was written
+type payoutRecord struct {
+	Line        int64  `json:"line"`
+	FromAccount string `json:"from_account_id"`
+	ToAccount   string `json:"to_account_id"`
+	Amount      string `json:"amount"`
+	Currency    string `json:"currency"`
+	Reference   string `json:"reference"`
+	Description string `json:"description"`
+}
+
+// readPayoutFile reads the rows of a CSV payout file, the header names the columns
+func readPayoutFile(r io.Reader) ([]payoutRecord, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the payout file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// spreadsheets tend to save CSV files with a byte order mark
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case payoutColumnFrom, payoutColumnTo, payoutColumnAmount, payoutColumnCurrency, payoutColumnReference, payoutColumnDescription:
+		default:
+			return nil, fmt.Errorf("unknown column %q", header[i])
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("column %s is given twice", name)
+		}
+		columns[name] = i
+	}
+	for _, name := range payoutColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("column %s is missing", name)
+		}
+	}
+
+	field := func(row []string, name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	var records []payoutRecord
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == maxPayoutImportRows {
+			return nil, fmt.Errorf("a payout file has at most %d rows", maxPayoutImportRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		records = append(records, payoutRecord{
+			Line:        int64(line),
+			FromAccount: field(row, payoutColumnFrom),
+			ToAccount:   field(row, payoutColumnTo),
+			Amount:      field(row, payoutColumnAmount),
+			Currency:    field(row, payoutColumnCurrency),
+			Reference:   field(row, payoutColumnReference),
+			Description: field(row, payoutColumnDescription),
+		})
+	}
+	if len(records) == 0 {
+		return nil, errors.New("the payout file has no rows")
+	}
+	return records, nil
+}
+
+type payoutValidationRow struct {
+	payoutRecord
+	Errors []string `json:"errors"`
+}
+
+type payoutValidationResponse struct {
+	DryRun  bool                  `json:"dry_run"`
+	Rows    int                   `json:"rows"`
+	Invalid int                   `json:"invalid"`
+	Results []payoutValidationRow `json:"results"`
+}
+
+// payout is a row of a payout file with the transfer it makes, which only runs when the whole file is valid
+type payout struct {
+	record payoutRecord
+	arg    db.TransferTxParams
+	amount utils.Money
+	// hold is set when the transfer is above the approval threshold and waits for a second user
+	hold bool
+}
+
+// payoutValidator checks the rows of a payout file the way createTransfer checks a request, the accounts
+// and the permissions are looked up once per file
+type payoutValidator struct {
+	server     *Server
+	ctx        *gin.Context
+	accounts   map[int64]*db.Account
+	access     map[int64]error
+	approvers  map[int64]bool
+	references map[string]int64
+}
+
+func (server *Server) newPayoutValidator(ctx *gin.Context) *payoutValidator {
+	return &payoutValidator{
+		server:     server,
+		ctx:        ctx,
+		accounts:   make(map[int64]*db.Account),
+		access:     make(map[int64]error),
+		approvers:  make(map[int64]bool),
+		references: make(map[string]int64),
+	}
+}
+
+// validate returns every problem of the row, the error is only set when the checks could not run
+func (v *payoutValidator) validate(record payoutRecord) (payout, []string, error) {
+	p := payout{record: record}
+	var problems []string
+	report := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	fromAccountID, err := strconv.ParseInt(record.FromAccount, 10, 64)
+	if err != nil || fromAccountID < 1 {
+		report("from_account_id %q is not an account number", record.FromAccount)
+	}
+	toAccountID, err := strconv.ParseInt(record.ToAccount, 10, 64)
+	if err != nil || toAccountID < 1 {
+		report("to_account_id %q is not an account number", record.ToAccount)
+	}
+	if fromAccountID > 0 && fromAccountID == toAccountID {
+		report("account %d cannot pay itself", fromAccountID)
+	}
+
+	currency := record.Currency
+	currencyValid := v.server.currencies.IsEnabled(currency)
+	if !currencyValid {
+		report("currency %q is not supported", currency)
+	} else if p.amount, err = utils.ParseMoney(record.Amount, currency); err != nil {
+		report("%s", err)
+	} else if !p.amount.IsPositive() {
+		report("amount %s must be positive", p.amount)
+	}
+
+	switch {
+	case record.Reference == "":
+		report("the reference is missing")
+	case len(record.Reference) > 64:
+		report("the reference is longer than 64 characters")
+	case v.references[record.Reference] != 0:
+		report("reference %s is already used on line %d", record.Reference, v.references[record.Reference])
+	default:
+		v.references[record.Reference] = record.Line
+	}
+	if len(record.Description) > 255 {
+		report("the description is longer than 255 characters")
+	}
+
+	if fromAccountID > 0 {
+		account, accountProblems, err := v.account(fromAccountID, currency, currencyValid, db.AccountDebit)
+		if err != nil {
+			return p, nil, err
+		}
+		problems = append(problems, accountProblems...)
+
+		if account != nil {
+			accessErr, ok := v.access[account.ID]
+			if !ok {
+				var status int
+				status, accessErr = v.server.accountAccess(v.ctx, *account, db.AccountPermissionSpend)
+				if status == http.StatusInternalServerError {
+					return p, nil, accessErr
+				}
+				v.access[account.ID] = accessErr
+			}
+			if accessErr != nil {
+				report("%s", accessErr)
+			} else if record.Reference != "" {
+				used, err := v.server.store.TransferReferenceExists(v.ctx, db.TransferReferenceExistsParams{
+					FromAccountID: account.ID,
+					Reference:     record.Reference,
+				})
+				if err != nil {
+					return p, nil, err
+				}
+				if used {
+					report("reference %s was already used by a transfer from account %d", record.Reference, account.ID)
+				}
+			}
+
+			if accessErr == nil && v.server.requiresApproval(p.amount) {
+				approver, ok := v.approvers[account.ID]
+				if !ok {
+					authPayload := v.ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+					if approver, err = v.server.hasApprover(v.ctx, *account, authPayload.Username); err != nil {
+						return p, nil, err
+					}
+					v.approvers[account.ID] = approver
+				}
+				if approver {
+					p.hold = true
+				} else {
+					report("%s", errNoApprover)
+				}
+			}
+		}
+	}
+	if toAccountID > 0 {
+		account, accountProblems, err := v.account(toAccountID, currency, currencyValid, db.AccountCredit)
+		if err != nil {
+			return p, nil, err
+		}
+		problems = append(problems, accountProblems...)
+
+		if account != nil && len(accountProblems) == 0 && p.amount.IsPositive() {
+			authPayload := v.ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+			err := v.server.store.CheckPayeeCoolingOff(v.ctx, authPayload.Username, *account, p.amount.Amount())
+			var coolingOffErr *db.PayeeCoolingOffError
+			if errors.As(err, &coolingOffErr) {
+				report("%s", err)
+			} else if err != nil {
+				return p, nil, err
+			}
+		}
+	}
+
+	p.arg = db.TransferTxParams{
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        p.amount.Amount(),
+		Description:   record.Description,
+		Reference:     record.Reference,
+	}
+	return p, problems, nil
+}
+
+// account checks an account of a row the way validAccount does, it is nil when the account does not exist
+func (v *payoutValidator) account(id int64, currency string, currencyValid bool, operation string) (*db.Account, []string, error) {
+	account, ok := v.accounts[id]
+	if !ok {
+		found, err := v.server.store.GetAccount(v.ctx, id)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, nil, err
+		}
+		if err == nil {
+			account = &found
+		}
+		v.accounts[id] = account
+	}
+	if account == nil {
+		return nil, []string{fmt.Sprintf("account %d does not exist", id)}, nil
+	}
+
+	var problems []string
+	if err := db.CheckAccountStatus(*account, operation); err != nil {
+		problems = append(problems, fmt.Sprintf("account %d: %s", id, err))
+	}
+	if currencyValid && account.Currency != currency {
+		problems = append(problems, fmt.Sprintf("account %d does not support currency %s", id, currency))
+	}
+	return account, problems, nil
+}
+
+type createPayoutImportRequest struct {
+	DryRun bool `form:"dry_run"`
+}
+
+// createPayoutImport imports a CSV payout file. Every row is validated first and the problems of all rows
+// are reported, nothing is executed unless the whole file is valid. With dry_run the file is only validated.
+// The transfers of a valid file are executed one by one, a transfer that fails does not hold back the rest.
+func (server *Server) createPayoutImport(ctx *gin.Context) {
+	var req createPayoutImportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	records, err := readPayoutFile(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxPayoutImportSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	validation := payoutValidationResponse{
+		DryRun:  req.DryRun,
+		Rows:    len(records),
+		Results: make([]payoutValidationRow, len(records)),
+	}
+	payouts := make([]payout, len(records))
+	validator := server.newPayoutValidator(ctx)
+	for i, record := range records {
+		p, problems, err := validator.validate(record)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if len(problems) > 0 {
+			validation.Invalid++
+		}
+		payouts[i] = p
+		validation.Results[i] = payoutValidationRow{payoutRecord: record, Errors: problems}
+	}
+
+	if req.DryRun {
+		ctx.JSON(http.StatusOK, validation)
+		return
+	}
+	if validation.Invalid > 0 {
+		err := fmt.Errorf("%d of %d rows are invalid, no transfer was executed", validation.Invalid, validation.Rows)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "validation": validation})
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	payoutImport, err := server.store.CreatePayoutImport(ctx, db.CreatePayoutImportParams{
+		Owner:    authPayload.Username,
+		RowCount: int64(len(payouts)),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	for _, p := range payouts {
+		if err := server.executePayout(ctx, payoutImport, p); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	rsp, err := server.payoutImportResponse(ctx, payoutImport)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// executePayout executes or holds a validated row and records the outcome,
+// it only fails when the outcome cannot be recorded
+func (server *Server) executePayout(ctx *gin.Context, payoutImport db.PayoutImport, p payout) error {
+	record := db.CreatePayoutImportRowParams{
+		ImportID:      payoutImport.ID,
+		Line:          p.record.Line,
+		FromAccountID: p.arg.FromAccountID,
+		ToAccountID:   p.arg.ToAccountID,
+		Amount:        p.arg.Amount,
+		Currency:      p.amount.Currency(),
+		Description:   p.arg.Description,
+		Reference:     p.arg.Reference,
+	}
+
+	metadata, err := json.Marshal(map[string]string{
+		"payout_import_id": strconv.FormatInt(payoutImport.ID, 10),
+		"line":             strconv.FormatInt(p.record.Line, 10),
+	})
+	if err != nil {
+		return err
+	}
+	arg := p.arg
+	arg.Metadata = metadata
+	arg.InitiatedBy = payoutImport.Owner
+
+	execute := db.ExecutePayoutImportRowTxParams{
+		Row:      record,
+		Transfer: arg,
+	}
+	if p.hold {
+		hold := server.approvalRequest(ctx, arg)
+		execute.Hold = &hold
+	}
+
+	_, err = server.store.ExecutePayoutImportRowTx(ctx, execute)
+	if err == nil {
+		return nil
+	}
+
+	// accounts may have changed since the file was validated, the failure is reported like a rejected batch transaction
+	record.Status = db.PayoutStatusFailed
+	record.Error = payoutFailure(err)
+	_, err = server.store.CreatePayoutImportRow(ctx, record)
+	return err
+}
+
+// payoutFailure is the error recorded for a row whose transfer failed, a reference another transfer
+// took since the file was validated is named as such
+func payoutFailure(err error) string {
+	if errors.Is(err, db.ErrReferenceUsed) {
+		return err.Error()
+	}
+	return transferRejection(err).Error()
+}
+
+type payoutImportRowResponse struct {
+	Line          int64         `json:"line"`
+	FromAccountID int64         `json:"from_account_id"`
+	ToAccountID   int64         `json:"to_account_id"`
+	Amount        utils.Money   `json:"amount"`
+	Description   string        `json:"description"`
+	Reference     string        `json:"reference"`
+	Status        string        `json:"status"`
+	Error         string        `json:"error"`
+	TransferID    sql.NullInt64 `json:"transfer_id"`
+	ApprovalID    sql.NullInt64 `json:"approval_id"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// newPayoutImportRowResponse reports a row held for approval with the outcome of the approval
+func newPayoutImportRowResponse(row db.ListPayoutImportRowsRow) (payoutImportRowResponse, error) {
+	amount, err := utils.NewMoney(row.Amount, row.Currency)
+	if err != nil {
+		return payoutImportRowResponse{}, err
+	}
+
+	rsp := payoutImportRowResponse{
+		Line:          row.Line,
+		FromAccountID: row.FromAccountID,
+		ToAccountID:   row.ToAccountID,
+		Amount:        amount,
+		Description:   row.Description,
+		Reference:     row.Reference,
+		Status:        row.Status,
+		Error:         row.Error,
+		TransferID:    row.TransferID,
+		ApprovalID:    row.ApprovalID,
+		CreatedAt:     row.CreatedAt,
+	}
+
+	if row.Status == db.PayoutStatusPending {
+		switch row.ApprovalStatus {
+		case db.ApprovalStatusApproved:
+			rsp.Status = db.PayoutStatusExecuted
+			rsp.TransferID = row.ApprovalTransferID
+		case db.ApprovalStatusRejected:
+			rsp.Status = db.PayoutStatusFailed
+			rsp.Error = "the transfer was rejected by the approver"
+		case db.ApprovalStatusExpired:
+			rsp.Status = db.PayoutStatusFailed
+			rsp.Error = "the transfer was not approved in time"
+		}
+	}
+	return rsp, nil
+}
+
+type payoutImportResponse struct {
+	db.PayoutImport
+	Executed int                       `json:"executed"`
+	Pending  int                       `json:"pending"`
+	Failed   int                       `json:"failed"`
+	Rows     []payoutImportRowResponse `json:"rows"`
+}
+
+func (server *Server) payoutImportResponse(ctx *gin.Context, payoutImport db.PayoutImport) (payoutImportResponse, error) {
+	rsp := payoutImportResponse{PayoutImport: payoutImport}
+
+	rows, err := server.store.ListPayoutImportRows(ctx, payoutImport.ID)
+	if err != nil {
+		return rsp, err
+	}
+
+	rsp.Rows = make([]payoutImportRowResponse, len(rows))
+	for i, row := range rows {
+		if rsp.Rows[i], err = newPayoutImportRowResponse(row); err != nil {
+			return rsp, err
+		}
+		switch rsp.Rows[i].Status {
+		case db.PayoutStatusExecuted:
+			rsp.Executed++
+		case db.PayoutStatusPending:
+			rsp.Pending++
+		case db.PayoutStatusFailed:
+			rsp.Failed++
+		}
+	}
+	return rsp, nil
+}
+
+type listPayoutImportsRequest struct {
+	Limit int32 `form:"limit,default=50" binding:"min=1,max=200"`
+}
+
+// listPayoutImports returns the payout files imported by the authenticated user, newest first
+func (server *Server) listPayoutImports(ctx *gin.Context) {
+	var req listPayoutImportsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	imports, err := server.store.ListPayoutImports(ctx, db.ListPayoutImportsParams{
+		Owner: authPayload.Username,
+		Limit: req.Limit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, imports)
+}
+
+type getPayoutImportRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// authorizedPayoutImport returns the import when it was made by the authenticated user
+func (server *Server) authorizedPayoutImport(ctx *gin.Context) (db.PayoutImport, bool) {
+	var req getPayoutImportRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.PayoutImport{}, false
+	}
+
+	payoutImport, err := server.store.GetPayoutImport(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return payoutImport, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return payoutImport, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if payoutImport.Owner != authPayload.Username {
+		err := fmt.Errorf("payout import %d doesn't belong to the authenticated user", payoutImport.ID)
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return payoutImport, false
+	}
+	return payoutImport, true
+}
+
+// getPayoutImport returns the import with the current status of every row
+func (server *Server) getPayoutImport(ctx *gin.Context) {
+	payoutImport, valid := server.authorizedPayoutImport(ctx)
+	if !valid {
+		return
+	}
+
+	rsp, err := server.payoutImportResponse(ctx, payoutImport)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// getPayoutImportResult downloads the outcome of every row of the import as a CSV file in the order of the upload
+func (server *Server) getPayoutImportResult(ctx *gin.Context) {
+	payoutImport, valid := server.authorizedPayoutImport(ctx)
+	if !valid {
+		return
+	}
+
+	rsp, err := server.payoutImportResponse(ctx, payoutImport)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(payoutResultColumns); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	for _, row := range rsp.Rows {
+		err := w.Write([]string{
+			strconv.FormatInt(row.Line, 10),
+			strconv.FormatInt(row.FromAccountID, 10),
+			strconv.FormatInt(row.ToAccountID, 10),
+			row.Amount.Decimal(),
+			row.Amount.Currency(),
+			csvCell(row.Reference),
+			row.Status,
+			nullID(row.TransferID),
+			nullID(row.ApprovalID),
+			csvCell(row.Error),
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="payouts-%d-result.csv"`, payoutImport.ID))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// csvCell keeps spreadsheets from running text that starts like a formula
+func csvCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func nullID(id sql.NullInt64) string {
+	if !id.Valid {
+		return ""
+	}
+	return strconv.FormatInt(id.Int64, 10)
+}
@@ -0,0 +1,675 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/token"
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+const testPayoutFile = "from_account_id,to_account_id,amount,currency,reference,description\n" +
+	"10,20,100.00,USD,PAY-1,January\n" +
+	"10,20,1500.00,USD,PAY-2,\n" +
+	"10,20,5.00,USD,PAY-3,\n"
+
+// testInvalidPayoutFile has one valid row, the others have every problem the validation reports
+const testInvalidPayoutFile = "\ufeffReference,From_Account_ID,To_Account_ID,Amount,Currency\n" +
+	"PAY-1,10,20,100.00,USD\n" +
+	"PAY-1,10,22,5.00,USD\n" +
+	"PAY-4,10,23,5.00,USD\n" +
+	"PAY-5,30,20,5.00,USD\n" +
+	",abc,20,-1,XXX\n" +
+	"PAY-OLD,10,21,5.00,USD\n"
+
+func payoutAccounts(owner string) map[int64]db.Account {
+	eur := batchAccount(23, "recipient", db.AccountStatusActive)
+	eur.Currency = utils.EUR
+	return map[int64]db.Account{
+		10: batchAccount(10, owner, db.AccountStatusActive),
+		20: batchAccount(20, "recipient", db.AccountStatusActive),
+		21: batchAccount(21, "recipient", db.AccountStatusClosed),
+		23: eur,
+		30: batchAccount(30, "someone_else", db.AccountStatusActive),
+	}
+}
+
+func stubPayoutAccounts(store *mockdb.MockStore, accounts map[int64]db.Account, times int) {
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(times).
+		DoAndReturn(func(_ context.Context, id int64) (db.Account, error) {
+			account, ok := accounts[id]
+			if !ok {
+				return account, sql.ErrNoRows
+			}
+			return account, nil
+		})
+}
+
+func payoutImportRow(id int64, arg db.CreatePayoutImportRowParams) db.ListPayoutImportRowsRow {
+	return db.ListPayoutImportRowsRow{
+		ID:            id,
+		ImportID:      arg.ImportID,
+		Line:          arg.Line,
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Currency:      arg.Currency,
+		Description:   arg.Description,
+		Reference:     arg.Reference,
+		Status:        arg.Status,
+		Error:         arg.Error,
+		TransferID:    arg.TransferID,
+		ApprovalID:    arg.ApprovalID,
+	}
+}
+
+func TestCreatePayoutImportAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	accounts := payoutAccounts(user.Username)
+	payoutImport := db.PayoutImport{ID: 5, Owner: user.Username, RowCount: 3}
+
+	invalidRows := func(store *mockdb.MockStore) {
+		stubPayoutAccounts(store, accounts, 6)
+		stubMember(store, 10, user.Username, db.MemberRoleOwner)
+		stubMember(store, 30, user.Username, "")
+		store.EXPECT().TransferReferenceExists(gomock.Any(), gomock.Any()).Times(4).
+			DoAndReturn(func(_ context.Context, arg db.TransferReferenceExistsParams) (bool, error) {
+				require.Equal(t, int64(10), arg.FromAccountID)
+				return arg.Reference == "PAY-OLD", nil
+			})
+		store.EXPECT().CheckPayeeCoolingOff(gomock.Any(), gomock.Eq(user.Username), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+		store.EXPECT().CreatePayoutImport(gomock.Any(), gomock.Any()).Times(0)
+		store.EXPECT().ExecutePayoutImportRowTx(gomock.Any(), gomock.Any()).Times(0)
+	}
+	requireInvalidRows := func(t *testing.T, validation payoutValidationResponse) {
+		require.Equal(t, 6, validation.Rows)
+		require.Equal(t, 5, validation.Invalid)
+
+		errs := make(map[int64][]string)
+		for _, row := range validation.Results {
+			errs[row.Line] = row.Errors
+		}
+		require.Equal(t, map[int64][]string{
+			2: nil,
+			3: {"reference PAY-1 is already used on line 2", "account 22 does not exist"},
+			4: {"account 23 does not support currency USD"},
+			5: {"account 30 doesn't belong to the authenticated user"},
+			6: {`from_account_id "abc" is not an account number`, `currency "XXX" is not supported`, "the reference is missing"},
+			7: {"reference PAY-OLD was already used by a transfer from account 10", "account 21: account is closed"},
+		}, errs)
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		body          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: testPayoutFile,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubPayoutAccounts(store, accounts, 2)
+				stubMember(store, 10, user.Username, db.MemberRoleOwner)
+				stubAccountMembers(store, 10,
+					randomMember(10, user.Username, db.MemberRoleOwner),
+					randomMember(10, "co_owner", db.MemberRoleOwner))
+				store.EXPECT().TransferReferenceExists(gomock.Any(), gomock.Any()).Times(3).Return(false, nil)
+				store.EXPECT().CheckPayeeCoolingOff(gomock.Any(), gomock.Eq(user.Username), gomock.Any(), gomock.Any()).Times(3).Return(nil)
+				store.EXPECT().CreatePayoutImport(gomock.Any(), gomock.Eq(db.CreatePayoutImportParams{
+					Owner:    user.Username,
+					RowCount: 3,
+				})).Times(1).Return(payoutImport, nil)
+
+				var recorded []db.ListPayoutImportRowsRow
+				store.EXPECT().ExecutePayoutImportRowTx(gomock.Any(), gomock.Any()).Times(3).
+					DoAndReturn(func(_ context.Context, arg db.ExecutePayoutImportRowTxParams) (db.ExecutePayoutImportRowTxResult, error) {
+						require.Equal(t, payoutImport.ID, arg.Row.ImportID)
+						require.Equal(t, int64(10), arg.Transfer.FromAccountID)
+						require.Equal(t, int64(20), arg.Transfer.ToAccountID)
+						require.Equal(t, arg.Row.Reference, arg.Transfer.Reference)
+
+						var metadata map[string]string
+						require.NoError(t, json.Unmarshal(arg.Transfer.Metadata, &metadata))
+						require.Equal(t, "5", metadata["payout_import_id"])
+						require.Equal(t, fmt.Sprint(arg.Row.Line), metadata["line"])
+
+						row := arg.Row
+						switch arg.Transfer.Amount {
+						case 500:
+							return db.ExecutePayoutImportRowTxResult{}, &db.LimitExceededError{Requested: arg.Transfer.Amount}
+						case 150000:
+							require.NotNil(t, arg.Hold)
+							require.Equal(t, user.Username, arg.Hold.InitiatedBy)
+							row.Status = db.PayoutStatusPending
+							row.ApprovalID = sql.NullInt64{Int64: 3, Valid: true}
+						default:
+							require.Nil(t, arg.Hold)
+							require.Equal(t, "January", arg.Transfer.Description)
+							row.Status = db.PayoutStatusExecuted
+							row.TransferID = sql.NullInt64{Int64: 41, Valid: true}
+						}
+						recorded = append(recorded, payoutImportRow(int64(len(recorded)+1), row))
+						return db.ExecutePayoutImportRowTxResult{}, nil
+					})
+				store.EXPECT().CreatePayoutImportRow(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreatePayoutImportRowParams) (db.PayoutImportRow, error) {
+						require.Equal(t, db.PayoutStatusFailed, arg.Status)
+						require.Equal(t, int64(4), arg.Line)
+						require.NotEmpty(t, arg.Error)
+						recorded = append(recorded, payoutImportRow(int64(len(recorded)+1), arg))
+						return db.PayoutImportRow{}, nil
+					})
+				store.EXPECT().ListPayoutImportRows(gomock.Any(), gomock.Eq(payoutImport.ID)).Times(1).
+					DoAndReturn(func(_ context.Context, _ int64) ([]db.ListPayoutImportRowsRow, error) {
+						return recorded, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp payoutImportResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, payoutImport.ID, rsp.ID)
+				require.Equal(t, 1, rsp.Executed)
+				require.Equal(t, 1, rsp.Pending)
+				require.Equal(t, 1, rsp.Failed)
+				require.Len(t, rsp.Rows, 3)
+			},
+		},
+		{
+			name: "ReferenceUsedAtExecution",
+			body: "from_account_id,to_account_id,amount,currency,reference\n10,20,5.00,USD,PAY-1\n",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubPayoutAccounts(store, accounts, 2)
+				stubMember(store, 10, user.Username, db.MemberRoleOwner)
+				store.EXPECT().TransferReferenceExists(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+				store.EXPECT().CheckPayeeCoolingOff(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().CreatePayoutImport(gomock.Any(), gomock.Any()).Times(1).Return(payoutImport, nil)
+				store.EXPECT().ExecutePayoutImportRowTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ExecutePayoutImportRowTxResult{}, db.ErrReferenceUsed)
+
+				var recorded []db.ListPayoutImportRowsRow
+				store.EXPECT().CreatePayoutImportRow(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreatePayoutImportRowParams) (db.PayoutImportRow, error) {
+						require.Equal(t, db.PayoutStatusFailed, arg.Status)
+						require.Equal(t, db.ErrReferenceUsed.Error(), arg.Error)
+						recorded = append(recorded, payoutImportRow(1, arg))
+						return db.PayoutImportRow{}, nil
+					})
+				store.EXPECT().ListPayoutImportRows(gomock.Any(), gomock.Eq(payoutImport.ID)).Times(1).
+					DoAndReturn(func(_ context.Context, _ int64) ([]db.ListPayoutImportRowsRow, error) {
+						return recorded, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp payoutImportResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Zero(t, rsp.Executed)
+				require.Equal(t, 1, rsp.Failed)
+			},
+		},
+		{
+			name:  "DryRun",
+			query: "?dry_run=true",
+			body:  testInvalidPayoutFile,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: invalidRows,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp payoutValidationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.True(t, rsp.DryRun)
+				requireInvalidRows(t, rsp)
+			},
+		},
+		{
+			name:  "DryRunValidFile",
+			query: "?dry_run=true",
+			body:  testPayoutFile,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubPayoutAccounts(store, accounts, 2)
+				stubMember(store, 10, user.Username, db.MemberRoleOwner)
+				stubAccountMembers(store, 10,
+					randomMember(10, user.Username, db.MemberRoleOwner),
+					randomMember(10, "co_owner", db.MemberRoleOwner))
+				store.EXPECT().TransferReferenceExists(gomock.Any(), gomock.Any()).Times(3).Return(false, nil)
+				store.EXPECT().CheckPayeeCoolingOff(gomock.Any(), gomock.Eq(user.Username), gomock.Any(), gomock.Any()).Times(3).Return(nil)
+				store.EXPECT().CreatePayoutImport(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ExecutePayoutImportRowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp payoutValidationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, 3, rsp.Rows)
+				require.Zero(t, rsp.Invalid)
+			},
+		},
+		{
+			name:  "DryRunNoApprover",
+			query: "?dry_run=true",
+			body:  testPayoutFile,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubPayoutAccounts(store, accounts, 2)
+				stubMember(store, 10, user.Username, db.MemberRoleOwner)
+				stubAccountMembers(store, 10, randomMember(10, user.Username, db.MemberRoleOwner))
+				store.EXPECT().TransferReferenceExists(gomock.Any(), gomock.Any()).Times(3).Return(false, nil)
+				store.EXPECT().CheckPayeeCoolingOff(gomock.Any(), gomock.Eq(user.Username), gomock.Any(), gomock.Any()).Times(3).Return(nil)
+				store.EXPECT().CreatePayoutImport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp payoutValidationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, 1, rsp.Invalid)
+				require.Equal(t, int64(3), rsp.Results[1].Line)
+				require.Equal(t, []string{errNoApprover.Error()}, rsp.Results[1].Errors)
+			},
+		},
+		{
+			name:  "DryRunPayeeCoolingOff",
+			query: "?dry_run=true",
+			body:  testPayoutFile,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubPayoutAccounts(store, accounts, 2)
+				stubMember(store, 10, user.Username, db.MemberRoleOwner)
+				stubAccountMembers(store, 10,
+					randomMember(10, user.Username, db.MemberRoleOwner),
+					randomMember(10, "co_owner", db.MemberRoleOwner))
+				store.EXPECT().TransferReferenceExists(gomock.Any(), gomock.Any()).Times(3).Return(false, nil)
+				store.EXPECT().CheckPayeeCoolingOff(gomock.Any(), gomock.Eq(user.Username), gomock.Any(), gomock.Any()).Times(3).
+					DoAndReturn(func(_ context.Context, _ string, account db.Account, amount int64) error {
+						require.Equal(t, int64(20), account.ID)
+						if amount > 100000 {
+							return &db.PayeeCoolingOffError{PayeeID: 8, Until: time.Now().Add(time.Hour)}
+						}
+						return nil
+					})
+				store.EXPECT().CreatePayoutImport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp payoutValidationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, 1, rsp.Invalid)
+				require.Equal(t, int64(3), rsp.Results[1].Line)
+				require.Len(t, rsp.Results[1].Errors, 1)
+				require.Contains(t, rsp.Results[1].Errors[0], "payee 8 is new")
+			},
+		},
+		{
+			name:  "DryRunSelfPayout",
+			query: "?dry_run=true",
+			body:  "from_account_id,to_account_id,amount,currency,reference\n10,10,5.00,USD,PAY-1\n",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubPayoutAccounts(store, accounts, 1)
+				stubMember(store, 10, user.Username, db.MemberRoleOwner)
+				store.EXPECT().TransferReferenceExists(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+				store.EXPECT().CheckPayeeCoolingOff(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().CreatePayoutImport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp payoutValidationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, 1, rsp.Invalid)
+				require.Equal(t, []string{"account 10 cannot pay itself"}, rsp.Results[0].Errors)
+			},
+		},
+		{
+			name: "PayeeCoolingOffAtExecution",
+			body: "from_account_id,to_account_id,amount,currency,reference\n10,20,5.00,USD,PAY-1\n",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubPayoutAccounts(store, accounts, 2)
+				stubMember(store, 10, user.Username, db.MemberRoleOwner)
+				store.EXPECT().TransferReferenceExists(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+				store.EXPECT().CheckPayeeCoolingOff(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().CreatePayoutImport(gomock.Any(), gomock.Any()).Times(1).Return(payoutImport, nil)
+
+				// the payee was saved between the validation and the execution
+				coolingOffErr := &db.PayeeCoolingOffError{PayeeID: 8, Until: time.Now().Add(time.Hour)}
+				store.EXPECT().ExecutePayoutImportRowTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ExecutePayoutImportRowTxResult{}, coolingOffErr)
+
+				var recorded []db.ListPayoutImportRowsRow
+				store.EXPECT().CreatePayoutImportRow(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreatePayoutImportRowParams) (db.PayoutImportRow, error) {
+						require.Equal(t, db.PayoutStatusFailed, arg.Status)
+						require.Equal(t, coolingOffErr.Error(), arg.Error)
+						recorded = append(recorded, payoutImportRow(1, arg))
+						return db.PayoutImportRow{}, nil
+					})
+				store.EXPECT().ListPayoutImportRows(gomock.Any(), gomock.Eq(payoutImport.ID)).Times(1).
+					DoAndReturn(func(_ context.Context, _ int64) ([]db.ListPayoutImportRowsRow, error) {
+						return recorded, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp payoutImportResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Zero(t, rsp.Executed)
+				require.Equal(t, 1, rsp.Failed)
+			},
+		},
+		{
+			name: "InvalidRows",
+			body: testInvalidPayoutFile,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: invalidRows,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				var rsp struct {
+					Error      string                   `json:"error"`
+					Validation payoutValidationResponse `json:"validation"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, "5 of 6 rows are invalid, no transfer was executed", rsp.Error)
+				require.False(t, rsp.Validation.DryRun)
+				requireInvalidRows(t, rsp.Validation)
+			},
+		},
+		{
+			name: "MissingColumn",
+			body: "from_account_id,to_account_id,amount,currency\n10,20,1.00,USD\n",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "column reference is missing")
+			},
+		},
+		{
+			name: "UnknownColumn",
+			body: "from_account_id,to_account_id,amount,currency,reference,iban\n",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `unknown column \"iban\"`)
+			},
+		},
+		{
+			name: "NoRows",
+			body: "from_account_id,to_account_id,amount,currency,reference\n",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "the payout file has no rows")
+			},
+		},
+		{
+			name: "TooManyRows",
+			body: testPayoutFile + strings.Repeat("10,20,1.00,USD,PAY,\n", maxPayoutImportRows),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), fmt.Sprintf("at most %d rows", maxPayoutImportRows))
+			},
+		},
+		{
+			name: "TooLarge",
+			body: testPayoutFile + "10,20,1.00,USD,PAY-4," + strings.Repeat("x", maxPayoutImportSize) + "\n",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+			},
+		},
+		{
+			name:      "NoAuthorization",
+			body:      testPayoutFile,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newApprovalTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/imports/payouts"+tc.query, strings.NewReader(tc.body))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "text/csv")
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetPayoutImportAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	payoutImport := db.PayoutImport{ID: 5, Owner: user.Username, RowCount: 4}
+
+	rows := []db.ListPayoutImportRowsRow{
+		{ID: 1, ImportID: payoutImport.ID, Line: 2, FromAccountID: 10, ToAccountID: 20, Amount: 10000, Currency: utils.USD,
+			Reference: "PAY-1", Status: db.PayoutStatusExecuted, TransferID: sql.NullInt64{Int64: 41, Valid: true}},
+		{ID: 2, ImportID: payoutImport.ID, Line: 3, FromAccountID: 10, ToAccountID: 20, Amount: 150000, Currency: utils.USD,
+			Reference: "PAY-2", Status: db.PayoutStatusPending, ApprovalID: sql.NullInt64{Int64: 3, Valid: true},
+			ApprovalStatus: db.ApprovalStatusApproved, ApprovalTransferID: sql.NullInt64{Int64: 52, Valid: true}},
+		{ID: 3, ImportID: payoutImport.ID, Line: 4, FromAccountID: 10, ToAccountID: 20, Amount: 200000, Currency: utils.USD,
+			Reference: "=PAY-3", Status: db.PayoutStatusPending, ApprovalID: sql.NullInt64{Int64: 4, Valid: true},
+			ApprovalStatus: db.ApprovalStatusPending},
+		{ID: 4, ImportID: payoutImport.ID, Line: 5, FromAccountID: 10, ToAccountID: 20, Amount: 500, Currency: utils.USD,
+			Reference: "PAY-4", Status: db.PayoutStatusFailed, Error: "limit exceeded"},
+	}
+
+	testCases := []struct {
+		name          string
+		path          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			path:     "/imports/payouts/5",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayoutImport(gomock.Any(), gomock.Eq(payoutImport.ID)).Times(1).Return(payoutImport, nil)
+				store.EXPECT().ListPayoutImportRows(gomock.Any(), gomock.Eq(payoutImport.ID)).Times(1).Return(rows, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp payoutImportResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, 2, rsp.Executed)
+				require.Equal(t, 1, rsp.Pending)
+				require.Equal(t, 1, rsp.Failed)
+
+				// approved payouts are executed by the transfer of the approval
+				require.Equal(t, db.PayoutStatusExecuted, rsp.Rows[1].Status)
+				require.Equal(t, int64(52), rsp.Rows[1].TransferID.Int64)
+				require.Equal(t, "1500.00", rsp.Rows[1].Amount.Decimal())
+			},
+		},
+		{
+			name:     "Result",
+			path:     "/imports/payouts/5/result",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayoutImport(gomock.Any(), gomock.Eq(payoutImport.ID)).Times(1).Return(payoutImport, nil)
+				store.EXPECT().ListPayoutImportRows(gomock.Any(), gomock.Eq(payoutImport.ID)).Times(1).Return(rows, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+				require.Equal(t, `attachment; filename="payouts-5-result.csv"`, recorder.Header().Get("Content-Disposition"))
+
+				records, err := csv.NewReader(bytes.NewReader(recorder.Body.Bytes())).ReadAll()
+				require.NoError(t, err)
+				require.Equal(t, [][]string{
+					payoutResultColumns,
+					{"2", "10", "20", "100.00", "USD", "PAY-1", "executed", "41", "", ""},
+					{"3", "10", "20", "1500.00", "USD", "PAY-2", "executed", "52", "3", ""},
+					{"4", "10", "20", "2000.00", "USD", "'=PAY-3", "pending", "", "4", ""},
+					{"5", "10", "20", "5.00", "USD", "PAY-4", "failed", "", "", "limit exceeded"},
+				}, records)
+			},
+		},
+		{
+			name:     "NotFound",
+			path:     "/imports/payouts/5/result",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayoutImport(gomock.Any(), gomock.Eq(payoutImport.ID)).Times(1).Return(db.PayoutImport{}, sql.ErrNoRows)
+				store.EXPECT().ListPayoutImportRows(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			path:     "/imports/payouts/5/result",
+			username: "unauthorized_user",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayoutImport(gomock.Any(), gomock.Eq(payoutImport.ID)).Times(1).Return(payoutImport, nil)
+				store.EXPECT().ListPayoutImportRows(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidID",
+			path:     "/imports/payouts/0",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayoutImport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "List",
+			path:     "/imports/payouts?limit=10",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPayoutImports(gomock.Any(), gomock.Eq(db.ListPayoutImportsParams{
+					Owner: user.Username,
+					Limit: 10,
+				})).Times(1).Return([]db.PayoutImport{payoutImport}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp []db.PayoutImport
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, []db.PayoutImport{payoutImport}, rsp)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
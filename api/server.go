@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/lordofthemind/backendMasterGo/blob"
 	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
 	"github.com/lordofthemind/backendMasterGo/token"
 	"github.com/lordofthemind/backendMasterGo/utils"
@@ -15,7 +16,12 @@ type Server struct {
 	config     utils.Config
 	store      db.Store
 	tokenMaker token.Maker
+	currencies *utils.CurrencyRegistry
+	blobs      blob.Storage
 	router     *gin.Engine
+
+	approvalThresholds map[string]utils.Money
+	paymentLinkKey     []byte
 }
 
 func NewServer(config utils.Config, store db.Store) (*Server, error) {
@@ -25,14 +31,32 @@ func NewServer(config utils.Config, store db.Store) (*Server, error) {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	currencies, err := utils.NewCurrencyRegistry(config.EnabledCurrencies)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create currency registry: %w", err)
+	}
+
+	approvalThresholds, err := utils.ParseCurrencyAmounts(config.ApprovalThresholds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse approval thresholds: %w", err)
+	}
+
 	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
+		config:             config,
+		store:              store,
+		tokenMaker:         tokenMaker,
+		currencies:         currencies,
+		blobs:              blob.NewLocalStorage(config.BlobStorageDir),
+		approvalThresholds: approvalThresholds,
+		paymentLinkKey:     []byte(config.PaymentLinkKey),
+	}
+	if len(server.paymentLinkKey) == 0 {
+		server.paymentLinkKey = []byte(config.TokenSymmetricKey)
 	}
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterValidation("currency", validCurrency)
+		v.RegisterValidation("currency", currencyValidator(currencies))
+		v.RegisterValidation("metadata", validMetadata)
 	}
 
 	server.setupRouter()
@@ -45,13 +69,72 @@ func (server *Server) setupRouter() {
 
 	router.POST("/users", server.createUser)
 	router.POST("/users/login", server.loginUser)
+	router.GET("/currencies", server.listCurrencies)
+	router.GET("/pay/:token", server.previewPaymentLink)
+	router.GET("/pay/:token/qr", server.paymentLinkQR)
 
-	authRouter := router.Group("/").Use(authMiddleware(server.tokenMaker))
+	authRouter := router.Group("/").Use(authMiddleware(server.tokenMaker), orgMiddleware(server.store))
 
 	authRouter.POST("/accounts", server.createAccount)
 	authRouter.GET("/accounts/:id", server.getAccount)
 	authRouter.GET("/accounts", server.listAccounts)
+	authRouter.DELETE("/accounts/:id", server.closeAccount)
+	authRouter.GET("/accounts/:id/limits", server.getAccountLimits)
+	authRouter.GET("/accounts/:id/balance", server.getAccountBalance)
+	authRouter.GET("/accounts/:id/transfers", server.listAccountTransfers)
+	authRouter.GET("/accounts/:id/entries", server.listAccountEntries)
+	authRouter.GET("/accounts/:id/chain", server.getAccountChain)
+	authRouter.GET("/accounts/:id/statements", server.getAccountStatement)
+	authRouter.GET("/accounts/:id/statements/archive", server.listArchivedStatements)
+	authRouter.GET("/accounts/:id/statements/archive/:statement_id", server.getArchivedStatement)
+	authRouter.POST("/accounts/:id/members", server.addAccountMember)
+	authRouter.GET("/accounts/:id/members", server.listAccountMembers)
+	authRouter.POST("/accounts/:id/members/accept", server.acceptAccountMember)
+	authRouter.DELETE("/accounts/:id/members/:username", server.removeAccountMember)
+	authRouter.GET("/notifications", server.listNotifications)
+	authRouter.POST("/notifications/:id/read", server.readNotification)
+	authRouter.POST("/organizations", server.createOrganization)
+	authRouter.GET("/organizations", server.listOrganizations)
+	authRouter.POST("/organizations/:id/members", server.addOrganizationMember)
+	authRouter.GET("/organizations/:id/members", server.listOrganizationMembers)
+	authRouter.DELETE("/organizations/:id/members/:username", server.removeOrganizationMember)
+	authRouter.POST("/payees", server.createPayee)
+	authRouter.GET("/payees", server.listPayees)
+	authRouter.DELETE("/payees/:id", server.deletePayee)
+	authRouter.POST("/payments", server.createPayment)
+	authRouter.POST("/payment-batches", server.createPaymentBatch)
+	authRouter.GET("/payment-batches", server.listPaymentBatches)
+	authRouter.GET("/payment-batches/:id", server.getPaymentBatch)
+	authRouter.GET("/payment-batches/:id/status-report", server.getPaymentBatchStatusReport)
+	authRouter.POST("/imports/payouts", server.createPayoutImport)
+	authRouter.GET("/imports/payouts", server.listPayoutImports)
+	authRouter.GET("/imports/payouts/:id", server.getPayoutImport)
+	authRouter.GET("/imports/payouts/:id/result", server.getPayoutImportResult)
+	authRouter.POST("/payment-links", server.createPaymentLink)
+	authRouter.GET("/payment-links", server.listPaymentLinks)
+	authRouter.DELETE("/payment-links/:id", server.disablePaymentLink)
+	authRouter.POST("/pay/:token", server.payPaymentLink)
+	authRouter.POST("/payment-requests", server.createPaymentRequest)
+	authRouter.GET("/payment-requests", server.listPaymentRequests)
+	authRouter.GET("/payment-requests/:id", server.getPaymentRequest)
+	authRouter.POST("/payment-requests/:id/accept", server.acceptPaymentRequest)
+	authRouter.POST("/payment-requests/:id/decline", server.declinePaymentRequest)
+	authRouter.POST("/payment-requests/:id/cancel", server.cancelPaymentRequest)
 	authRouter.POST("/transfers", server.createTransfer)
+	authRouter.GET("/transfers", server.searchTransfers)
+	authRouter.GET("/transfers/:id", server.getTransfer)
+	authRouter.GET("/transfer-approvals", server.listTransferApprovals)
+	authRouter.GET("/transfer-approvals/:id", server.getTransferApproval)
+	authRouter.POST("/transfer-approvals/:id/approve", server.approveTransfer)
+	authRouter.POST("/transfer-approvals/:id/reject", server.rejectTransfer)
+
+	staffRouter := router.Group("/staff").Use(authMiddleware(server.tokenMaker), staffMiddleware(server.store))
+
+	staffRouter.PATCH("/accounts/:id/status", server.updateAccountStatus)
+	staffRouter.GET("/accounts/:id/status-history", server.listAccountStatusHistory)
+	staffRouter.POST("/interest-rates", server.createInterestRate)
+	staffRouter.GET("/interest-rates", server.listInterestRates)
+	staffRouter.GET("/ledger/checkpoints", server.listLedgerCheckpoints)
 	server.router = router
 }
 
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/token"
+)
+
+type updateAccountStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active frozen debit_blocked credit_blocked"`
+	Reason string `json:"reason" binding:"required,oneof=suspected_fraud compliance_review court_order customer_request resolved"`
+	Note   string `json:"note" binding:"max=255"`
+}
+
+type updateAccountStatusResponse struct {
+	Account accountResponse         `json:"account"`
+	History db.AccountStatusHistory `json:"history"`
+}
+
+func (server *Server) updateAccountStatus(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updateAccountStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := server.store.UpdateAccountStatusTx(ctx, db.UpdateAccountStatusTxParams{
+		AccountID: uri.ID,
+		Status:    req.Status,
+		Reason:    req.Reason,
+		Note:      req.Note,
+		Actor:     authPayload.Username,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrAccountClosed) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	account, err := newAccountResponse(result.Account)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, updateAccountStatusResponse{Account: account, History: result.History})
+}
+
+func (server *Server) listAccountStatusHistory(ctx *gin.Context) {
+	var req getAccountRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	history, err := server.store.ListAccountStatusHistory(ctx, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, history)
+}
+
+type createInterestRateRequest struct {
+	AccountType   string    `json:"account_type" binding:"required,oneof=checking savings"`
+	Currency      string    `json:"currency" binding:"required,currency"`
+	AnnualRatePPM int64     `json:"annual_rate_ppm" binding:"min=0,max=1000000"`
+	EffectiveFrom time.Time `json:"effective_from" binding:"required"`
+}
+
+func (server *Server) createInterestRate(ctx *gin.Context) {
+	var req createInterestRateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// rates apply from the start of a day, accruals are never recomputed after capitalization
+	effectiveFrom := req.EffectiveFrom.UTC()
+	arg := db.CreateInterestRateParams{
+		AccountType:   req.AccountType,
+		Currency:      req.Currency,
+		AnnualRatePpm: req.AnnualRatePPM,
+		EffectiveFrom: time.Date(effectiveFrom.Year(), effectiveFrom.Month(), effectiveFrom.Day(), 0, 0, 0, 0, time.UTC),
+	}
+
+	rate, err := server.store.CreateInterestRate(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rate)
+}
+
+func (server *Server) listInterestRates(ctx *gin.Context) {
+	rates, err := server.store.ListInterestRates(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rates)
+}
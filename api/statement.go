@@ -0,0 +1,217 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/statement"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+// maxStatementDays bounds the period of a statement
+const maxStatementDays = 366
+
+type getAccountStatementRequest struct {
+	From   time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	To     time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	Format string    `form:"format" binding:"omitempty,oneof=csv pdf ofx camt053"`
+}
+
+// period returns the start and the exclusive end of the statement, to is the last day it covers
+func (req getAccountStatementRequest) period() (time.Time, time.Time, error) {
+	if req.To.Before(req.From) {
+		return time.Time{}, time.Time{}, errors.New("to must not be before from")
+	}
+	end := req.To.AddDate(0, 0, 1)
+	if end.Sub(req.From) > maxStatementDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("a statement covers at most %d days", maxStatementDays)
+	}
+	return req.From, end, nil
+}
+
+// getAccountStatement streams the statement of the account for the days from and to, both included
+func (server *Server) getAccountStatement(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req getAccountStatementRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Format == "" {
+		req.Format = statement.FormatCSV
+	}
+	from, to, err := req.period()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uri.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	period := statement.Request{
+		Account: account,
+		From:    from,
+		To:      to,
+	}
+	if _, err := period.Start(); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	holder, err := server.store.GetUser(ctx, account.Owner)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	period.Holder = holder.FullName
+
+	w, err := statement.NewWriter(req.Format, ctx.Writer)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	filename := fmt.Sprintf("statement-%d-%s-%s.%s", account.ID, req.From.Format(time.DateOnly), req.To.Format(time.DateOnly), statement.Extension(req.Format))
+	ctx.Header("Content-Type", statement.ContentType(req.Format))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Status(http.StatusOK)
+
+	err = statement.Generate(ctx, server.store, period, w)
+	if err != nil {
+		// once the statement started streaming the status is sent, all we can do is cut it short
+		if ctx.Writer.Written() {
+			ctx.Abort()
+			return
+		}
+		ctx.Writer.Header().Del("Content-Type")
+		ctx.Writer.Header().Del("Content-Disposition")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+type archivedStatementResponse struct {
+	ID             int64       `json:"id"`
+	AccountID      int64       `json:"account_id"`
+	PeriodStart    string      `json:"period_start"`
+	PeriodEnd      string      `json:"period_end"`
+	Format         string      `json:"format"`
+	Size           int64       `json:"size"`
+	Sha256         string      `json:"sha256"`
+	OpeningBalance utils.Money `json:"opening_balance"`
+	ClosingBalance utils.Money `json:"closing_balance"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+func newArchivedStatementResponse(archived db.Statement, currency string) (archivedStatementResponse, error) {
+	opening, err := utils.NewMoney(archived.OpeningBalance, currency)
+	if err != nil {
+		return archivedStatementResponse{}, err
+	}
+	closing, err := utils.NewMoney(archived.ClosingBalance, currency)
+	if err != nil {
+		return archivedStatementResponse{}, err
+	}
+
+	return archivedStatementResponse{
+		ID:             archived.ID,
+		AccountID:      archived.AccountID,
+		PeriodStart:    archived.PeriodStart.Format(time.DateOnly),
+		PeriodEnd:      archived.PeriodEnd.Format(time.DateOnly),
+		Format:         archived.Format,
+		Size:           archived.Size,
+		Sha256:         hex.EncodeToString(archived.Sha256),
+		OpeningBalance: opening,
+		ClosingBalance: closing,
+		CreatedAt:      archived.CreatedAt,
+	}, nil
+}
+
+// listArchivedStatements lists the monthly statements of the account, newest first
+func (server *Server) listArchivedStatements(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uri.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	statements, err := server.store.ListAccountStatements(ctx, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]archivedStatementResponse, len(statements))
+	for i, archived := range statements {
+		if rsp[i], err = newArchivedStatementResponse(archived, account.Currency); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type getArchivedStatementRequest struct {
+	ID          int64 `uri:"id" binding:"required,min=1"`
+	StatementID int64 `uri:"statement_id" binding:"required,min=1"`
+}
+
+// getArchivedStatement downloads a monthly statement exactly as it was archived
+func (server *Server) getArchivedStatement(ctx *gin.Context) {
+	var req getArchivedStatementRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, req.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	archived, err := server.store.GetStatement(ctx, req.StatementID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if archived.AccountID != account.ID {
+		err := fmt.Errorf("statement %d doesn't belong to account %d", archived.ID, account.ID)
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+
+	r, err := server.blobs.Open(ctx, archived.BlobKey)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer r.Close()
+
+	filename := fmt.Sprintf("statement-%d-%s.%s", account.ID, archived.PeriodStart.Format("2006-01"), statement.Extension(archived.Format))
+	ctx.DataFromReader(http.StatusOK, archived.Size, statement.ContentType(archived.Format), r, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="%s"`, filename),
+		"ETag":                fmt.Sprintf(`"%x"`, archived.Sha256),
+	})
+}
@@ -0,0 +1,556 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/iso20022"
+	"github.com/lordofthemind/backendMasterGo/statement"
+	"github.com/lordofthemind/backendMasterGo/token"
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountStatementAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	account.Currency = utils.USD
+	account.Balance = 20000
+	account.CreatedAt = time.Date(2026, 2, 15, 9, 30, 0, 0, time.UTC)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	entries := []db.ListStatementEntriesRow{
+		{ID: 1, Amount: 5000, CreatedAt: from.Add(time.Hour), Description: "rent share", TransferID: 7, Counterparty: "Bob"},
+		{ID: 2, Amount: -1250, CreatedAt: from.Add(2 * time.Hour), Description: "groceries", TransferID: 8, Counterparty: "Shop"},
+	}
+
+	// stubStatement reads the statement in a transaction, the opening balance is recomputed
+	// from the current balance of 200.00 with 150.00 of entries written since the first of March,
+	// the period ends at end
+	stubStatement := func(store *mockdb.MockStore, end time.Time) {
+		store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+		store.EXPECT().ReadTx(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, fn func(db.Querier) error) error {
+				return fn(store)
+			})
+		store.EXPECT().GetLatestBalanceSnapshot(gomock.Any(), gomock.Any()).Times(1).
+			Return(db.BalanceSnapshot{}, sql.ErrNoRows)
+		store.EXPECT().SumAccountEntriesSince(gomock.Any(), gomock.Eq(db.SumAccountEntriesSinceParams{
+			AccountID: account.ID,
+			StartTime: from,
+		})).Times(1).Return(int64(15000), nil)
+		store.EXPECT().SumStatementEntries(gomock.Any(), gomock.Eq(db.SumStatementEntriesParams{
+			AccountID: account.ID,
+			StartTime: from,
+			EndTime:   end,
+		})).Times(1).Return(db.SumStatementEntriesRow{Credits: 5000, Debits: -1250, CreditEntries: 1, DebitEntries: 1}, nil)
+		store.EXPECT().ListStatementEntries(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, arg db.ListStatementEntriesParams) ([]db.ListStatementEntriesRow, error) {
+				require.Equal(t, account.ID, arg.AccountID)
+				require.True(t, from.Equal(arg.StartTime))
+				require.True(t, end.Equal(arg.EndTime))
+				return entries, nil
+			})
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "CSV",
+			query: "from=2026-03-01&to=2026-03-31",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(2).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleViewer)
+				stubStatement(store, to)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+				require.Equal(t,
+					fmt.Sprintf(`attachment; filename="statement-%d-2026-03-01-2026-03-31.csv"`, account.ID),
+					recorder.Header().Get("Content-Disposition"))
+
+				rows, err := csv.NewReader(recorder.Body).ReadAll()
+				require.NoError(t, err)
+				require.Len(t, rows, 5)
+				require.Equal(t, "50.00", rows[1][8])
+				require.Equal(t, []string{"entry", "2026-03-01T01:00:00Z", "1", "7", "Bob", "rent share", "", "50.00", "100.00", utils.USD}, rows[2])
+				require.Equal(t, []string{"entry", "2026-03-01T02:00:00Z", "2", "8", "Shop", "groceries", "", "-12.50", "87.50", utils.USD}, rows[3])
+				require.Equal(t, "closing", rows[4][0])
+				require.Equal(t, "87.50", rows[4][8])
+			},
+		},
+		{
+			name:  "PDF",
+			query: "from=2026-03-01&to=2026-03-31&format=pdf",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(2).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				stubStatement(store, to)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+				require.True(t, strings.HasPrefix(recorder.Body.String(), "%PDF-"))
+				require.True(t, strings.HasSuffix(recorder.Body.String(), "%%EOF\n"))
+			},
+		},
+		{
+			name:  "OFX",
+			query: "from=2026-03-01&to=2026-03-31&format=ofx",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(2).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				stubStatement(store, to)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/x-ofx", recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Body.String(), "<FITID>2</FITID>")
+				require.Contains(t, recorder.Body.String(), "<BALAMT>87.50</BALAMT>")
+			},
+		},
+		{
+			name:  "CAMT053",
+			query: "from=2026-03-01&to=2026-03-31&format=camt053",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(2).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleViewer)
+				stubStatement(store, to)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/xml", recorder.Header().Get("Content-Type"))
+				require.Equal(t, fmt.Sprintf(`attachment; filename="statement-%d-2026-03-01-2026-03-31.xml"`, account.ID),
+					recorder.Header().Get("Content-Disposition"))
+
+				_, err := iso20022.Camt053.Validate(bytes.NewReader(recorder.Body.Bytes()))
+				require.NoError(t, err)
+				require.Contains(t, recorder.Body.String(), "<Cd>CLBD</Cd>")
+				require.Contains(t, recorder.Body.String(), `<Amt Ccy="USD">87.50</Amt>`)
+			},
+		},
+		{
+			name:  "MissingPeriod",
+			query: "from=2026-03-01",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ReadTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidFormat",
+			query: "from=2026-03-01&to=2026-03-31&format=xls",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ReadTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "ToBeforeFrom",
+			query: "from=2026-03-31&to=2026-03-01",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ReadTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "SingleDay",
+			query: "from=2026-03-01&to=2026-03-01",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(2).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleViewer)
+				stubStatement(store, from.AddDate(0, 0, 1))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t,
+					fmt.Sprintf(`attachment; filename="statement-%d-2026-03-01-2026-03-01.csv"`, account.ID),
+					recorder.Header().Get("Content-Disposition"))
+
+				rows, err := csv.NewReader(recorder.Body).ReadAll()
+				require.NoError(t, err)
+				require.Len(t, rows, 5)
+				require.Equal(t, "87.50", rows[4][8])
+			},
+		},
+		{
+			name:  "ToBeforeOpening",
+			query: "from=2026-01-01&to=2026-02-14",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ReadTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "was opened on 2026-02-15")
+			},
+		},
+		{
+			name:  "PeriodTooLong",
+			query: "from=2025-01-01&to=2026-03-31",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ReadTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "UnauthorizedUser",
+			query: "from=2026-03-01&to=2026-03-31",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, "unauthorized_user", "")
+				store.EXPECT().ReadTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: "from=2026-03-01&to=2026-03-31",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().ReadTx(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Empty(t, recorder.Header().Get("Content-Disposition"))
+				require.Contains(t, recorder.Header().Get("Content-Type"), "application/json")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/statements?%s", account.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListArchivedStatementsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	account.Currency = utils.EUR
+
+	archived := db.Statement{
+		ID:             3,
+		AccountID:      account.ID,
+		PeriodStart:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:      time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		Format:         statement.FormatPDF,
+		Size:           4,
+		Sha256:         []byte{0xab, 0xcd},
+		OpeningBalance: 1000,
+		ClosingBalance: 2550,
+	}
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleViewer)
+				store.EXPECT().ListAccountStatements(gomock.Any(), gomock.Eq(account.ID)).Times(1).
+					Return([]db.Statement{archived}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp []archivedStatementResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp, 1)
+				require.Equal(t, archived.ID, rsp[0].ID)
+				require.Equal(t, "2026-03-01", rsp[0].PeriodStart)
+				require.Equal(t, "2026-03-31", rsp[0].PeriodEnd)
+				require.Equal(t, "abcd", rsp[0].Sha256)
+				require.Equal(t, "10.00", rsp[0].OpeningBalance.Decimal())
+				require.Equal(t, "25.50", rsp[0].ClosingBalance.Decimal())
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, "unauthorized_user", "")
+				store.EXPECT().ListAccountStatements(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().ListAccountStatements(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/statements/archive", account.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetArchivedStatementAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	document := "%PDF-1.4 archived"
+	sum := sha256.Sum256([]byte(document))
+	archived := db.Statement{
+		ID:          3,
+		AccountID:   account.ID,
+		PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		Format:      statement.FormatPDF,
+		BlobKey:     statement.BlobKey(account.ID, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), statement.FormatPDF),
+		Size:        int64(len(document)),
+		Sha256:      sum[:],
+	}
+	other := archived
+	other.AccountID = account.ID + 1
+	missing := archived
+	missing.BlobKey = "statements/missing.pdf"
+
+	testCases := []struct {
+		name          string
+		statementID   int64
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			statementID: archived.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleViewer)
+				store.EXPECT().GetStatement(gomock.Any(), gomock.Eq(archived.ID)).Times(1).Return(archived, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, document, recorder.Body.String())
+				require.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+				require.Equal(t, fmt.Sprintf(`"%x"`, sum), recorder.Header().Get("ETag"))
+				require.Equal(t,
+					fmt.Sprintf(`attachment; filename="statement-%d-2026-03.pdf"`, account.ID),
+					recorder.Header().Get("Content-Disposition"))
+			},
+		},
+		{
+			name:        "OtherAccount",
+			statementID: other.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().GetStatement(gomock.Any(), gomock.Eq(other.ID)).Times(1).Return(other, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			statementID: archived.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().GetStatement(gomock.Any(), gomock.Eq(archived.ID)).Times(1).Return(db.Statement{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:        "MissingDocument",
+			statementID: missing.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().GetStatement(gomock.Any(), gomock.Eq(missing.ID)).Times(1).Return(missing, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:        "UnauthorizedUser",
+			statementID: archived.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				stubMember(store, account.ID, "unauthorized_user", "")
+				store.EXPECT().GetStatement(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			err := server.blobs.Put(context.Background(), archived.BlobKey, strings.NewReader(document))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/accounts/%d/statements/archive/%d", account.ID, tc.statementID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -2,19 +2,152 @@ package api
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
 	"github.com/lordofthemind/backendMasterGo/token"
+	"github.com/lordofthemind/backendMasterGo/utils"
 )
 
+// TransferRequest takes the amount as a decimal string in the currency, "12.34" EUR or "1234" JPY,
+// and the destination as either an account or a saved payee
 type TransferRequest struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountID int64                  `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID   int64                  `json:"to_account_id" binding:"omitempty,min=1"`
+	PayeeID       int64                  `json:"payee_id" binding:"omitempty,min=1"`
+	Amount        string                 `json:"amount" binding:"required"`
+	Currency      string                 `json:"currency" binding:"required,currency"`
+	Description   string                 `json:"description" binding:"max=255"`
+	Reference     string                 `json:"reference" binding:"max=64"`
+	Metadata      map[string]interface{} `json:"metadata" binding:"omitempty,metadata"`
+}
+
+type transferResponse struct {
+	ID            int64           `json:"id"`
+	FromAccountID int64           `json:"from_account_id,omitempty"`
+	ToAccountID   int64           `json:"to_account_id,omitempty"`
+	Amount        utils.Money     `json:"amount"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Description   string          `json:"description"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
+}
+
+// newTransferResponse needs the currency because transfers only store minor units,
+// both accounts of a transfer always share the same currency
+func newTransferResponse(transfer db.Transfer, currency string) (transferResponse, error) {
+	amount, err := utils.NewMoney(transfer.Amount, currency)
+	if err != nil {
+		return transferResponse{}, err
+	}
+	return transferResponse{
+		ID:            transfer.ID,
+		FromAccountID: transfer.FromAccountID,
+		ToAccountID:   transfer.ToAccountID,
+		Amount:        amount,
+		CreatedAt:     transfer.CreatedAt,
+		Description:   transfer.Description,
+		Reference:     transfer.Reference,
+		Metadata:      transfer.Metadata,
+	}, nil
+}
+
+func newTransfersResponse(transfers []db.Transfer, currency string) ([]transferResponse, error) {
+	rsp := make([]transferResponse, len(transfers))
+	for i, transfer := range transfers {
+		var err error
+		if rsp[i], err = newTransferResponse(transfer, currency); err != nil {
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
+// hideAccounts leaves out the accounts of the transfers the authenticated user may not view, the account
+// of a payee or of another user is not theirs to learn. visible remembers the accounts already checked.
+func (server *Server) hideAccounts(ctx *gin.Context, transfers []transferResponse, visible map[int64]bool) error {
+	for i := range transfers {
+		for _, accountID := range []*int64{&transfers[i].FromAccountID, &transfers[i].ToAccountID} {
+			ok, err := server.canViewAccount(ctx, *accountID, visible)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				*accountID = 0
+			}
+		}
+	}
+	return nil
+}
+
+// canViewAccount tells whether the authenticated user may view the account, remembering it in visible
+func (server *Server) canViewAccount(ctx *gin.Context, accountID int64, visible map[int64]bool) (bool, error) {
+	if ok, known := visible[accountID]; known {
+		return ok, nil
+	}
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		return false, err
+	}
+	status, err := server.accountAccess(ctx, account, db.AccountPermissionView)
+	if status == http.StatusInternalServerError {
+		return false, err
+	}
+	visible[accountID] = err == nil
+	return err == nil, nil
+}
+
+type transferTxResponse struct {
+	Transfer    transferResponse `json:"transfer"`
+	FromAccount accountResponse  `json:"from_account"`
+	ToAccount   *accountResponse `json:"to_account,omitempty"`
+	FromEntry   entryResponse    `json:"from_entry"`
+	ToEntry     *entryResponse   `json:"to_entry,omitempty"`
+}
+
+func newTransferTxResponse(result db.TransferTxResult, currency string) (rsp transferTxResponse, err error) {
+	if rsp.Transfer, err = newTransferResponse(result.Transfer, currency); err != nil {
+		return
+	}
+	if rsp.FromAccount, err = newAccountResponse(result.FromAccount); err != nil {
+		return
+	}
+	toAccount, err := newAccountResponse(result.ToAccount)
+	if err != nil {
+		return
+	}
+	rsp.ToAccount = &toAccount
+	if rsp.FromEntry, err = newEntryResponse(result.FromEntry, currency); err != nil {
+		return
+	}
+	toEntry, err := newEntryResponse(result.ToEntry, currency)
+	rsp.ToEntry = &toEntry
+	return
+}
+
+// visibleTransferTxResponse leaves out the account the money went to and its entry unless the authenticated
+// user may view that account, the account of a payee or of another user is not the payer's to see
+func (server *Server) visibleTransferTxResponse(ctx *gin.Context, result db.TransferTxResult, currency string) (transferTxResponse, error) {
+	rsp, err := newTransferTxResponse(result, currency)
+	if err != nil {
+		return rsp, err
+	}
+
+	status, err := server.accountAccess(ctx, result.ToAccount, db.AccountPermissionView)
+	if status == http.StatusInternalServerError {
+		return rsp, err
+	}
+	if err != nil {
+		rsp.Transfer.ToAccountID = 0
+		rsp.ToAccount = nil
+		rsp.ToEntry = nil
+	}
+	return rsp, nil
 }
 
 func (server *Server) createTransfer(ctx *gin.Context) {
@@ -24,37 +157,355 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
-	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
-	if !valid {
+	if (req.ToAccountID == 0) == (req.PayeeID == 0) {
+		err := errors.New("exactly one of to_account_id and payee_id is required")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if fromAccount.Owner != authPayload.Username {
-		err := fmt.Errorf("account %d does not belong to user %s", req.FromAccountID, authPayload.Username)
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	amount, err := utils.ParseMoney(req.Amount, req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !amount.IsPositive() {
+		err := fmt.Errorf("amount %s must be positive", amount)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
-	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
+
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency, db.AccountDebit)
 	if !valid {
 		return
 	}
 
+	if !server.authorizeAccount(ctx, fromAccount, db.AccountPermissionSpend) {
+		return
+	}
+
+	toAccountID := req.ToAccountID
+	if req.PayeeID != 0 {
+		if toAccountID, valid = server.payeeAccount(ctx, req.PayeeID, amount); !valid {
+			return
+		}
+	}
+	if _, valid = server.validAccount(ctx, toAccountID, req.Currency, db.AccountCredit); !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 	arg := db.TransferTxParams{
 		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
-		Amount:        req.Amount,
+		ToAccountID:   toAccountID,
+		Amount:        amount.Amount(),
+		Description:   req.Description,
+		Reference:     req.Reference,
+		InitiatedBy:   authPayload.Username,
+	}
+
+	if req.Metadata != nil {
+		metadata, err := json.Marshal(req.Metadata)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.Metadata = metadata
+	}
+
+	hold, valid := server.approvalHold(ctx, fromAccount, amount)
+	if !valid {
+		return
+	}
+	if !hold {
+		result, err := server.store.TransferTx(ctx, arg)
+		if err == nil {
+			rsp, err := server.visibleTransferTxResponse(ctx, result, req.Currency)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusOK, rsp)
+			return
+		}
+		// the store has the last word on the approval threshold
+		if !errors.Is(err, db.ErrApprovalRequired) {
+			transferTxError(ctx, err)
+			return
+		}
+		if !server.approverAvailable(ctx, fromAccount) {
+			return
+		}
+	}
+
+	approval, valid := server.holdTransfer(ctx, arg)
+	if !valid {
+		return
+	}
+	rsp, err := newTransferApprovalResponse(approval, req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusAccepted, rsp)
+}
+
+type searchTransfersRequest struct {
+	Reference string `form:"reference" binding:"required,max=64"`
+}
+
+func (server *Server) searchTransfers(ctx *gin.Context) {
+	var req searchTransfersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.ListTransfersByReferenceParams{
+		Reference: req.Reference,
+		Username:  authPayload.Username,
+		OrgID:     orgID(ctx),
+	}
+
+	rows, err := server.store.ListTransfersByReference(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]transferResponse, len(rows))
+	for i, row := range rows {
+		transfer := db.Transfer{
+			ID:            row.ID,
+			FromAccountID: row.FromAccountID,
+			ToAccountID:   row.ToAccountID,
+			Amount:        row.Amount,
+			CreatedAt:     row.CreatedAt,
+			Description:   row.Description,
+			Reference:     row.Reference,
+			Metadata:      row.Metadata,
+		}
+		if rsp[i], err = newTransferResponse(transfer, row.Currency); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+	if err := server.hideAccounts(ctx, rsp, make(map[int64]bool)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type historyFilterRequest struct {
+	pageRequest
+	Direction string    `form:"direction" binding:"omitempty,oneof=in out"`
+	StartTime time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime   time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	// MinAmount and MaxAmount are decimal strings in the currency of the account
+	MinAmount string `form:"min_amount"`
+	MaxAmount string `form:"max_amount"`
+}
+
+func (req historyFilterRequest) validate() error {
+	if !req.StartTime.IsZero() && !req.EndTime.IsZero() && !req.EndTime.After(req.StartTime) {
+		return errors.New("end_time must be after start_time")
+	}
+	return nil
+}
+
+// amountRange parses min_amount and max_amount in the currency of the account
+func (req historyFilterRequest) amountRange(currency string) (minAmount, maxAmount sql.NullInt64, err error) {
+	if minAmount, err = parseAmountFilter("min_amount", req.MinAmount, currency); err != nil {
+		return
+	}
+	if maxAmount, err = parseAmountFilter("max_amount", req.MaxAmount, currency); err != nil {
+		return
 	}
+	if minAmount.Valid && maxAmount.Valid && maxAmount.Int64 < minAmount.Int64 {
+		err = errors.New("max_amount must not be less than min_amount")
+	}
+	return
+}
 
-	result, err := server.store.TransferTx(ctx, arg)
+func parseAmountFilter(name string, value string, currency string) (sql.NullInt64, error) {
+	if value == "" {
+		return sql.NullInt64{}, nil
+	}
+	amount, err := utils.ParseMoney(value, currency)
+	if err != nil {
+		return sql.NullInt64{}, fmt.Errorf("%s: %w", name, err)
+	}
+	if !amount.IsPositive() {
+		return sql.NullInt64{}, fmt.Errorf("%s must be positive", name)
+	}
+	return sql.NullInt64{Int64: amount.Amount(), Valid: true}, nil
+}
+
+func (req historyFilterRequest) direction() sql.NullString {
+	return sql.NullString{String: req.Direction, Valid: req.Direction != ""}
+}
+
+func (req historyFilterRequest) startTime() sql.NullTime {
+	return sql.NullTime{Time: req.StartTime, Valid: !req.StartTime.IsZero()}
+}
+
+func (req historyFilterRequest) endTime() sql.NullTime {
+	return sql.NullTime{Time: req.EndTime, Valid: !req.EndTime.IsZero()}
+}
+
+func (server *Server) listAccountTransfers(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req historyFilterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := req.validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	p, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizedAccount(ctx, uri.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	minAmount, maxAmount, err := req.amountRange(account.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	transfers, err := keysetPage(ctx, p, func(transfer db.Transfer) int64 { return transfer.ID },
+		func(after bool, cursorID int64, limit int32) ([]db.Transfer, error) {
+			if p.Legacy {
+				return server.store.ListAccountTransfers(ctx, db.ListAccountTransfersParams{
+					AccountID:  account.ID,
+					Direction:  req.direction(),
+					StartTime:  req.startTime(),
+					EndTime:    req.endTime(),
+					MinAmount:  minAmount,
+					MaxAmount:  maxAmount,
+					PageLimit:  limit,
+					PageOffset: p.Offset,
+				})
+			}
+			if after {
+				return server.store.ListAccountTransfersAfter(ctx, db.ListAccountTransfersAfterParams{
+					AccountID: account.ID,
+					CursorID:  cursorID,
+					Direction: req.direction(),
+					StartTime: req.startTime(),
+					EndTime:   req.endTime(),
+					MinAmount: minAmount,
+					MaxAmount: maxAmount,
+					PageLimit: limit,
+				})
+			}
+			return server.store.ListAccountTransfersBefore(ctx, db.ListAccountTransfersBeforeParams{
+				AccountID: account.ID,
+				CursorID:  cursorID,
+				Direction: req.direction(),
+				StartTime: req.startTime(),
+				EndTime:   req.endTime(),
+				MinAmount: minAmount,
+				MaxAmount: maxAmount,
+				PageLimit: limit,
+			})
+		})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp, err := newTransfersResponse(transfers, account.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if err := server.hideAccounts(ctx, rsp, map[int64]bool{account.ID: true}); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type getTransferRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getTransfer(ctx *gin.Context) {
+	var req getTransferRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	transfer, err := server.store.GetTransfer(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the transfer is visible through either of its accounts, the other one only when it is visible too
+	visible := make(map[int64]bool)
+	var currency string
+	for _, accountID := range []int64{transfer.FromAccountID, transfer.ToAccountID} {
+		account, err := server.store.GetAccount(ctx, accountID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		status, err := server.accountAccess(ctx, account, db.AccountPermissionView)
+		if status == http.StatusInternalServerError {
+			ctx.JSON(status, errorResponse(err))
+			return
+		}
+		visible[account.ID] = err == nil
+		if err == nil {
+			currency = account.Currency
+		}
+	}
+	if currency == "" {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		err = fmt.Errorf("transfer %d does not belong to user %s", req.ID, authPayload.Username)
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	rsp, err := newTransferResponse(transfer, currency)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, result)
+	rsps := []transferResponse{rsp}
+	if err := server.hideAccounts(ctx, rsps, visible); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsps[0])
 }
 
-func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
+// validAccount checks that the account exists, uses the currency and that its
+// status allows the operation (db.AccountDebit or db.AccountCredit)
+func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string, operation string) (db.Account, bool) {
 	account, err := server.store.GetAccount(ctx, accountID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -64,6 +515,10 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return account, false
 	}
+	if err := db.CheckAccountStatus(account, operation); err != nil {
+		ctx.JSON(http.StatusForbidden, errorResponse(fmt.Errorf("account %d: %w", accountID, err)))
+		return account, false
+	}
 	if account.Currency != currency {
 		err := fmt.Errorf("account %d does not support currency %s", accountID, currency)
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
@@ -71,3 +526,24 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 	}
 	return account, true
 }
+
+// transferTxError replies to an error returned by TransferTx, transfers refused by limits
+// or account statuses are forbidden
+func transferTxError(ctx *gin.Context, err error) {
+	var limitErr *db.LimitExceededError
+	if errors.As(err, &limitErr) {
+		ctx.JSON(http.StatusForbidden, limitExceededResponse(limitErr))
+		return
+	}
+	var coolingOffErr *db.PayeeCoolingOffError
+	if errors.Is(err, db.ErrAccountClosed) || errors.Is(err, db.ErrAccountStatusViolation) ||
+		errors.Is(err, db.ErrApprovalRequired) || errors.As(err, &coolingOffErr) {
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+}
+
+func limitExceededResponse(err *db.LimitExceededError) gin.H {
+	return gin.H{"error": err.Error(), "limit": err}
+}
@@ -0,0 +1,337 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/token"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+// defaultApprovalExpiry applies when APPROVAL_EXPIRY is not configured
+const defaultApprovalExpiry = 72 * time.Hour
+
+// aboveLimit reports whether the amount is above the limit of its currency,
+// currencies without a limit are never above it
+func aboveLimit(limits map[string]utils.Money, amount utils.Money) bool {
+	limit, ok := limits[amount.Currency()]
+	if !ok {
+		return false
+	}
+	cmp, err := amount.Cmp(limit)
+	return err == nil && cmp > 0
+}
+
+// requiresApproval reports whether the amount is above the approval threshold of its currency
+func (server *Server) requiresApproval(amount utils.Money) bool {
+	return aboveLimit(server.approvalThresholds, amount)
+}
+
+// errNoApprover refuses a transfer above the approval threshold that nobody could ever approve
+var errNoApprover = errors.New("the amount is above the approval threshold and nobody else can approve transfers from this account")
+
+// holdForApproval reports whether the transfer waits for a second user to approve it. Only joint and organization
+// accounts have someone else who can, a transfer above the threshold from any other account fails with errNoApprover.
+func (server *Server) holdForApproval(ctx *gin.Context, account db.Account, amount utils.Money, initiator string) (bool, error) {
+	if !server.requiresApproval(amount) {
+		return false, nil
+	}
+	approver, err := server.hasApprover(ctx, account, initiator)
+	if err != nil {
+		return false, err
+	}
+	if !approver {
+		return false, errNoApprover
+	}
+	return true, nil
+}
+
+// hasApprover reports whether a user other than the initiator may approve transfers from the account
+func (server *Server) hasApprover(ctx *gin.Context, account db.Account, initiator string) (bool, error) {
+	if account.OrgID.Valid {
+		members, err := server.store.ListOrganizationMembers(ctx, account.OrgID.Int64)
+		if err != nil {
+			return false, err
+		}
+		for _, member := range members {
+			if member.Username != initiator && member.Can(db.AccountPermissionApprove) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	members, err := server.store.ListAccountMembers(ctx, account.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, member := range members {
+		if member.Username != initiator && member.Can(db.AccountPermissionApprove) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// approvalHold replies with an error when the transfer cannot go ahead for want of an approver,
+// hold reports whether it must be held for approval
+func (server *Server) approvalHold(ctx *gin.Context, account db.Account, amount utils.Money) (hold bool, valid bool) {
+	if !server.requiresApproval(amount) {
+		return false, true
+	}
+	return true, server.approverAvailable(ctx, account)
+}
+
+// approverAvailable replies with errNoApprover when nobody but the authenticated user can approve
+// transfers from the account
+func (server *Server) approverAvailable(ctx *gin.Context, account db.Account) bool {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	approver, err := server.hasApprover(ctx, account, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	if !approver {
+		ctx.JSON(http.StatusForbidden, errorResponse(errNoApprover))
+		return false
+	}
+	return true
+}
+
+type transferApprovalResponse struct {
+	ID            int64           `json:"id"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        utils.Money     `json:"amount"`
+	Description   string          `json:"description"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
+	Status        string          `json:"status"`
+	InitiatedBy   string          `json:"initiated_by"`
+	DecidedBy     sql.NullString  `json:"decided_by"`
+	TransferID    sql.NullInt64   `json:"transfer_id"`
+	ExpiresAt     time.Time       `json:"expires_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DecidedAt     sql.NullTime    `json:"decided_at"`
+}
+
+func newTransferApprovalResponse(approval db.TransferApproval, currency string) (transferApprovalResponse, error) {
+	amount, err := utils.NewMoney(approval.Amount, currency)
+	if err != nil {
+		return transferApprovalResponse{}, err
+	}
+	return transferApprovalResponse{
+		ID:            approval.ID,
+		FromAccountID: approval.FromAccountID,
+		ToAccountID:   approval.ToAccountID,
+		Amount:        amount,
+		Description:   approval.Description,
+		Reference:     approval.Reference,
+		Metadata:      approval.Metadata,
+		Status:        approval.Status,
+		InitiatedBy:   approval.InitiatedBy,
+		DecidedBy:     approval.DecidedBy,
+		TransferID:    approval.TransferID,
+		ExpiresAt:     approval.ExpiresAt,
+		CreatedAt:     approval.CreatedAt,
+		DecidedAt:     approval.DecidedAt,
+	}, nil
+}
+
+// approvalRequest holds the transfer in the name of the authenticated user until the approval expiry
+func (server *Server) approvalRequest(ctx *gin.Context, arg db.TransferTxParams) db.CreateTransferApprovalTxParams {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	expiry := server.config.ApprovalExpiry
+	if expiry <= 0 {
+		expiry = defaultApprovalExpiry
+	}
+
+	arg.InitiatedBy = authPayload.Username
+	return db.CreateTransferApprovalTxParams{
+		TransferTxParams: arg,
+		ExpiresAt:        time.Now().Add(expiry),
+	}
+}
+
+// holdTransfer records the transfer as pending until a second user approves it
+func (server *Server) holdTransfer(ctx *gin.Context, arg db.TransferTxParams) (db.TransferApproval, bool) {
+	approval, err := server.store.CreateTransferApprovalTx(ctx, server.approvalRequest(ctx, arg))
+	if err != nil {
+		transferTxError(ctx, err)
+		return approval, false
+	}
+	return approval, true
+}
+
+// listTransferApprovals lists the pending transfers of the accounts the user can see,
+// the accounts of the organization when acting for one
+func (server *Server) listTransferApprovals(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	rows, err := server.store.ListPendingTransferApprovals(ctx, db.ListPendingTransferApprovalsParams{
+		OrgID:    orgID(ctx),
+		Username: authPayload.Username,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]transferApprovalResponse, len(rows))
+	for i, row := range rows {
+		approval := db.TransferApproval{
+			ID:            row.ID,
+			FromAccountID: row.FromAccountID,
+			ToAccountID:   row.ToAccountID,
+			Amount:        row.Amount,
+			Description:   row.Description,
+			Reference:     row.Reference,
+			Metadata:      row.Metadata,
+			Status:        row.Status,
+			InitiatedBy:   row.InitiatedBy,
+			DecidedBy:     row.DecidedBy,
+			TransferID:    row.TransferID,
+			ExpiresAt:     row.ExpiresAt,
+			CreatedAt:     row.CreatedAt,
+			DecidedAt:     row.DecidedAt,
+		}
+		if rsp[i], err = newTransferApprovalResponse(approval, row.Currency); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type getTransferApprovalRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type transferApprovalDetailsResponse struct {
+	transferApprovalResponse
+	Events []db.TransferApprovalEvent `json:"events"`
+}
+
+// getTransferApproval returns a transfer approval with its audit trail
+func (server *Server) getTransferApproval(ctx *gin.Context) {
+	var req getTransferApprovalRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	approval, account, valid := server.authorizedTransferApproval(ctx, req.ID, db.AccountPermissionView)
+	if !valid {
+		return
+	}
+
+	events, err := server.store.ListTransferApprovalEvents(ctx, approval.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp, err := newTransferApprovalResponse(approval, account.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, transferApprovalDetailsResponse{transferApprovalResponse: rsp, Events: events})
+}
+
+type decideTransferApprovalRequest struct {
+	Note string `json:"note" binding:"max=255"`
+}
+
+type decideTransferApprovalResponse struct {
+	Approval transferApprovalResponse `json:"approval"`
+	Transfer *transferTxResponse      `json:"transfer,omitempty"`
+}
+
+func (server *Server) approveTransfer(ctx *gin.Context) {
+	server.decideTransferApproval(ctx, true)
+}
+
+func (server *Server) rejectTransfer(ctx *gin.Context) {
+	server.decideTransferApproval(ctx, false)
+}
+
+// decideTransferApproval approves or rejects a pending transfer, the approver must differ from the initiator
+func (server *Server) decideTransferApproval(ctx *gin.Context, approve bool) {
+	var uri getTransferApprovalRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req decideTransferApprovalRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	approval, account, valid := server.authorizedTransferApproval(ctx, uri.ID, db.AccountPermissionApprove)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if approval.InitiatedBy == authPayload.Username {
+		ctx.JSON(http.StatusForbidden, errorResponse(db.ErrSelfApproval))
+		return
+	}
+
+	result, err := server.store.DecideTransferApprovalTx(ctx, db.DecideTransferApprovalTxParams{
+		ApprovalID: approval.ID,
+		Approver:   authPayload.Username,
+		Approve:    approve,
+		Note:       req.Note,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrApprovalNotPending) || errors.Is(err, db.ErrApprovalExpired) || errors.Is(err, db.ErrSelfApproval) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		transferTxError(ctx, err)
+		return
+	}
+
+	var rsp decideTransferApprovalResponse
+	if rsp.Approval, err = newTransferApprovalResponse(result.Approval, account.Currency); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if result.Transfer != nil {
+		transfer, err := server.visibleTransferTxResponse(ctx, *result.Transfer, account.Currency)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Transfer = &transfer
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// authorizedTransferApproval loads the approval and checks the permission on the account the money leaves
+func (server *Server) authorizedTransferApproval(ctx *gin.Context, approvalID int64, permission string) (db.TransferApproval, db.Account, bool) {
+	approval, err := server.store.GetTransferApproval(ctx, approvalID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return approval, db.Account{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return approval, db.Account{}, false
+	}
+
+	account, valid := server.authorizedAccount(ctx, approval.FromAccountID, permission)
+	return approval, account, valid
+}
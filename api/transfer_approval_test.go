@@ -0,0 +1,460 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func newApprovalTestServer(t *testing.T, store db.Store) *Server {
+	rg := utils.NewRandomGenerator()
+	config := utils.Config{
+		TokenSymmetricKey:   rg.RandomString(32),
+		AccessTokenDuration: time.Minute,
+		ApprovalThresholds:  []string{"USD:1000.00"},
+		ApprovalExpiry:      time.Hour,
+	}
+
+	server, err := NewServer(config, store)
+	require.NoError(t, err)
+
+	return server
+}
+
+func TestApprovalThresholds(t *testing.T) {
+	amounts, err := utils.ParseCurrencyAmounts([]string{"usd:1000.00", " JPY:50000"})
+	require.NoError(t, err)
+
+	above, err := utils.ParseMoney("1000.01", utils.USD)
+	require.NoError(t, err)
+	require.True(t, aboveLimit(amounts, above))
+	require.False(t, aboveLimit(amounts, amounts[utils.USD]))
+
+	rg := utils.NewRandomGenerator()
+	_, err = NewServer(utils.Config{
+		TokenSymmetricKey:  rg.RandomString(32),
+		ApprovalThresholds: []string{"USD"},
+	}, nil)
+	require.Error(t, err)
+}
+
+func TestTransferOverApprovalThreshold(t *testing.T) {
+	user, _ := randomUser(t)
+
+	account1 := randomAccount(user.Username)
+	account2 := randomAccount("recipient")
+	account1.Currency = utils.USD
+	account2.Currency = utils.USD
+
+	testCases := []struct {
+		name          string
+		amount        string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Held",
+			amount: "1000.01",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				stubMember(store, account1.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				stubAccountMembers(store, account1.ID,
+					randomMember(account1.ID, user.Username, db.MemberRoleOwner),
+					randomMember(account1.ID, "co_owner", db.MemberRoleOwner))
+
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateTransferApprovalTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateTransferApprovalTxParams) (db.TransferApproval, error) {
+						require.Equal(t, int64(100001), arg.Amount)
+						require.Equal(t, user.Username, arg.InitiatedBy)
+						require.WithinDuration(t, time.Now().Add(time.Hour), arg.ExpiresAt, time.Minute)
+						return db.TransferApproval{
+							ID:            1,
+							FromAccountID: arg.FromAccountID,
+							ToAccountID:   arg.ToAccountID,
+							Amount:        arg.Amount,
+							Status:        db.ApprovalStatusPending,
+							InitiatedBy:   arg.InitiatedBy,
+							ExpiresAt:     arg.ExpiresAt,
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+
+				var rsp transferApprovalResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, db.ApprovalStatusPending, rsp.Status)
+				require.Equal(t, "1000.01", rsp.Amount.Decimal())
+			},
+		},
+		{
+			name:   "NoApprover",
+			amount: "1000.01",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				stubMember(store, account1.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				// a spender cannot approve, the transfer would be held forever
+				stubAccountMembers(store, account1.ID,
+					randomMember(account1.ID, user.Username, db.MemberRoleOwner),
+					randomMember(account1.ID, "spender", db.MemberRoleSpender))
+
+				store.EXPECT().CreateTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errNoApprover.Error())
+			},
+		},
+		{
+			name:   "AtThreshold",
+			amount: "1000.00",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				stubMember(store, account1.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+
+				store.EXPECT().CreateTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.TransferTxResult{FromAccount: account1, ToAccount: account2}, nil)
+				stubMember(store, account2.ID, user.Username, "")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "HeldByStore",
+			amount: "999.00",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				stubMember(store, account1.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				stubAccountMembers(store, account1.ID,
+					randomMember(account1.ID, user.Username, db.MemberRoleOwner),
+					randomMember(account1.ID, "co_owner", db.MemberRoleOwner))
+
+				// the store enforces its own threshold, the transfer is held instead of refused
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, db.ErrApprovalRequired)
+				store.EXPECT().CreateTransferApprovalTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateTransferApprovalTxParams) (db.TransferApproval, error) {
+						require.Equal(t, int64(99900), arg.Amount)
+						require.Equal(t, user.Username, arg.InitiatedBy)
+						return db.TransferApproval{
+							ID:            2,
+							FromAccountID: arg.FromAccountID,
+							ToAccountID:   arg.ToAccountID,
+							Amount:        arg.Amount,
+							Status:        db.ApprovalStatusPending,
+							InitiatedBy:   arg.InitiatedBy,
+							ExpiresAt:     arg.ExpiresAt,
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+
+				var rsp transferApprovalResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, db.ApprovalStatusPending, rsp.Status)
+				require.Equal(t, "999.00", rsp.Amount.Decimal())
+			},
+		},
+		{
+			name:   "NoApproverByStore",
+			amount: "999.00",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				stubMember(store, account1.ID, user.Username, db.MemberRoleOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				stubAccountMembers(store, account1.ID,
+					randomMember(account1.ID, user.Username, db.MemberRoleOwner))
+
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, db.ErrApprovalRequired)
+				store.EXPECT().CreateTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errNoApprover.Error())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newApprovalTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          tc.amount,
+				"currency":        utils.USD,
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDecideTransferApprovalAPI(t *testing.T) {
+	initiator, _ := randomUser(t)
+	approver, _ := randomUser(t)
+
+	account1 := randomAccount(initiator.Username)
+	account2 := randomAccount("recipient")
+	account1.Currency = utils.USD
+	account2.Currency = utils.USD
+
+	approval := db.TransferApproval{
+		ID:            9,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        500000,
+		Metadata:      json.RawMessage(`{}`),
+		Status:        db.ApprovalStatusPending,
+		InitiatedBy:   initiator.Username,
+		ExpiresAt:     time.Now().Add(time.Hour),
+	}
+
+	testCases := []struct {
+		name          string
+		action        string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Approve",
+			action:   "approve",
+			username: approver.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				stubMember(store, account1.ID, approver.Username, db.MemberRoleOwner)
+
+				arg := db.DecideTransferApprovalTxParams{
+					ApprovalID: approval.ID,
+					Approver:   approver.Username,
+					Approve:    true,
+					Note:       "checked",
+				}
+				approved := approval
+				approved.Status = db.ApprovalStatusApproved
+				approved.DecidedBy = sql.NullString{String: approver.Username, Valid: true}
+				approved.TransferID = sql.NullInt64{Int64: 3, Valid: true}
+				store.EXPECT().DecideTransferApprovalTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.DecideTransferApprovalTxResult{
+						Approval: approved,
+						Transfer: &db.TransferTxResult{
+							Transfer:    db.Transfer{ID: 3, FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: approval.Amount},
+							FromAccount: account1,
+							ToAccount:   account2,
+						},
+					}, nil)
+				stubMember(store, account2.ID, approver.Username, "")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp decideTransferApprovalResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, db.ApprovalStatusApproved, rsp.Approval.Status)
+				require.NotNil(t, rsp.Transfer)
+				require.Equal(t, int64(3), rsp.Transfer.Transfer.ID)
+				require.Nil(t, rsp.Transfer.ToAccount)
+				require.Nil(t, rsp.Transfer.ToEntry)
+			},
+		},
+		{
+			name:     "Reject",
+			action:   "reject",
+			username: approver.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				stubMember(store, account1.ID, approver.Username, db.MemberRoleOwner)
+
+				rejected := approval
+				rejected.Status = db.ApprovalStatusRejected
+				store.EXPECT().DecideTransferApprovalTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.DecideTransferApprovalTxParams) (db.DecideTransferApprovalTxResult, error) {
+						require.False(t, arg.Approve)
+						return db.DecideTransferApprovalTxResult{Approval: rejected}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp decideTransferApprovalResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, db.ApprovalStatusRejected, rsp.Approval.Status)
+				require.Nil(t, rsp.Transfer)
+			},
+		},
+		{
+			name:     "SelfApproval",
+			action:   "approve",
+			username: initiator.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				stubMember(store, account1.ID, initiator.Username, db.MemberRoleOwner)
+				store.EXPECT().DecideTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "SpenderCannotApprove",
+			action:   "approve",
+			username: approver.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				stubMember(store, account1.ID, approver.Username, db.MemberRoleSpender)
+				store.EXPECT().DecideTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Expired",
+			action:   "approve",
+			username: approver.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				stubMember(store, account1.ID, approver.Username, db.MemberRoleOwner)
+				store.EXPECT().DecideTransferApprovalTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.DecideTransferApprovalTxResult{}, db.ErrApprovalExpired)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			action:   "reject",
+			username: approver.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).
+					Return(db.TransferApproval{}, sql.ErrNoRows)
+				store.EXPECT().DecideTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"note": "checked"})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/transfer-approvals/%d/%s", approval.ID, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetTransferApprovalAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	approval := db.TransferApproval{
+		ID:            9,
+		FromAccountID: account.ID,
+		ToAccountID:   account.ID + 1,
+		Amount:        500000,
+		Status:        db.ApprovalStatusRejected,
+		InitiatedBy:   user.Username,
+	}
+	events := []db.TransferApprovalEvent{
+		{ID: 1, ApprovalID: approval.ID, Action: db.ApprovalActionRequested, Actor: sql.NullString{String: user.Username, Valid: true}},
+		{ID: 2, ApprovalID: approval.ID, Action: db.ApprovalActionRejected, Actor: sql.NullString{String: "approver", Valid: true}},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	stubMember(store, account.ID, user.Username, db.MemberRoleViewer)
+	store.EXPECT().ListTransferApprovalEvents(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(events, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/transfer-approvals/%d", approval.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var rsp transferApprovalDetailsResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &rsp)
+	require.NoError(t, err)
+	require.Equal(t, db.ApprovalStatusRejected, rsp.Status)
+	require.Equal(t, events, rsp.Events)
+}
+
+// stubAccountMembers lists the members of an account, a transfer above the approval threshold looks for a second approver among them
+func stubAccountMembers(store *mockdb.MockStore, accountID int64, members ...db.AccountMember) {
+	store.EXPECT().ListAccountMembers(gomock.Any(), gomock.Eq(accountID)).Times(1).Return(members, nil)
+}
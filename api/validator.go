@@ -1,13 +1,90 @@
 package api
 
 import (
+	"encoding/json"
+	"strconv"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/lordofthemind/backendMasterGo/utils"
 )
 
-var validCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool {
-	if currency, ok := fieldLevel.Field().Interface().(string); ok {
-		return utils.IsSupportedCurrency(currency)
+const (
+	maxMetadataKeys  = 20
+	maxMetadataBytes = 4096
+)
+
+// currencyValidator accepts the currencies enabled in the registry
+func currencyValidator(currencies *utils.CurrencyRegistry) validator.Func {
+	return func(fieldLevel validator.FieldLevel) bool {
+		if currency, ok := fieldLevel.Field().Interface().(string); ok {
+			return currencies.IsEnabled(currency)
+		}
+		return false
+	}
+}
+
+var validMetadata validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	metadata, ok := fieldLevel.Field().Interface().(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if len(metadata) > maxMetadataKeys {
+		return false
+	}
+	return jsonbTextSize(metadata) <= maxMetadataBytes
+}
+
+// jsonbTextSize is the size of the value as Postgres prints a jsonb column, which the size checks of
+// the details columns measure. It puts a space after every colon and comma, writes numbers without an
+// exponent and only escapes the characters JSON requires.
+func jsonbTextSize(value interface{}) int {
+	switch value := value.(type) {
+	case nil:
+		return len("null")
+	case bool:
+		return len(strconv.FormatBool(value))
+	case float64:
+		return len(strconv.FormatFloat(value, 'f', -1, 64))
+	case string:
+		return jsonbStringSize(value)
+	case []interface{}:
+		size := len("[]") + separatorsSize(len(value))
+		for _, element := range value {
+			size += jsonbTextSize(element)
+		}
+		return size
+	case map[string]interface{}:
+		size := len("{}") + separatorsSize(len(value))
+		for key, element := range value {
+			size += jsonbStringSize(key) + len(": ") + jsonbTextSize(element)
+		}
+		return size
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return maxMetadataBytes + 1
+	}
+	return len(data)
+}
+
+// separatorsSize is the size of the commas between the elements of an array or object
+func separatorsSize(elements int) int {
+	if elements < 2 {
+		return 0
+	}
+	return (elements - 1) * len(", ")
+}
+
+// jsonbStringSize is the size of the quoted string as Postgres prints it in a jsonb column
+func jsonbStringSize(s string) int {
+	size := len(`""`) + len(s)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"', c == '\\', c == '\b', c == '\f', c == '\n', c == '\r', c == '\t':
+			size++
+		case c < 0x20:
+			size += len(`\u0000`) - 1
+		}
 	}
-	return false
+	return size
 }
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONBTextSize(t *testing.T) {
+	testCases := []struct {
+		metadata string
+		// jsonb is how Postgres prints the metadata
+		jsonb string
+	}{
+		{metadata: `{}`, jsonb: `{}`},
+		{metadata: `{"a":1}`, jsonb: `{"a": 1}`},
+		{metadata: `{"a":[1,2,3],"b":{"c":null,"d":true}}`, jsonb: `{"a": [1, 2, 3], "b": {"c": null, "d": true}}`},
+		{metadata: `{"big":1e21,"small":1e-7,"rate":1.50}`, jsonb: `{"big": 1000000000000000000000, "rate": 1.5, "small": 0.0000001}`},
+		{metadata: `{"note":"<a & b>\n\"c\"\u0001é"}`, jsonb: `{"note": "<a & b>\n\"c\"\u0001é"}`},
+	}
+
+	for _, tc := range testCases {
+		var metadata map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(tc.metadata), &metadata))
+		require.Equal(t, len(tc.jsonb), jsonbTextSize(metadata), tc.metadata)
+	}
+}
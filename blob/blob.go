@@ -0,0 +1,139 @@
+// Package blob stores documents, such as archived statements, outside the database.
+//
+// Objects are immutable: a key is written once and never overwritten or removed,
+// so what was stored is exactly what can be read back later.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned when reading a key that was never written
+	ErrNotFound = errors.New("blob not found")
+	// ErrExists is returned when writing a key that was already written
+	ErrExists = errors.New("blob already exists")
+)
+
+// Storage keeps immutable objects under slash separated keys
+type Storage interface {
+	// Put writes the object, it fails with ErrExists when the key is taken
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Open reads the object, it fails with ErrNotFound when the key was never written
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// ValidKey reports whether key is a relative slash separated path without . or .. elements
+func ValidKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key {
+		return false
+	}
+	for _, element := range strings.Split(key, "/") {
+		if element == "." || element == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// DefaultLocalRoot is the root of a LocalStorage when none is configured
+const DefaultLocalRoot = "data/blobs"
+
+// LocalStorage keeps the objects as read-only files under a root directory
+type LocalStorage struct {
+	root string
+}
+
+// NewLocalStorage stores the objects under root, DefaultLocalRoot when it is empty.
+// The directory is created by the first Put.
+func NewLocalStorage(root string) *LocalStorage {
+	if root == "" {
+		root = DefaultLocalRoot
+	}
+	return &LocalStorage{root: root}
+}
+
+func (storage *LocalStorage) path(key string) (string, error) {
+	if !ValidKey(key) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(storage.root, filepath.FromSlash(key)), nil
+}
+
+// Put writes the object to a temporary file and links it in place once it is complete,
+// so a reader never sees a partial object and an existing one is never replaced
+func (storage *LocalStorage) Put(ctx context.Context, key string, r io.Reader) error {
+	name, err := storage.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, contextReader{ctx, r}); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o444); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Link(tmp.Name(), name); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return ErrExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (storage *LocalStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := storage.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return file, nil
+}
+
+// contextReader stops a copy once the context is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
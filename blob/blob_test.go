@@ -0,0 +1,66 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir())
+
+	ctx := context.Background()
+	err := storage.Put(ctx, "statements/1/2026-03.pdf", strings.NewReader("first"))
+	require.NoError(t, err)
+
+	// objects are never overwritten
+	err = storage.Put(ctx, "statements/1/2026-03.pdf", strings.NewReader("second"))
+	require.ErrorIs(t, err, ErrExists)
+
+	r, err := storage.Open(ctx, "statements/1/2026-03.pdf")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "first", string(data))
+
+	_, err = storage.Open(ctx, "statements/1/2026-04.pdf")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// no temporary file is left behind
+	files, err := os.ReadDir(filepath.Join(storage.root, "statements", "1"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
+
+func TestLocalStorageCanceled(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := storage.Put(ctx, "canceled", strings.NewReader("data"))
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = storage.Open(context.Background(), "canceled")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestValidKey(t *testing.T) {
+	for _, key := range []string{"a", "statements/1/2026-03.pdf", "a.b/c"} {
+		require.True(t, ValidKey(key), key)
+	}
+	for _, key := range []string{"", "/etc/passwd", "../a", "a/../../b", "a//b", "a/./b", "a/", `a\b`} {
+		require.False(t, ValidKey(key), key)
+	}
+
+	storage := NewLocalStorage(t.TempDir())
+	require.Error(t, storage.Put(context.Background(), "../escape", strings.NewReader("data")))
+
+	require.Equal(t, DefaultLocalRoot, NewLocalStorage("").root)
+}
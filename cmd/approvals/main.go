@@ -0,0 +1,43 @@
+// Command approvals expires the transfer approvals still pending past their expiry:
+//
+//	approvals expire
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	_ "github.com/lib/pq"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+func main() {
+	if len(os.Args) != 2 || os.Args[1] != "expire" {
+		fmt.Fprintln(os.Stderr, "usage: approvals expire")
+		os.Exit(2)
+	}
+
+	config, err := utils.LoadConfig(".")
+	if err != nil {
+		log.Fatal("cannot load config: ", err)
+	}
+
+	conn, err := sql.Open(config.DBDriver, config.DBSource)
+	if err != nil {
+		log.Fatal("cannot connect to db: ", err)
+	}
+
+	expired, err := db.NewStore(conn, db.TransferPolicy{}).ExpireTransferApprovalsTx(context.Background(), time.Now())
+	if err != nil {
+		log.Fatal("approvals expire failed: ", err)
+	}
+
+	out, _ := json.MarshalIndent(expired, "", "  ")
+	fmt.Println(string(out))
+}
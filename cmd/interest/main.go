@@ -0,0 +1,90 @@
+// Command interest runs the interest jobs:
+//
+//	interest accrue [-date 2006-01-02]            accrue one day, yesterday by default
+//	interest backfill -from 2006-01-02 -to 2006-01-02
+//	interest capitalize [-month 2006-01]          capitalize one month, last month by default
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	_ "github.com/lib/pq"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/interest"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	config, err := utils.LoadConfig(".")
+	if err != nil {
+		log.Fatal("cannot load config: ", err)
+	}
+
+	conn, err := sql.Open(config.DBDriver, config.DBSource)
+	if err != nil {
+		log.Fatal("cannot connect to db: ", err)
+	}
+
+	engine := interest.NewEngine(db.NewStore(conn, db.TransferPolicy{}))
+	ctx := context.Background()
+	yesterday := time.Now().UTC().AddDate(0, 0, -1)
+
+	var summary interface{}
+
+	flags := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	switch os.Args[1] {
+	case "accrue":
+		date := flags.String("date", yesterday.Format(time.DateOnly), "day to accrue")
+		flags.Parse(os.Args[2:])
+
+		summary, err = engine.AccrueDay(ctx, parse(time.DateOnly, *date))
+	case "backfill":
+		from := flags.String("from", "", "first day to recompute")
+		to := flags.String("to", yesterday.Format(time.DateOnly), "last day to recompute")
+		flags.Parse(os.Args[2:])
+
+		summary, err = engine.Backfill(ctx, parse(time.DateOnly, *from), parse(time.DateOnly, *to))
+	case "capitalize":
+		thisMonth, _ := interest.MonthBounds(time.Now().UTC())
+		month := flags.String("month", thisMonth.AddDate(0, -1, 0).Format("2006-01"), "month to capitalize")
+		flags.Parse(os.Args[2:])
+
+		summary, err = engine.CapitalizeMonth(ctx, parse("2006-01", *month))
+	default:
+		usage()
+	}
+	if err != nil {
+		log.Fatal("interest ", os.Args[1], " failed: ", err)
+	}
+
+	out, _ := json.MarshalIndent(summary, "", "  ")
+	fmt.Println(string(out))
+
+	if capitalization, ok := summary.(interest.CapitalizationSummary); ok && len(capitalization.Failed) > 0 {
+		os.Exit(1)
+	}
+}
+
+func parse(layout string, value string) time.Time {
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		log.Fatalf("invalid date %q, expected %s", value, layout)
+	}
+	return t
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: interest accrue|backfill|capitalize [flags]")
+	os.Exit(2)
+}
@@ -0,0 +1,182 @@
+// Command ledger checks the ledger, exiting with status 1 when it finds a problem, and runs its periodic jobs:
+//
+//	ledger check [-format text|json]    check the ledger invariants
+//	ledger verify                       verify the hash chain of every account and the latest checkpoint
+//	ledger checkpoint                   sign and store the Merkle root of the chain heads, run it periodically
+//	ledger checkpoints [-limit 100]     export the signed checkpoints as JSON for external anchoring
+//	ledger snapshot [-date 2006-01-02]  snapshot the balance of every account at the end of a day, yesterday by default
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	_ "github.com/lib/pq"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/ledger"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	flags := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	format := flags.String("format", "text", "output format of check, text or json")
+	limit := flags.Int("limit", 100, "number of checkpoints to export")
+	date := flags.String("date", time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly), "day to snapshot")
+	flags.Parse(os.Args[2:])
+	if *format != "text" && *format != "json" {
+		usage()
+	}
+
+	config, err := utils.LoadConfig(".")
+	if err != nil {
+		log.Fatal("cannot load config: ", err)
+	}
+
+	conn, err := sql.Open(config.DBDriver, config.DBSource)
+	if err != nil {
+		log.Fatal("cannot connect to db: ", err)
+	}
+
+	store := db.NewStore(conn, db.TransferPolicy{})
+	ctx := context.Background()
+
+	switch os.Args[1] {
+	case "check":
+		check(ctx, store, *format)
+	case "verify":
+		// a checkpoint only proves something when it is signed by our own key, not the one it carries
+		key, err := ledger.ParseSigningKey(config.LedgerSigningKey)
+		if err != nil {
+			log.Fatal("cannot parse LEDGER_SIGNING_KEY: ", err)
+		}
+		verify(ctx, store, key.Public().(ed25519.PublicKey))
+	case "checkpoint":
+		key, err := ledger.ParseSigningKey(config.LedgerSigningKey)
+		if err != nil {
+			log.Fatal("cannot parse LEDGER_SIGNING_KEY: ", err)
+		}
+		checkpoint, err := ledger.CreateCheckpoint(ctx, store, key, time.Now())
+		if err != nil {
+			log.Fatal("cannot create checkpoint: ", err)
+		}
+		printJSON(ledger.NewCheckpoint(checkpoint))
+	case "checkpoints":
+		checkpoints, err := store.ListLedgerCheckpoints(ctx, int32(*limit))
+		if err != nil {
+			log.Fatal("cannot list checkpoints: ", err)
+		}
+		export := make([]ledger.Checkpoint, len(checkpoints))
+		for i, checkpoint := range checkpoints {
+			export[i] = ledger.NewCheckpoint(checkpoint)
+		}
+		printJSON(export)
+	case "snapshot":
+		snapshot(ctx, store, *date)
+	default:
+		usage()
+	}
+}
+
+func check(ctx context.Context, store db.Store, format string) {
+	report, err := ledger.Check(ctx, store)
+	if err != nil {
+		log.Fatal("ledger check failed: ", err)
+	}
+
+	if format == "json" {
+		printJSON(report)
+	} else if err := report.WriteText(os.Stdout); err != nil {
+		log.Fatal("cannot write report: ", err)
+	}
+
+	if !report.OK {
+		os.Exit(1)
+	}
+}
+
+func verify(ctx context.Context, store db.Store, publicKey ed25519.PublicKey) {
+	accountIDs, err := store.ListEntryAccountIDs(ctx)
+	if err != nil {
+		log.Fatal("cannot list accounts: ", err)
+	}
+
+	ok := true
+	for _, accountID := range accountIDs {
+		verification, err := ledger.VerifyAccountChain(ctx, store, accountID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, b := range verification.Breaks {
+			ok = false
+			fmt.Printf("account %d: entry %d: %s\n", accountID, b.EntryID, b.Reason)
+		}
+	}
+	fmt.Printf("%d account chains verified\n", len(accountIDs))
+
+	verification, err := ledger.VerifyLatestCheckpoint(ctx, store, publicKey)
+	if err != nil {
+		log.Fatal("cannot verify checkpoint: ", err)
+	}
+	if verification != nil {
+		checkpoint := verification.Checkpoint
+		if !verification.Signed {
+			ok = false
+			fmt.Printf("checkpoint %d: invalid signature\n", checkpoint.ID)
+		} else if !verification.Matches {
+			ok = false
+			fmt.Printf("checkpoint %d: root does not match the entries up to %d\n", checkpoint.ID, checkpoint.LastEntryID)
+		} else {
+			fmt.Printf("checkpoint %d matches the entries up to %d\n", checkpoint.ID, checkpoint.LastEntryID)
+		}
+	}
+
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// snapshot records the end of day balances, entries must no longer be written with a time in that day.
+// Days that already have snapshots keep them, so running it again only fills in new accounts.
+func snapshot(ctx context.Context, store db.Store, date string) {
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		log.Fatal("cannot parse date: ", err)
+	}
+	dayEnd := db.SnapshotEnd(day)
+	if dayEnd.After(time.Now()) {
+		log.Fatalf("%s has not ended yet", date)
+	}
+
+	accounts, err := store.CreateBalanceSnapshots(ctx, db.CreateBalanceSnapshotsParams{
+		Day:    day,
+		DayEnd: dayEnd,
+	})
+	if err != nil {
+		log.Fatal("cannot snapshot balances: ", err)
+	}
+	printJSON(map[string]interface{}{"day": date, "accounts": accounts})
+}
+
+func printJSON(v interface{}) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatal("cannot encode output: ", err)
+	}
+	fmt.Println(string(out))
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: ledger check [-format text|json] | verify | checkpoint | checkpoints [-limit 100] | snapshot [-date 2006-01-02]")
+	os.Exit(2)
+}
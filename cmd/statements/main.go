@@ -0,0 +1,63 @@
+// Command statements archives the monthly statements, run it on the first of every month:
+//
+//	statements monthly [-month 2006-01]           archive one month, last month by default
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/lordofthemind/backendMasterGo/blob"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/statement"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "monthly" {
+		fmt.Fprintln(os.Stderr, "usage: statements monthly [-month 2006-01]")
+		os.Exit(2)
+	}
+
+	now := time.Now().UTC()
+	lastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+
+	flags := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	month := flags.String("month", lastMonth.Format("2006-01"), "month to archive")
+	flags.Parse(os.Args[2:])
+
+	start, err := time.Parse("2006-01", *month)
+	if err != nil {
+		log.Fatalf("invalid month %q, expected 2006-01", *month)
+	}
+
+	config, err := utils.LoadConfig(".")
+	if err != nil {
+		log.Fatal("cannot load config: ", err)
+	}
+
+	conn, err := sql.Open(config.DBDriver, config.DBSource)
+	if err != nil {
+		log.Fatal("cannot connect to db: ", err)
+	}
+
+	archiver := statement.NewArchiver(db.NewStore(conn, db.TransferPolicy{}), blob.NewLocalStorage(config.BlobStorageDir))
+	summary, err := archiver.ArchiveMonth(context.Background(), start)
+	if err != nil {
+		log.Fatal("statements monthly failed: ", err)
+	}
+
+	out, _ := json.MarshalIndent(summary, "", "  ")
+	fmt.Println(string(out))
+
+	if len(summary.Failed) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,35 @@
+ALTER TABLE "users" ADD COLUMN "tier" varchar NOT NULL DEFAULT 'standard';
+
+CREATE TABLE "transfer_limits" (
+  "id" bigserial PRIMARY KEY,
+  "scope" varchar NOT NULL,
+  "account_id" bigint,
+  "username" varchar,
+  "tier" varchar,
+  "currency" varchar,
+  "max_single_amount" bigint,
+  "daily_amount" bigint,
+  "monthly_amount" bigint,
+  "daily_count" bigint,
+  "monthly_count" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "transfer_limits_scope_check" CHECK (
+    ("scope" = 'account' AND "account_id" IS NOT NULL) OR
+    ("scope" = 'user' AND "username" IS NOT NULL) OR
+    ("scope" = 'tier' AND "tier" IS NOT NULL)
+  )
+);
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE INDEX ON "transfer_limits" ("account_id");
+
+CREATE INDEX ON "transfer_limits" ("username");
+
+CREATE INDEX ON "transfer_limits" ("tier");
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+COMMENT ON COLUMN "transfer_limits"."currency" IS 'null applies the limit to every currency';
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS "transfers_reference_idx";
+
+ALTER TABLE IF EXISTS "entries"
+  DROP CONSTRAINT IF EXISTS "entries_details_size_check",
+  DROP COLUMN IF EXISTS "description",
+  DROP COLUMN IF EXISTS "reference",
+  DROP COLUMN IF EXISTS "metadata";
+
+ALTER TABLE IF EXISTS "transfers"
+  DROP CONSTRAINT IF EXISTS "transfers_details_size_check",
+  DROP COLUMN IF EXISTS "description",
+  DROP COLUMN IF EXISTS "reference",
+  DROP COLUMN IF EXISTS "metadata";
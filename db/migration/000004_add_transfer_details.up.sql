@@ -0,0 +1,23 @@
+ALTER TABLE "transfers"
+  ADD COLUMN "description" varchar NOT NULL DEFAULT '',
+  ADD COLUMN "reference" varchar NOT NULL DEFAULT '',
+  ADD COLUMN "metadata" jsonb NOT NULL DEFAULT '{}';
+
+ALTER TABLE "entries"
+  ADD COLUMN "description" varchar NOT NULL DEFAULT '',
+  ADD COLUMN "reference" varchar NOT NULL DEFAULT '',
+  ADD COLUMN "metadata" jsonb NOT NULL DEFAULT '{}';
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_details_size_check" CHECK (
+  char_length("description") <= 255 AND
+  char_length("reference") <= 64 AND
+  octet_length("metadata"::text) <= 4096
+);
+
+ALTER TABLE "entries" ADD CONSTRAINT "entries_details_size_check" CHECK (
+  char_length("description") <= 255 AND
+  char_length("reference") <= 64 AND
+  octet_length("metadata"::text) <= 4096
+);
+
+CREATE INDEX ON "transfers" ("reference");
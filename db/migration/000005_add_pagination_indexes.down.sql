@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "entries_account_id_id_idx";
+
+DROP INDEX IF EXISTS "accounts_owner_id_idx";
@@ -0,0 +1,3 @@
+CREATE INDEX ON "accounts" ("owner", "id");
+
+CREATE INDEX ON "entries" ("account_id", "id");
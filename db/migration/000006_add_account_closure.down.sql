@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "accounts"
+  DROP CONSTRAINT IF EXISTS "accounts_closed_balance_check",
+  DROP COLUMN IF EXISTS "closed_at";
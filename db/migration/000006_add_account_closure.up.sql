@@ -0,0 +1,7 @@
+ALTER TABLE "accounts" ADD COLUMN "closed_at" timestamptz;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_closed_balance_check" CHECK (
+  "closed_at" IS NULL OR "balance" = 0
+);
+
+COMMENT ON COLUMN "accounts"."closed_at" IS 'closed accounts keep their history but cannot send or receive money';
@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS "accounts_status_check" ON "accounts";
+
+DROP FUNCTION IF EXISTS "check_account_status";
+
+DROP TABLE IF EXISTS "account_status_history";
+
+ALTER TABLE IF EXISTS "accounts"
+  DROP CONSTRAINT IF EXISTS "accounts_status_check",
+  DROP COLUMN IF EXISTS "status";
+
+ALTER TABLE IF EXISTS "users"
+  DROP CONSTRAINT IF EXISTS "users_role_check",
+  DROP COLUMN IF EXISTS "role";
@@ -0,0 +1,50 @@
+ALTER TABLE "users" ADD COLUMN "role" varchar NOT NULL DEFAULT 'customer';
+
+ALTER TABLE "users" ADD CONSTRAINT "users_role_check" CHECK ("role" IN ('customer', 'staff'));
+
+ALTER TABLE "accounts" ADD COLUMN "status" varchar NOT NULL DEFAULT 'active';
+
+UPDATE "accounts" SET "status" = 'closed' WHERE "closed_at" IS NOT NULL;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_status_check" CHECK (
+  "status" IN ('active', 'frozen', 'debit_blocked', 'credit_blocked', 'closed') AND
+  ("status" = 'closed') = ("closed_at" IS NOT NULL)
+);
+
+CREATE TABLE "account_status_history" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "from_status" varchar NOT NULL,
+  "to_status" varchar NOT NULL,
+  "reason" varchar NOT NULL,
+  "note" varchar NOT NULL DEFAULT '',
+  "actor" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "account_status_history" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_status_history" ADD FOREIGN KEY ("actor") REFERENCES "users" ("username");
+
+CREATE INDEX ON "account_status_history" ("account_id", "id");
+
+-- balances only move through accounts whose status allows it, whoever the caller is
+CREATE FUNCTION "check_account_status"() RETURNS trigger AS $$
+BEGIN
+  IF NEW."balance" < OLD."balance" AND OLD."status" IN ('frozen', 'debit_blocked', 'closed') THEN
+    RAISE EXCEPTION 'account % is %, debits are not allowed', OLD."id", OLD."status"
+      USING ERRCODE = 'BA001';
+  END IF;
+  IF NEW."balance" > OLD."balance" AND OLD."status" IN ('frozen', 'credit_blocked', 'closed') THEN
+    RAISE EXCEPTION 'account % is %, credits are not allowed', OLD."id", OLD."status"
+      USING ERRCODE = 'BA001';
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "accounts_status_check"
+  BEFORE UPDATE OF "balance" ON "accounts"
+  FOR EACH ROW EXECUTE FUNCTION "check_account_status"();
+
+COMMENT ON COLUMN "account_status_history"."actor" IS 'user who changed the status';
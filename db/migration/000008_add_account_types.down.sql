@@ -0,0 +1,28 @@
+DELETE FROM "transfer_limits" WHERE "scope" = 'account_type';
+
+ALTER TABLE IF EXISTS "transfer_limits"
+  DROP CONSTRAINT IF EXISTS "transfer_limits_scope_check",
+  DROP COLUMN IF EXISTS "account_type";
+
+ALTER TABLE "transfer_limits" ADD CONSTRAINT "transfer_limits_scope_check" CHECK (
+  ("scope" = 'account' AND "account_id" IS NOT NULL) OR
+  ("scope" = 'user' AND "username" IS NOT NULL) OR
+  ("scope" = 'tier' AND "tier" IS NOT NULL)
+);
+
+ALTER TABLE IF EXISTS "accounts"
+  DROP CONSTRAINT IF EXISTS "owner_currency_type_key",
+  DROP CONSTRAINT IF EXISTS "accounts_type_check",
+  DROP COLUMN IF EXISTS "type";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_key" UNIQUE ("owner", "currency");
+
+DELETE FROM "users" WHERE "username" = 'bank' AND NOT EXISTS (
+  SELECT 1 FROM "accounts" WHERE "owner" = 'bank'
+);
+
+UPDATE "users" SET "role" = 'customer' WHERE "role" = 'system';
+
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS "users_role_check";
+
+ALTER TABLE "users" ADD CONSTRAINT "users_role_check" CHECK ("role" IN ('customer', 'staff'));
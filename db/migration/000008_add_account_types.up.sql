@@ -0,0 +1,35 @@
+ALTER TABLE "users" DROP CONSTRAINT "users_role_check";
+
+ALTER TABLE "users" ADD CONSTRAINT "users_role_check" CHECK ("role" IN ('customer', 'staff', 'system'));
+
+-- the bank owns the system accounts, it has no password so it cannot log in
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email", "role")
+VALUES ('bank', '', 'Bank', 'bank@system.invalid', 'system');
+
+ALTER TABLE "accounts" ADD COLUMN "type" varchar NOT NULL DEFAULT 'checking';
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_type_check" CHECK (
+  "type" IN ('checking', 'savings', 'system') AND
+  ("type" = 'system') = ("owner" = 'bank')
+);
+
+ALTER TABLE "accounts" DROP CONSTRAINT "owner_currency_key";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_type_key" UNIQUE ("owner", "currency", "type");
+
+ALTER TABLE "transfer_limits" ADD COLUMN "account_type" varchar;
+
+ALTER TABLE "transfer_limits" DROP CONSTRAINT "transfer_limits_scope_check";
+
+ALTER TABLE "transfer_limits" ADD CONSTRAINT "transfer_limits_scope_check" CHECK (
+  ("scope" = 'account' AND "account_id" IS NOT NULL) OR
+  ("scope" = 'user' AND "username" IS NOT NULL) OR
+  ("scope" = 'tier' AND "tier" IS NOT NULL) OR
+  ("scope" = 'account_type' AND "account_type" IS NOT NULL)
+);
+
+-- savings accounts allow six withdrawals a month
+INSERT INTO "transfer_limits" ("scope", "account_type", "monthly_count")
+VALUES ('account_type', 'savings', 6);
+
+COMMENT ON COLUMN "accounts"."type" IS 'system accounts belong to the bank';
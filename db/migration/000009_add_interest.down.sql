@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS "interest_accruals";
+
+DROP TABLE IF EXISTS "interest_capitalizations";
+
+DROP TABLE IF EXISTS "interest_rates";
+
+ALTER TABLE IF EXISTS "accounts"
+  DROP CONSTRAINT IF EXISTS "owner_currency_type_key",
+  DROP CONSTRAINT IF EXISTS "accounts_purpose_check",
+  DROP COLUMN IF EXISTS "purpose";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_type_key" UNIQUE ("owner", "currency", "type");
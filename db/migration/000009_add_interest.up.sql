@@ -0,0 +1,64 @@
+ALTER TABLE "accounts" ADD COLUMN "purpose" varchar NOT NULL DEFAULT '';
+
+UPDATE "accounts" SET "purpose" = 'general' WHERE "type" = 'system';
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_purpose_check" CHECK (("type" = 'system') = ("purpose" <> ''));
+
+ALTER TABLE "accounts" DROP CONSTRAINT "owner_currency_type_key";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_type_key" UNIQUE ("owner", "currency", "type", "purpose");
+
+CREATE TABLE "interest_rates" (
+  "id" bigserial PRIMARY KEY,
+  "account_type" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "annual_rate_ppm" bigint NOT NULL,
+  "effective_from" date NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "interest_rates_rate_check" CHECK ("annual_rate_ppm" >= 0),
+  CONSTRAINT "interest_rates_product_key" UNIQUE ("account_type", "currency", "effective_from")
+);
+
+CREATE TABLE "interest_capitalizations" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "period_start" date NOT NULL,
+  "period_end" date NOT NULL,
+  "accrued_micros" bigint NOT NULL,
+  "carried_micros" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "remainder_micros" bigint NOT NULL,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "interest_capitalizations_period_key" UNIQUE ("account_id", "period_start")
+);
+
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_rate_ppm" bigint NOT NULL,
+  "amount_micros" bigint NOT NULL,
+  "capitalization_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "interest_accruals_day_key" UNIQUE ("account_id", "accrual_date")
+);
+
+ALTER TABLE "interest_capitalizations" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_capitalizations" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("capitalization_id") REFERENCES "interest_capitalizations" ("id");
+
+CREATE INDEX ON "interest_accruals" ("capitalization_id");
+
+COMMENT ON COLUMN "accounts"."purpose" IS 'what a system account is used for, empty for customer accounts';
+
+COMMENT ON COLUMN "interest_rates"."annual_rate_ppm" IS 'annual rate in parts per million, 4.25% is 42500';
+
+COMMENT ON COLUMN "interest_accruals"."amount_micros" IS 'millionths of the minor currency unit';
+
+COMMENT ON COLUMN "interest_capitalizations"."remainder_micros" IS 'carried over to the next capitalization';
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS "accounts_owner_member" ON "accounts";
+
+DROP FUNCTION IF EXISTS "add_account_owner_member";
+
+DROP TABLE IF EXISTS "account_members";
@@ -0,0 +1,44 @@
+CREATE TABLE "account_members" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'invited',
+  "invited_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "accepted_at" timestamptz
+);
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("username");
+
+ALTER TABLE "account_members" ADD CONSTRAINT "account_members_account_username_key" UNIQUE ("account_id", "username");
+
+ALTER TABLE "account_members" ADD CONSTRAINT "account_members_role_check" CHECK (
+  "role" IN ('owner', 'spender', 'viewer') AND
+  "status" IN ('invited', 'active') AND
+  ("status" = 'active') = ("accepted_at" IS NOT NULL)
+);
+
+CREATE INDEX ON "account_members" ("username", "account_id");
+
+INSERT INTO "account_members" ("account_id", "username", "role", "status", "invited_by", "accepted_at")
+SELECT "id", "owner", 'owner', 'active', "owner", "created_at" FROM "accounts";
+
+-- whoever opens an account is its first owner
+CREATE FUNCTION "add_account_owner_member"() RETURNS trigger AS $$
+BEGIN
+  INSERT INTO "account_members" ("account_id", "username", "role", "status", "invited_by", "accepted_at")
+  VALUES (NEW."id", NEW."owner", 'owner', 'active', NEW."owner", NEW."created_at");
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "accounts_owner_member"
+  AFTER INSERT ON "accounts"
+  FOR EACH ROW EXECUTE FUNCTION "add_account_owner_member"();
+
+COMMENT ON COLUMN "accounts"."owner" IS 'user who opened the account, access is granted through account_members';
@@ -0,0 +1,21 @@
+CREATE OR REPLACE FUNCTION "add_account_owner_member"() RETURNS trigger AS $$
+BEGIN
+  INSERT INTO "account_members" ("account_id", "username", "role", "status", "invited_by", "accepted_at")
+  VALUES (NEW."id", NEW."owner", 'owner', 'active', NEW."owner", NEW."created_at");
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS "org_currency_type_key";
+
+DROP INDEX IF EXISTS "owner_currency_type_key";
+
+ALTER TABLE IF EXISTS "accounts"
+  DROP CONSTRAINT IF EXISTS "accounts_org_type_check",
+  DROP COLUMN IF EXISTS "org_id";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_type_key" UNIQUE ("owner", "currency", "type", "purpose");
+
+DROP TABLE IF EXISTS "organization_members";
+
+DROP TABLE IF EXISTS "organizations";
@@ -0,0 +1,53 @@
+CREATE TABLE "organizations" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "created_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "organizations" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
+
+CREATE TABLE "organization_members" (
+  "id" bigserial PRIMARY KEY,
+  "org_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "added_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "organization_members_org_username_key" UNIQUE ("org_id", "username"),
+  CONSTRAINT "organization_members_role_check" CHECK ("role" IN ('admin', 'approver', 'viewer'))
+);
+
+ALTER TABLE "organization_members" ADD FOREIGN KEY ("org_id") REFERENCES "organizations" ("id");
+
+ALTER TABLE "organization_members" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "organization_members" ADD FOREIGN KEY ("added_by") REFERENCES "users" ("username");
+
+CREATE INDEX ON "organization_members" ("username", "org_id");
+
+ALTER TABLE "accounts" ADD COLUMN "org_id" bigint;
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("org_id") REFERENCES "organizations" ("id");
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_org_type_check" CHECK ("org_id" IS NULL OR "type" <> 'system');
+
+-- the owner of an organization account is only the user who opened it, uniqueness is per organization
+ALTER TABLE "accounts" DROP CONSTRAINT "owner_currency_type_key";
+
+CREATE UNIQUE INDEX "owner_currency_type_key" ON "accounts" ("owner", "currency", "type", "purpose") WHERE "org_id" IS NULL;
+
+CREATE UNIQUE INDEX "org_currency_type_key" ON "accounts" ("org_id", "currency", "type", "purpose") WHERE "org_id" IS NOT NULL;
+
+-- organization accounts are accessed through organization_members, not account_members
+CREATE OR REPLACE FUNCTION "add_account_owner_member"() RETURNS trigger AS $$
+BEGIN
+  IF NEW."org_id" IS NULL THEN
+    INSERT INTO "account_members" ("account_id", "username", "role", "status", "invited_by", "accepted_at")
+    VALUES (NEW."id", NEW."owner", 'owner', 'active', NEW."owner", NEW."created_at");
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMENT ON COLUMN "accounts"."org_id" IS 'organization owning the account, its members act on its behalf';
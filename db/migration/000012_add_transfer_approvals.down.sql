@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "transfer_approval_events";
+
+DROP TABLE IF EXISTS "transfer_approvals";
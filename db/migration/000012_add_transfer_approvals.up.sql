@@ -0,0 +1,55 @@
+CREATE TABLE "transfer_approvals" (
+  "id" bigserial PRIMARY KEY,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "reference" varchar NOT NULL DEFAULT '',
+  "metadata" jsonb NOT NULL DEFAULT '{}',
+  "status" varchar NOT NULL DEFAULT 'pending_approval',
+  "initiated_by" varchar NOT NULL,
+  "decided_by" varchar,
+  "transfer_id" bigint,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "decided_at" timestamptz,
+  CONSTRAINT "transfer_approvals_amount_check" CHECK ("amount" > 0),
+  CONSTRAINT "transfer_approvals_status_check" CHECK (
+    "status" IN ('pending_approval', 'approved', 'rejected', 'expired') AND
+    ("status" = 'pending_approval') = ("decided_at" IS NULL) AND
+    ("status" = 'approved') = ("transfer_id" IS NOT NULL)
+  ),
+  CONSTRAINT "transfer_approvals_four_eyes_check" CHECK ("decided_by" <> "initiated_by")
+);
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("initiated_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("decided_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "transfer_approvals" ("from_account_id", "status");
+
+CREATE INDEX ON "transfer_approvals" ("status", "expires_at");
+
+CREATE TABLE "transfer_approval_events" (
+  "id" bigserial PRIMARY KEY,
+  "approval_id" bigint NOT NULL,
+  "action" varchar NOT NULL,
+  "actor" varchar,
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "transfer_approval_events_action_check" CHECK ("action" IN ('requested', 'approved', 'rejected', 'expired'))
+);
+
+ALTER TABLE "transfer_approval_events" ADD FOREIGN KEY ("approval_id") REFERENCES "transfer_approvals" ("id");
+
+ALTER TABLE "transfer_approval_events" ADD FOREIGN KEY ("actor") REFERENCES "users" ("username");
+
+CREATE INDEX ON "transfer_approval_events" ("approval_id", "id");
+
+COMMENT ON COLUMN "transfer_approval_events"."actor" IS 'user who took the decision, NULL when the request expired';
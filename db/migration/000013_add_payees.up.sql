@@ -0,0 +1,22 @@
+CREATE TABLE "payees" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "account_id" bigint,
+  "username" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "payees_target_check" CHECK (("account_id" IS NULL) <> ("username" IS NULL)),
+  CONSTRAINT "payees_name_check" CHECK (char_length("name") BETWEEN 1 AND 64)
+);
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE UNIQUE INDEX "payees_owner_account_key" ON "payees" ("owner", "account_id") WHERE "account_id" IS NOT NULL;
+
+CREATE UNIQUE INDEX "payees_owner_username_key" ON "payees" ("owner", "username") WHERE "username" IS NOT NULL;
+
+COMMENT ON COLUMN "payees"."username" IS 'pays the checking account of the user in the currency of each transfer';
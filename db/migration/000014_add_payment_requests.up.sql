@@ -0,0 +1,37 @@
+CREATE TABLE "payment_requests" (
+  "id" bigserial PRIMARY KEY,
+  "requester" varchar NOT NULL,
+  "payer" varchar NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "transfer_id" bigint UNIQUE,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "decided_at" timestamptz,
+  CONSTRAINT "payment_requests_amount_check" CHECK ("amount" > 0),
+  CONSTRAINT "payment_requests_status_check" CHECK (
+    "status" IN ('pending', 'paid', 'declined', 'cancelled', 'expired') AND
+    ("status" = 'pending') = ("decided_at" IS NULL) AND
+    ("status" = 'paid') = ("transfer_id" IS NOT NULL)
+  ),
+  CONSTRAINT "payment_requests_payer_check" CHECK ("payer" <> "requester")
+);
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("requester") REFERENCES "users" ("username");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("payer") REFERENCES "users" ("username");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "payment_requests" ("payer", "id");
+
+CREATE INDEX ON "payment_requests" ("requester", "id");
+
+COMMENT ON COLUMN "payment_requests"."to_account_id" IS 'account of the requester credited when the request is paid';
+
+COMMENT ON COLUMN "payment_requests"."transfer_id" IS 'unique, a request is paid by exactly one transfer';
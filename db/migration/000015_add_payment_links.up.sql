@@ -0,0 +1,43 @@
+CREATE TABLE "payment_links" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint,
+  "currency" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "single_use" boolean NOT NULL DEFAULT false,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "expires_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "payment_links_amount_check" CHECK ("amount" > 0),
+  CONSTRAINT "payment_links_status_check" CHECK (
+    "status" IN ('active', 'used', 'disabled') AND
+    ("status" <> 'used' OR "single_use")
+  )
+);
+
+ALTER TABLE "payment_links" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payment_links" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+CREATE INDEX ON "payment_links" ("owner", "id");
+
+COMMENT ON COLUMN "payment_links"."amount" IS 'fixed amount of the link, NULL lets the payer choose it';
+
+COMMENT ON COLUMN "payment_links"."status" IS 'used once a single-use link is paid';
+
+CREATE TABLE "payment_link_payments" (
+  "id" bigserial PRIMARY KEY,
+  "link_id" bigint NOT NULL,
+  "transfer_id" bigint UNIQUE NOT NULL,
+  "payer" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "payment_link_payments" ADD FOREIGN KEY ("link_id") REFERENCES "payment_links" ("id");
+
+ALTER TABLE "payment_link_payments" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "payment_link_payments" ADD FOREIGN KEY ("payer") REFERENCES "users" ("username");
+
+CREATE INDEX ON "payment_link_payments" ("link_id", "id");
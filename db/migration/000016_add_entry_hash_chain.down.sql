@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "ledger_checkpoints";
+
+ALTER TABLE "entries"
+  DROP COLUMN IF EXISTS "hash",
+  DROP COLUMN IF EXISTS "prev_hash";
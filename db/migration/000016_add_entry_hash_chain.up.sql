@@ -0,0 +1,21 @@
+ALTER TABLE "entries"
+  ADD COLUMN "prev_hash" bytea,
+  ADD COLUMN "hash" bytea;
+
+COMMENT ON COLUMN "entries"."prev_hash" IS 'hash of the previous entry of the account, NULL for the first entry of the chain';
+
+COMMENT ON COLUMN "entries"."hash" IS 'SHA-256 of the entry and prev_hash, NULL for entries written before the chain';
+
+CREATE TABLE "ledger_checkpoints" (
+  "id" bigserial PRIMARY KEY,
+  "root" bytea NOT NULL,
+  "accounts" bigint NOT NULL,
+  "last_entry_id" bigint NOT NULL,
+  "public_key" bytea NOT NULL,
+  "signature" bytea NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "ledger_checkpoints"."root" IS 'Merkle root of the chain heads of every account, ordered by account id';
+
+COMMENT ON COLUMN "ledger_checkpoints"."last_entry_id" IS 'newest entry covered by the checkpoint';
@@ -0,0 +1,23 @@
+GRANT UPDATE, DELETE, TRUNCATE ON "entries", "transfers" TO CURRENT_USER;
+
+DROP TRIGGER IF EXISTS "transfers_no_truncate" ON "transfers";
+
+DROP TRIGGER IF EXISTS "transfers_append_only" ON "transfers";
+
+DROP TRIGGER IF EXISTS "entries_no_truncate" ON "entries";
+
+DROP TRIGGER IF EXISTS "entries_append_only" ON "entries";
+
+DROP TRIGGER IF EXISTS "entries_update_check" ON "entries";
+
+DROP FUNCTION IF EXISTS "check_entry_update";
+
+DROP TRIGGER IF EXISTS "accounts_balance_check" ON "accounts";
+
+DROP FUNCTION IF EXISTS "check_balance_update";
+
+DROP TRIGGER IF EXISTS "entries_apply" ON "entries";
+
+DROP FUNCTION IF EXISTS "apply_entry";
+
+DROP FUNCTION IF EXISTS "forbid_ledger_change";
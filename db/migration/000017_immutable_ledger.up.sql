@@ -0,0 +1,76 @@
+-- entries and transfers are the books, corrections are new transfers and never edits
+CREATE FUNCTION "forbid_ledger_change"() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION '% is append-only, % is not allowed', TG_TABLE_NAME, TG_OP
+    USING ERRCODE = 'BA002';
+END;
+$$ LANGUAGE plpgsql;
+
+-- the only update an entry takes is linking it into the hash chain, once, right after it is written
+CREATE FUNCTION "check_entry_update"() RETURNS trigger AS $$
+DECLARE
+  "chained" "entries"%ROWTYPE;
+BEGIN
+  "chained" := OLD;
+  "chained"."prev_hash" := NEW."prev_hash";
+  "chained"."hash" := NEW."hash";
+  IF OLD."hash" IS NULL AND NEW."hash" IS NOT NULL AND NEW IS NOT DISTINCT FROM "chained" THEN
+    RETURN NEW;
+  END IF;
+  RAISE EXCEPTION 'entries is append-only, entry % cannot be changed', OLD."id"
+    USING ERRCODE = 'BA002';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "entries_update_check"
+  BEFORE UPDATE ON "entries"
+  FOR EACH ROW EXECUTE FUNCTION "check_entry_update"();
+
+CREATE TRIGGER "entries_append_only"
+  BEFORE DELETE ON "entries"
+  FOR EACH ROW EXECUTE FUNCTION "forbid_ledger_change"();
+
+CREATE TRIGGER "entries_no_truncate"
+  BEFORE TRUNCATE ON "entries"
+  FOR EACH STATEMENT EXECUTE FUNCTION "forbid_ledger_change"();
+
+CREATE TRIGGER "transfers_append_only"
+  BEFORE UPDATE OR DELETE ON "transfers"
+  FOR EACH ROW EXECUTE FUNCTION "forbid_ledger_change"();
+
+CREATE TRIGGER "transfers_no_truncate"
+  BEFORE TRUNCATE ON "transfers"
+  FOR EACH STATEMENT EXECUTE FUNCTION "forbid_ledger_change"();
+
+-- balances follow the entries, an entry moves the balance of its account as it is written
+CREATE FUNCTION "apply_entry"() RETURNS trigger AS $$
+BEGIN
+  UPDATE "accounts" SET "balance" = "balance" + NEW."amount" WHERE "id" = NEW."account_id";
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "entries_apply"
+  AFTER INSERT ON "entries"
+  FOR EACH ROW EXECUTE FUNCTION "apply_entry"();
+
+-- an update of the balance that does not come from the entries_apply trigger has no entry behind it
+CREATE FUNCTION "check_balance_update"() RETURNS trigger AS $$
+BEGIN
+  IF NEW."balance" IS DISTINCT FROM OLD."balance" AND pg_trigger_depth() < 2 THEN
+    RAISE EXCEPTION 'balance of account % only changes through entries', OLD."id"
+      USING ERRCODE = 'BA002';
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "accounts_balance_check"
+  BEFORE UPDATE OF "balance" ON "accounts"
+  FOR EACH ROW EXECUTE FUNCTION "check_balance_update"();
+
+-- the application role keeps INSERT and SELECT, and UPDATE of the chain columns only.
+-- Repairs need the table owner to grant itself the privileges back and disable the triggers on purpose.
+REVOKE UPDATE, DELETE, TRUNCATE ON "entries", "transfers" FROM PUBLIC, CURRENT_USER;
+
+GRANT UPDATE ("prev_hash", "hash") ON "entries" TO CURRENT_USER;
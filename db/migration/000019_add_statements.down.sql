@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "notifications";
+
+DROP TRIGGER IF EXISTS "statements_append_only" ON "statements";
+
+DROP TABLE IF EXISTS "statements";
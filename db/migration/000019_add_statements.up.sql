@@ -0,0 +1,44 @@
+CREATE TABLE "statements" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "period_start" date NOT NULL,
+  "period_end" date NOT NULL,
+  "format" varchar NOT NULL,
+  "blob_key" varchar UNIQUE NOT NULL,
+  "size" bigint NOT NULL,
+  "sha256" bytea NOT NULL,
+  "opening_balance" bigint NOT NULL,
+  "closing_balance" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "statements_period_check" CHECK ("period_end" >= "period_start"),
+  CONSTRAINT "statements_format_check" CHECK ("format" IN ('csv', 'pdf', 'ofx'))
+);
+
+ALTER TABLE "statements" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+CREATE UNIQUE INDEX ON "statements" ("account_id", "period_start", "format");
+
+COMMENT ON COLUMN "statements"."period_end" IS 'last day covered by the statement';
+
+COMMENT ON COLUMN "statements"."blob_key" IS 'key of the statement document in the blob storage';
+
+-- an archived statement is a record of what was sent, it is never edited or removed
+CREATE TRIGGER "statements_append_only"
+  BEFORE UPDATE OR DELETE ON "statements"
+  FOR EACH ROW EXECUTE FUNCTION "forbid_ledger_change"();
+
+CREATE TABLE "notifications" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "kind" varchar NOT NULL,
+  "message" varchar NOT NULL,
+  "data" jsonb NOT NULL DEFAULT '{}',
+  "read_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "notifications" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE INDEX ON "notifications" ("username", "id");
+
+COMMENT ON COLUMN "notifications"."data" IS 'ids of the objects the notification is about';
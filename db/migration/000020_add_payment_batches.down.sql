@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS "payment_batch_transactions_append_only" ON "payment_batch_transactions";
+
+DROP TRIGGER IF EXISTS "payment_batches_append_only" ON "payment_batches";
+
+DROP TABLE IF EXISTS "payment_batch_transactions";
+
+DROP TABLE IF EXISTS "payment_batches";
@@ -0,0 +1,67 @@
+CREATE TABLE "payment_batches" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "message_id" varchar NOT NULL,
+  "message_name" varchar NOT NULL,
+  "message_created_at" timestamptz NOT NULL,
+  "initiating_party" varchar NOT NULL DEFAULT '',
+  "transactions" bigint NOT NULL,
+  "control_sum" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "payment_batches_owner_message_id_key" UNIQUE ("owner", "message_id")
+);
+
+ALTER TABLE "payment_batches" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+COMMENT ON COLUMN "payment_batches"."message_name" IS 'ISO 20022 message and version of the file, such as pain.001.001.09';
+
+COMMENT ON COLUMN "payment_batches"."control_sum" IS 'control sum declared by the file, empty when it has none';
+
+CREATE TABLE "payment_batch_transactions" (
+  "id" bigserial PRIMARY KEY,
+  "batch_id" bigint NOT NULL,
+  "payment_info_id" varchar NOT NULL,
+  "instruction_id" varchar NOT NULL DEFAULT '',
+  "end_to_end_id" varchar NOT NULL,
+  "debtor_account" varchar NOT NULL,
+  "creditor_account" varchar NOT NULL DEFAULT '',
+  "amount" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "status" varchar NOT NULL,
+  "reason_code" varchar NOT NULL DEFAULT '',
+  "reason" varchar NOT NULL DEFAULT '',
+  "transfer_id" bigint,
+  "approval_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "payment_batch_transactions_status_check" CHECK (
+    "status" IN ('ACSC', 'PDNG', 'RJCT') AND
+    ("status" = 'ACSC') = ("transfer_id" IS NOT NULL) AND
+    ("status" = 'PDNG') = ("approval_id" IS NOT NULL) AND
+    ("status" = 'RJCT') = ("reason_code" <> '')
+  )
+);
+
+ALTER TABLE "payment_batch_transactions" ADD FOREIGN KEY ("batch_id") REFERENCES "payment_batches" ("id");
+
+ALTER TABLE "payment_batch_transactions" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "payment_batch_transactions" ADD FOREIGN KEY ("approval_id") REFERENCES "transfer_approvals" ("id");
+
+CREATE INDEX ON "payment_batch_transactions" ("batch_id", "id");
+
+COMMENT ON COLUMN "payment_batch_transactions"."debtor_account" IS 'account identification as written in the file';
+
+COMMENT ON COLUMN "payment_batch_transactions"."amount" IS 'instructed amount as written in the file';
+
+COMMENT ON COLUMN "payment_batch_transactions"."status" IS 'ISO 20022 status when the file was imported, a pending transaction follows its approval';
+
+COMMENT ON COLUMN "payment_batch_transactions"."reason_code" IS 'ISO 20022 status reason code of a rejection';
+
+-- the outcome of an imported file is what its status reports are built from, it is never edited or removed
+CREATE TRIGGER "payment_batches_append_only"
+  BEFORE UPDATE OR DELETE ON "payment_batches"
+  FOR EACH ROW EXECUTE FUNCTION "forbid_ledger_change"();
+
+CREATE TRIGGER "payment_batch_transactions_append_only"
+  BEFORE UPDATE OR DELETE ON "payment_batch_transactions"
+  FOR EACH ROW EXECUTE FUNCTION "forbid_ledger_change"();
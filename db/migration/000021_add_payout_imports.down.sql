@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS "payout_import_rows_append_only" ON "payout_import_rows";
+
+DROP TRIGGER IF EXISTS "payout_imports_append_only" ON "payout_imports";
+
+DROP TABLE IF EXISTS "payout_import_rows";
+
+DROP TABLE IF EXISTS "payout_imports";
@@ -0,0 +1,58 @@
+CREATE TABLE "payout_imports" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "row_count" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "payout_imports" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+CREATE INDEX ON "payout_imports" ("owner", "id");
+
+CREATE TABLE "payout_import_rows" (
+  "id" bigserial PRIMARY KEY,
+  "import_id" bigint NOT NULL,
+  "line" bigint NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "reference" varchar NOT NULL,
+  "status" varchar NOT NULL,
+  "error" varchar NOT NULL DEFAULT '',
+  "transfer_id" bigint,
+  "approval_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "payout_import_rows_status_check" CHECK (
+    "status" IN ('executed', 'pending', 'failed') AND
+    ("status" = 'executed') = ("transfer_id" IS NOT NULL) AND
+    ("status" = 'pending') = ("approval_id" IS NOT NULL) AND
+    ("status" = 'failed') = ("error" <> '')
+  )
+);
+
+ALTER TABLE "payout_import_rows" ADD FOREIGN KEY ("import_id") REFERENCES "payout_imports" ("id");
+
+ALTER TABLE "payout_import_rows" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payout_import_rows" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payout_import_rows" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "payout_import_rows" ADD FOREIGN KEY ("approval_id") REFERENCES "transfer_approvals" ("id");
+
+CREATE INDEX ON "payout_import_rows" ("import_id", "line");
+
+COMMENT ON COLUMN "payout_import_rows"."line" IS 'line of the row in the uploaded file, the header is line 1';
+
+COMMENT ON COLUMN "payout_import_rows"."status" IS 'outcome when the file was imported, a pending row follows its approval';
+
+-- only files whose every row passed validation are imported, the rows record how each transfer went
+CREATE TRIGGER "payout_imports_append_only"
+  BEFORE UPDATE OR DELETE ON "payout_imports"
+  FOR EACH ROW EXECUTE FUNCTION "forbid_ledger_change"();
+
+CREATE TRIGGER "payout_import_rows_append_only"
+  BEFORE UPDATE OR DELETE ON "payout_import_rows"
+  FOR EACH ROW EXECUTE FUNCTION "forbid_ledger_change"();
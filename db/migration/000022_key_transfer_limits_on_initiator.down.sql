@@ -0,0 +1,5 @@
+COMMENT ON COLUMN "transfer_limits"."currency" IS 'null applies the limit to every currency';
+
+ALTER TABLE "transfer_limits" DROP CONSTRAINT IF EXISTS "transfer_limits_currency_check";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "initiated_by";
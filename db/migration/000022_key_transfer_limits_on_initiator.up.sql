@@ -0,0 +1,19 @@
+-- transfers record the user who made them, user and tier limits count what that user spent
+-- whichever account it came from, rather than everything spent from the accounts they own
+ALTER TABLE "transfers" ADD COLUMN "initiated_by" varchar;
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("initiated_by") REFERENCES "users" ("username");
+
+CREATE INDEX ON "transfers" ("initiated_by", "created_at");
+
+COMMENT ON COLUMN "transfers"."initiated_by" IS 'user who made the transfer, null for transfers made by the bank and for transfers made before it was recorded, which count against the owner of the account';
+
+-- amounts in different currencies have different minor units, an amount limit only makes sense in one currency.
+-- NOT VALID keeps existing limits loadable, the limit checks ignore the amounts of a limit without a currency
+ALTER TABLE "transfer_limits" ADD CONSTRAINT "transfer_limits_currency_check" CHECK (
+  "currency" IS NOT NULL OR (
+    "max_single_amount" IS NULL AND "daily_amount" IS NULL AND "monthly_amount" IS NULL
+  )
+) NOT VALID;
+
+COMMENT ON COLUMN "transfer_limits"."currency" IS 'required for amount limits, null applies count limits to every currency';
@@ -0,0 +1,5 @@
+ALTER TABLE "accounts" ALTER COLUMN "balance" DROP DEFAULT;
+
+DROP TRIGGER IF EXISTS "accounts_opening_balance_check" ON "accounts";
+
+DROP FUNCTION IF EXISTS "check_opening_balance";
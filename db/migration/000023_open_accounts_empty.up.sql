@@ -0,0 +1,16 @@
+-- a balance only comes from entries, so accounts are opened empty and funded by a transfer
+CREATE FUNCTION "check_opening_balance"() RETURNS trigger AS $$
+BEGIN
+  IF NEW."balance" <> 0 THEN
+    RAISE EXCEPTION 'account % must be opened with a zero balance', NEW."id"
+      USING ERRCODE = 'BA002';
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "accounts_opening_balance_check"
+  BEFORE INSERT ON "accounts"
+  FOR EACH ROW EXECUTE FUNCTION "check_opening_balance"();
+
+ALTER TABLE "accounts" ALTER COLUMN "balance" SET DEFAULT 0;
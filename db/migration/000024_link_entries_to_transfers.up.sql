@@ -0,0 +1,36 @@
+-- entries name the transfer they are a leg of, rather than being matched to it by account, amount and time
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint;
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "entries" ("transfer_id");
+
+COMMENT ON COLUMN "entries"."transfer_id" IS 'transfer the entry is a leg of, null for an entry no transfer accounts for';
+
+-- entries written before the link are paired once with the legs of the transfer written alongside them,
+-- the n-th entry of an account, amount, reference and time with the n-th transfer leg of the same.
+-- The ledger is append-only, so the owner lifts the update check for this repair only.
+ALTER TABLE "entries" DISABLE TRIGGER "entries_update_check";
+
+GRANT UPDATE ("transfer_id") ON "entries" TO CURRENT_USER;
+
+WITH "legs" AS (
+  SELECT "id" AS "transfer_id", "from_account_id" AS "account_id", -"amount" AS "amount", "reference", "created_at" FROM "transfers"
+  UNION ALL
+  SELECT "id", "to_account_id", "amount", "reference", "created_at" FROM "transfers"
+), "numbered_legs" AS (
+  SELECT *, row_number() OVER (PARTITION BY "account_id", "amount", "reference", "created_at" ORDER BY "transfer_id") AS "n"
+  FROM "legs"
+), "numbered_entries" AS (
+  SELECT "id", "account_id", "amount", "reference", "created_at",
+    row_number() OVER (PARTITION BY "account_id", "amount", "reference", "created_at" ORDER BY "id") AS "n"
+  FROM "entries"
+)
+UPDATE "entries" SET "transfer_id" = "numbered_legs"."transfer_id"
+FROM "numbered_entries"
+JOIN "numbered_legs" USING ("account_id", "amount", "reference", "created_at", "n")
+WHERE "entries"."id" = "numbered_entries"."id";
+
+REVOKE UPDATE ("transfer_id") ON "entries" FROM CURRENT_USER;
+
+ALTER TABLE "entries" ENABLE TRIGGER "entries_update_check";
@@ -0,0 +1,13 @@
+DELETE FROM "payees" WHERE "deleted_at" IS NOT NULL;
+
+DROP INDEX IF EXISTS "payees_owner_created_at_idx";
+
+DROP INDEX IF EXISTS "payees_owner_account_key";
+
+DROP INDEX IF EXISTS "payees_owner_username_key";
+
+CREATE UNIQUE INDEX "payees_owner_account_key" ON "payees" ("owner", "account_id") WHERE "account_id" IS NOT NULL;
+
+CREATE UNIQUE INDEX "payees_owner_username_key" ON "payees" ("owner", "username") WHERE "username" IS NOT NULL;
+
+ALTER TABLE "payees" DROP COLUMN IF EXISTS "deleted_at";
@@ -0,0 +1,14 @@
+-- deleting a payee keeps its row, so a payee deleted while it is new still caps transfers to its account
+ALTER TABLE "payees" ADD COLUMN "deleted_at" timestamptz;
+
+DROP INDEX "payees_owner_account_key";
+
+DROP INDEX "payees_owner_username_key";
+
+CREATE UNIQUE INDEX "payees_owner_account_key" ON "payees" ("owner", "account_id") WHERE "account_id" IS NOT NULL AND "deleted_at" IS NULL;
+
+CREATE UNIQUE INDEX "payees_owner_username_key" ON "payees" ("owner", "username") WHERE "username" IS NOT NULL AND "deleted_at" IS NULL;
+
+CREATE INDEX ON "payees" ("owner", "created_at");
+
+COMMENT ON COLUMN "payees"."deleted_at" IS 'deleted payees are kept for the cooling-off of their account';
@@ -2,9 +2,11 @@
 INSERT INTO accounts (
     owner,
     balance,
-    currency
+    currency,
+    type,
+    org_id
 ) VALUES (
-    $1, $2, $3
+    $1, 0, $2, $3, $4
 )
 RETURNING *;
 
@@ -12,6 +14,23 @@ RETURNING *;
 SELECT * FROM accounts
 WHERE id = $1 LIMIT 1;
 
+-- name: CreateSystemAccount :one
+INSERT INTO accounts (
+    owner,
+    balance,
+    currency,
+    type,
+    purpose
+) VALUES (
+    'bank', 0, $1, 'system', $2
+)
+RETURNING *;
+
+-- name: GetSystemAccount :one
+SELECT * FROM accounts
+WHERE owner = 'bank' AND type = 'system' AND currency = $1 AND purpose = $2
+LIMIT 1;
+
 -- name: GetAccountForUpdate :one
 SELECT * FROM accounts
 WHERE id = $1 LIMIT 1
@@ -19,23 +38,65 @@ FOR NO KEY UPDATE;
 
 -- name: ListAccounts :many
 SELECT * FROM accounts
-WHERE owner = $1
+WHERE (
+    sqlc.narg(org_id)::bigint IS NULL AND id IN (
+        SELECT account_id FROM account_members
+        WHERE username = sqlc.arg(username) AND status = 'active'
+    )
+) OR org_id = sqlc.narg(org_id)
 ORDER BY id
-LIMIT $2
-OFFSET $3;
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
--- name: UpdateAccount :one
+-- name: CloseAccount :one
 UPDATE accounts
-SET balance = $2
-WHERE id = $1
+SET closed_at = now(), status = 'closed'
+WHERE id = $1 AND closed_at IS NULL
 RETURNING *;
 
--- name: AddAccountBalance :one
+-- name: UpdateAccountStatus :one
 UPDATE accounts
-SET balance = balance + sqlc.arg(amount)
-WHERE id = sqlc.arg(id)
+SET status = $2
+WHERE id = $1 AND status <> 'closed'
 RETURNING *;
 
--- name: DeleteAccount :exec
-DELETE FROM accounts
-WHERE id = $1;
\ No newline at end of file
+-- name: ListAccountsAfter :many
+SELECT * FROM accounts
+WHERE ((
+    sqlc.narg(org_id)::bigint IS NULL AND id IN (
+        SELECT account_id FROM account_members
+        WHERE username = sqlc.arg(username) AND status = 'active'
+    )
+) OR org_id = sqlc.narg(org_id))
+AND id > sqlc.arg(cursor_id)
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
+
+-- name: ListAccountsBefore :many
+SELECT * FROM accounts
+WHERE ((
+    sqlc.narg(org_id)::bigint IS NULL AND id IN (
+        SELECT account_id FROM account_members
+        WHERE username = sqlc.arg(username) AND status = 'active'
+    )
+) OR org_id = sqlc.narg(org_id))
+AND id < sqlc.arg(cursor_id)
+ORDER BY id DESC
+LIMIT sqlc.arg(page_limit);
+
+-- name: GetOwnerAccount :one
+SELECT * FROM accounts
+WHERE owner = $1 AND currency = $2 AND type = $3 AND org_id IS NULL
+LIMIT 1;
+
+-- name: OpenOwnerAccount :one
+INSERT INTO accounts (
+    owner,
+    balance,
+    currency,
+    type
+) VALUES (
+    $1, 0, $2, $3
+)
+ON CONFLICT (owner, currency, type, purpose) WHERE org_id IS NULL DO NOTHING
+RETURNING *;
@@ -0,0 +1,34 @@
+-- name: CreateAccountMember :one
+INSERT INTO account_members (
+    account_id,
+    username,
+    role,
+    invited_by
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetAccountMember :one
+SELECT * FROM account_members
+WHERE account_id = $1 AND username = $2
+LIMIT 1;
+
+-- name: ListAccountMembers :many
+SELECT * FROM account_members
+WHERE account_id = $1
+ORDER BY id;
+
+-- name: AcceptAccountMember :one
+UPDATE account_members
+SET status = 'active', accepted_at = now()
+WHERE account_id = $1 AND username = $2 AND status = 'invited'
+RETURNING *;
+
+-- name: DeleteAccountMember :exec
+DELETE FROM account_members
+WHERE account_id = $1 AND username = $2;
+
+-- name: CountActiveAccountOwners :one
+SELECT count(*) FROM account_members
+WHERE account_id = $1 AND role = 'owner' AND status = 'active';
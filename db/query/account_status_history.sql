@@ -0,0 +1,17 @@
+-- name: CreateAccountStatusHistory :one
+INSERT INTO account_status_history (
+    account_id,
+    from_status,
+    to_status,
+    reason,
+    note,
+    actor
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: ListAccountStatusHistory :many
+SELECT * FROM account_status_history
+WHERE account_id = $1
+ORDER BY id;
@@ -0,0 +1,43 @@
+-- name: CreateBalanceSnapshots :execrows
+INSERT INTO balance_snapshots (
+    account_id,
+    day,
+    balance
+)
+SELECT
+    a.id,
+    sqlc.arg(day)::date,
+    (a.balance - COALESCE((
+        SELECT SUM(e.amount) FROM entries e
+        WHERE e.account_id = a.id AND e.created_at >= sqlc.arg(day_end)
+    ), 0))::bigint
+FROM accounts a
+WHERE a.created_at < sqlc.arg(day_end)
+ON CONFLICT (account_id, day) DO NOTHING;
+
+-- name: GetLatestBalanceSnapshot :one
+SELECT * FROM balance_snapshots
+WHERE account_id = sqlc.arg(account_id)
+AND day < sqlc.arg(before)::date
+ORDER BY day DESC
+LIMIT 1;
+
+-- name: ListAccountBalanceSnapshots :many
+SELECT * FROM balance_snapshots
+WHERE account_id = sqlc.arg(account_id)
+AND day >= sqlc.arg(from_date)::date
+AND day <= sqlc.arg(to_date)::date
+ORDER BY day;
+
+-- name: SumAccountEntriesBetween :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = sqlc.arg(account_id)
+AND created_at >= sqlc.arg(start_time)
+AND created_at < sqlc.arg(end_time);
+
+-- name: SumAccountEntriesSince :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = sqlc.arg(account_id)
+AND created_at >= sqlc.arg(start_time);
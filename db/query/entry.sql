@@ -1,9 +1,13 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
     account_id,
-    amount
+    amount,
+    description,
+    reference,
+    metadata,
+    transfer_id
 ) VALUES (
-    $1, $2
+    $1, $2, $3, $4, $5, $6
 )
 RETURNING *;
 
@@ -17,3 +21,77 @@ OFFSET $3;
 -- name: GetEntry :one
 SELECT * FROM entries
 WHERE id = $1 LIMIT 1;
+
+-- name: ListAccountEntries :many
+SELECT * FROM entries
+WHERE account_id = sqlc.arg(account_id)
+AND (
+    sqlc.narg(direction)::varchar IS NULL
+    OR (sqlc.narg(direction) = 'out' AND amount < 0)
+    OR (sqlc.narg(direction) = 'in' AND amount > 0)
+)
+AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+AND (sqlc.narg(min_amount)::bigint IS NULL OR abs(amount) >= sqlc.narg(min_amount))
+AND (sqlc.narg(max_amount)::bigint IS NULL OR abs(amount) <= sqlc.narg(max_amount))
+ORDER BY id
+LIMIT sqlc.arg(page_limit)
+OFFSET sqlc.arg(page_offset);
+
+-- name: ListAccountEntriesAfter :many
+SELECT * FROM entries
+WHERE account_id = sqlc.arg(account_id)
+AND id > sqlc.arg(cursor_id)
+AND (
+    sqlc.narg(direction)::varchar IS NULL
+    OR (sqlc.narg(direction) = 'out' AND amount < 0)
+    OR (sqlc.narg(direction) = 'in' AND amount > 0)
+)
+AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+AND (sqlc.narg(min_amount)::bigint IS NULL OR abs(amount) >= sqlc.narg(min_amount))
+AND (sqlc.narg(max_amount)::bigint IS NULL OR abs(amount) <= sqlc.narg(max_amount))
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
+
+-- name: ListAccountEntriesBefore :many
+SELECT * FROM entries
+WHERE account_id = sqlc.arg(account_id)
+AND id < sqlc.arg(cursor_id)
+AND (
+    sqlc.narg(direction)::varchar IS NULL
+    OR (sqlc.narg(direction) = 'out' AND amount < 0)
+    OR (sqlc.narg(direction) = 'in' AND amount > 0)
+)
+AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+AND (sqlc.narg(min_amount)::bigint IS NULL OR abs(amount) >= sqlc.narg(min_amount))
+AND (sqlc.narg(max_amount)::bigint IS NULL OR abs(amount) <= sqlc.narg(max_amount))
+ORDER BY id DESC
+LIMIT sqlc.arg(page_limit);
+
+-- name: GetAccountChainHead :one
+SELECT hash FROM entries
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: SetEntryHash :one
+UPDATE entries
+SET prev_hash = $2, hash = $3
+WHERE id = $1 AND hash IS NULL
+RETURNING *;
+
+-- name: ListAccountChainEntries :many
+SELECT * FROM entries
+WHERE account_id = $1
+ORDER BY id;
+
+-- name: ListAccountChainHeads :many
+SELECT DISTINCT ON (account_id) account_id, id, hash FROM entries
+WHERE hash IS NOT NULL AND id <= $1
+ORDER BY account_id, id DESC;
+
+-- name: ListEntryAccountIDs :many
+SELECT DISTINCT account_id FROM entries
+ORDER BY account_id;
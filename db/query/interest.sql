@@ -0,0 +1,102 @@
+-- name: CreateInterestRate :one
+INSERT INTO interest_rates (
+    account_type,
+    currency,
+    annual_rate_ppm,
+    effective_from
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: ListInterestRates :many
+SELECT * FROM interest_rates
+ORDER BY account_type, currency, effective_from;
+
+-- name: ListInterestBearingBalances :many
+SELECT
+    a.id AS account_id,
+    r.annual_rate_ppm,
+    (a.balance - COALESCE((
+        SELECT SUM(e.amount) FROM entries e
+        WHERE e.account_id = a.id AND e.created_at >= sqlc.arg(day_end)
+    ), 0))::bigint AS balance
+FROM accounts a
+JOIN LATERAL (
+    SELECT annual_rate_ppm FROM interest_rates
+    WHERE interest_rates.account_type = a.type
+    AND interest_rates.currency = a.currency
+    AND interest_rates.effective_from <= sqlc.arg(day)::date
+    ORDER BY interest_rates.effective_from DESC
+    LIMIT 1
+) r ON true
+WHERE a.created_at < sqlc.arg(day_end)
+AND (a.closed_at IS NULL OR a.closed_at >= sqlc.arg(day_end))
+ORDER BY a.id;
+
+-- name: UpsertInterestAccrual :execrows
+INSERT INTO interest_accruals (
+    account_id,
+    accrual_date,
+    balance,
+    annual_rate_ppm,
+    amount_micros
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (account_id, accrual_date) DO UPDATE
+SET balance = EXCLUDED.balance,
+    annual_rate_ppm = EXCLUDED.annual_rate_ppm,
+    amount_micros = EXCLUDED.amount_micros
+WHERE interest_accruals.capitalization_id IS NULL;
+
+-- name: ListAccountInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = sqlc.arg(account_id)
+AND accrual_date >= sqlc.arg(from_date)::date
+AND accrual_date <= sqlc.arg(to_date)::date
+ORDER BY accrual_date;
+
+-- name: ListAccountsWithUncapitalizedInterest :many
+SELECT DISTINCT account_id FROM interest_accruals
+WHERE capitalization_id IS NULL
+AND accrual_date >= sqlc.arg(period_start)::date
+AND accrual_date <= sqlc.arg(period_end)::date
+ORDER BY account_id;
+
+-- name: GetUncapitalizedInterest :one
+SELECT COALESCE(SUM(amount_micros), 0)::bigint AS accrued_micros
+FROM interest_accruals
+WHERE account_id = sqlc.arg(account_id)
+AND capitalization_id IS NULL
+AND accrual_date >= sqlc.arg(period_start)::date
+AND accrual_date <= sqlc.arg(period_end)::date;
+
+-- name: GetLastInterestCapitalization :one
+SELECT * FROM interest_capitalizations
+WHERE account_id = $1
+ORDER BY period_start DESC
+LIMIT 1;
+
+-- name: CreateInterestCapitalization :one
+INSERT INTO interest_capitalizations (
+    account_id,
+    period_start,
+    period_end,
+    accrued_micros,
+    carried_micros,
+    amount,
+    remainder_micros,
+    transfer_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: MarkInterestAccrualsCapitalized :exec
+UPDATE interest_accruals
+SET capitalization_id = sqlc.arg(capitalization_id)
+WHERE account_id = sqlc.arg(account_id)
+AND capitalization_id IS NULL
+AND accrual_date >= sqlc.arg(period_start)::date
+AND accrual_date <= sqlc.arg(period_end)::date;
@@ -0,0 +1,60 @@
+-- name: ListAccountBalanceMismatches :many
+SELECT accounts.id, accounts.currency, accounts.balance, COALESCE(SUM(entries.amount), 0)::bigint AS entries_total
+FROM accounts
+LEFT JOIN entries ON entries.account_id = accounts.id
+GROUP BY accounts.id
+HAVING accounts.balance <> COALESCE(SUM(entries.amount), 0)
+ORDER BY accounts.id;
+
+-- name: ListOrphanEntries :many
+SELECT entries.id, entries.account_id, entries.amount, entries.created_at, accounts.currency
+FROM entries
+JOIN accounts ON accounts.id = entries.account_id
+LEFT JOIN transfers ON transfers.id = entries.transfer_id
+WHERE transfers.id IS NULL
+OR NOT (
+    (entries.account_id = transfers.from_account_id AND entries.amount = -transfers.amount)
+    OR (entries.account_id = transfers.to_account_id AND entries.amount = transfers.amount)
+)
+OR EXISTS (
+    SELECT 1 FROM entries leg
+    WHERE leg.transfer_id = entries.transfer_id
+    AND leg.account_id = entries.account_id
+    AND leg.amount = entries.amount
+    AND leg.id < entries.id
+)
+ORDER BY entries.id;
+
+-- name: ListUnbalancedTransfers :many
+SELECT transfers.id, transfers.from_account_id, transfers.to_account_id, transfers.amount, transfers.created_at, accounts.currency
+FROM transfers
+JOIN accounts ON accounts.id = transfers.from_account_id
+WHERE transfers.amount <= 0
+OR NOT EXISTS (
+    SELECT 1 FROM entries
+    WHERE entries.transfer_id = transfers.id
+    AND entries.account_id = transfers.from_account_id
+    AND entries.amount = -transfers.amount
+)
+OR NOT EXISTS (
+    SELECT 1 FROM entries
+    WHERE entries.transfer_id = transfers.id
+    AND entries.account_id = transfers.to_account_id
+    AND entries.amount = transfers.amount
+)
+ORDER BY transfers.id;
+
+-- name: ListCurrencyLedgerTotals :many
+SELECT accounts.currency,
+    count(*) AS accounts,
+    COALESCE(SUM(accounts.balance), 0)::bigint AS balance,
+    COALESCE(SUM(account_entries.entries), 0)::bigint AS entries,
+    COALESCE(SUM(account_entries.total), 0)::bigint AS entries_total
+FROM accounts
+LEFT JOIN (
+    SELECT account_id, count(*) AS entries, SUM(amount) AS total
+    FROM entries
+    GROUP BY account_id
+) AS account_entries ON account_entries.account_id = accounts.id
+GROUP BY accounts.currency
+ORDER BY accounts.currency;
@@ -0,0 +1,21 @@
+-- name: CreateLedgerCheckpoint :one
+INSERT INTO ledger_checkpoints (
+    root,
+    accounts,
+    last_entry_id,
+    public_key,
+    signature,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetLedgerCheckpoint :one
+SELECT * FROM ledger_checkpoints
+WHERE id = $1 LIMIT 1;
+
+-- name: ListLedgerCheckpoints :many
+SELECT * FROM ledger_checkpoints
+ORDER BY id DESC
+LIMIT $1;
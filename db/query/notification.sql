@@ -0,0 +1,22 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (
+    username,
+    kind,
+    message,
+    data
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListNotifications :many
+SELECT * FROM notifications
+WHERE username = sqlc.arg(username)
+AND (NOT sqlc.arg(unread_only)::boolean OR read_at IS NULL)
+ORDER BY id DESC
+LIMIT sqlc.arg(page_limit);
+
+-- name: MarkNotificationRead :one
+UPDATE notifications
+SET read_at = COALESCE(read_at, now())
+WHERE id = $1 AND username = $2
+RETURNING *;
@@ -0,0 +1,52 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (
+    name,
+    created_by
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: GetOrganization :one
+SELECT * FROM organizations
+WHERE id = $1 LIMIT 1;
+
+-- name: GetOrganizationForUpdate :one
+SELECT * FROM organizations
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListUserOrganizations :many
+SELECT organizations.* FROM organizations
+JOIN organization_members ON organization_members.org_id = organizations.id
+WHERE organization_members.username = $1
+ORDER BY organizations.id;
+
+-- name: CreateOrganizationMember :one
+INSERT INTO organization_members (
+    org_id,
+    username,
+    role,
+    added_by
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetOrganizationMember :one
+SELECT * FROM organization_members
+WHERE org_id = $1 AND username = $2
+LIMIT 1;
+
+-- name: ListOrganizationMembers :many
+SELECT * FROM organization_members
+WHERE org_id = $1
+ORDER BY id;
+
+-- name: DeleteOrganizationMember :exec
+DELETE FROM organization_members
+WHERE org_id = $1 AND username = $2;
+
+-- name: CountOrganizationAdmins :one
+SELECT count(*) FROM organization_members
+WHERE org_id = $1 AND role = 'admin';
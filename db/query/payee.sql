@@ -0,0 +1,31 @@
+-- name: CreatePayee :one
+INSERT INTO payees (
+    owner,
+    name,
+    account_id,
+    username
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetPayee :one
+SELECT * FROM payees
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
+
+-- name: ListPayees :many
+SELECT * FROM payees
+WHERE owner = $1 AND deleted_at IS NULL
+ORDER BY name, id;
+
+-- name: ListNewPayees :many
+-- deleted payees are listed too, they cap transfers to their account until the cooling-off ends
+SELECT * FROM payees
+WHERE owner = sqlc.arg(owner) AND created_at > sqlc.arg(created_after)
+ORDER BY created_at, id;
+
+-- name: DeletePayee :one
+UPDATE payees
+SET deleted_at = now()
+WHERE id = $1 AND owner = $2 AND deleted_at IS NULL
+RETURNING *;
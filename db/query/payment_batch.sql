@@ -0,0 +1,51 @@
+-- name: CreatePaymentBatch :one
+INSERT INTO payment_batches (
+    owner,
+    message_id,
+    message_name,
+    message_created_at,
+    initiating_party,
+    transactions,
+    control_sum
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetPaymentBatch :one
+SELECT * FROM payment_batches
+WHERE id = $1 LIMIT 1;
+
+-- name: ListPaymentBatches :many
+SELECT * FROM payment_batches
+WHERE owner = $1
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: CreatePaymentBatchTransaction :one
+INSERT INTO payment_batch_transactions (
+    batch_id,
+    payment_info_id,
+    instruction_id,
+    end_to_end_id,
+    debtor_account,
+    creditor_account,
+    amount,
+    currency,
+    status,
+    reason_code,
+    reason,
+    transfer_id,
+    approval_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) RETURNING *;
+
+-- name: ListPaymentBatchTransactions :many
+SELECT
+    payment_batch_transactions.*,
+    COALESCE(transfer_approvals.status, '')::varchar AS approval_status,
+    transfer_approvals.transfer_id AS approval_transfer_id
+FROM payment_batch_transactions
+LEFT JOIN transfer_approvals ON transfer_approvals.id = payment_batch_transactions.approval_id
+WHERE payment_batch_transactions.batch_id = $1
+ORDER BY payment_batch_transactions.id;
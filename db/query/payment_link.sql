@@ -0,0 +1,48 @@
+-- name: CreatePaymentLink :one
+INSERT INTO payment_links (
+    owner,
+    to_account_id,
+    amount,
+    currency,
+    description,
+    single_use,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetPaymentLink :one
+SELECT * FROM payment_links
+WHERE id = $1 LIMIT 1;
+
+-- name: GetPaymentLinkForUpdate :one
+SELECT * FROM payment_links
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListPaymentLinks :many
+SELECT * FROM payment_links
+WHERE owner = $1
+ORDER BY id DESC;
+
+-- name: UpdatePaymentLinkStatus :one
+UPDATE payment_links
+SET status = $2
+WHERE id = $1 AND status = 'active'
+RETURNING *;
+
+-- name: CreatePaymentLinkPayment :one
+INSERT INTO payment_link_payments (
+    link_id,
+    transfer_id,
+    payer
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: ListPaymentLinkPayments :many
+SELECT * FROM payment_link_payments
+WHERE link_id = $1
+ORDER BY id;
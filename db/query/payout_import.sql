@@ -0,0 +1,45 @@
+-- name: CreatePayoutImport :one
+INSERT INTO payout_imports (
+    owner,
+    row_count
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetPayoutImport :one
+SELECT * FROM payout_imports
+WHERE id = $1 LIMIT 1;
+
+-- name: ListPayoutImports :many
+SELECT * FROM payout_imports
+WHERE owner = $1
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: CreatePayoutImportRow :one
+INSERT INTO payout_import_rows (
+    import_id,
+    line,
+    from_account_id,
+    to_account_id,
+    amount,
+    currency,
+    description,
+    reference,
+    status,
+    error,
+    transfer_id,
+    approval_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING *;
+
+-- name: ListPayoutImportRows :many
+SELECT
+    payout_import_rows.*,
+    COALESCE(transfer_approvals.status, '')::varchar AS approval_status,
+    transfer_approvals.transfer_id AS approval_transfer_id
+FROM payout_import_rows
+LEFT JOIN transfer_approvals ON transfer_approvals.id = payout_import_rows.approval_id
+WHERE payout_import_rows.import_id = $1
+ORDER BY payout_import_rows.line;
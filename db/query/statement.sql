@@ -0,0 +1,70 @@
+-- name: ListStatementEntries :many
+SELECT
+    entries.id,
+    entries.amount,
+    entries.created_at,
+    entries.description,
+    entries.reference,
+    COALESCE(entries.transfer_id, 0)::bigint AS transfer_id,
+    COALESCE(users.full_name, '')::varchar AS counterparty
+FROM entries
+LEFT JOIN transfers ON transfers.id = entries.transfer_id
+LEFT JOIN accounts counterparty ON counterparty.id =
+    CASE WHEN entries.amount < 0 THEN transfers.to_account_id ELSE transfers.from_account_id END
+LEFT JOIN users ON users.username = counterparty.owner
+WHERE entries.account_id = sqlc.arg(account_id)
+AND entries.created_at >= sqlc.arg(start_time)
+AND entries.created_at < sqlc.arg(end_time)
+AND entries.id > sqlc.arg(after_id)
+ORDER BY entries.id
+LIMIT sqlc.arg(page_limit);
+
+-- name: CreateStatement :one
+INSERT INTO statements (
+    account_id,
+    period_start,
+    period_end,
+    format,
+    blob_key,
+    size,
+    sha256,
+    opening_balance,
+    closing_balance
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetStatement :one
+SELECT * FROM statements
+WHERE id = $1 LIMIT 1;
+
+-- name: ListAccountStatements :many
+SELECT * FROM statements
+WHERE account_id = $1
+ORDER BY period_start DESC, id DESC;
+
+-- name: ListStatementAccounts :many
+SELECT * FROM accounts
+WHERE status = 'active'
+AND type <> 'system'
+AND created_at < sqlc.arg(period_end_time)
+AND id > sqlc.arg(after_id)
+AND NOT EXISTS (
+    SELECT 1 FROM statements
+    WHERE statements.account_id = accounts.id
+    AND statements.period_start = sqlc.arg(period_start)
+    AND statements.format = sqlc.arg(format)
+)
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
+
+-- name: SumStatementEntries :one
+SELECT
+    COALESCE(SUM(amount) FILTER (WHERE amount >= 0), 0)::bigint AS credits,
+    COALESCE(SUM(amount) FILTER (WHERE amount < 0), 0)::bigint AS debits,
+    COUNT(*) FILTER (WHERE amount >= 0) AS credit_entries,
+    COUNT(*) FILTER (WHERE amount < 0) AS debit_entries
+FROM entries
+WHERE account_id = sqlc.arg(account_id)
+AND created_at >= sqlc.arg(start_time)
+AND created_at < sqlc.arg(end_time);
@@ -2,9 +2,13 @@
 INSERT INTO transfers (
     from_account_id,
     to_account_id,
-    amount
+    amount,
+    description,
+    reference,
+    metadata,
+    initiated_by
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5, $6, $7
 )
 RETURNING *;
 
@@ -19,6 +23,100 @@ OFFSET $3;
 SELECT * FROM transfers
 WHERE id = $1 LIMIT 1;
 
--- name: DeleteTransfer :exec
-DELETE FROM transfers
-WHERE id = $1;
+-- name: GetAccountTransferTotals :one
+SELECT
+    COALESCE(SUM(amount), 0)::bigint AS total_amount,
+    COUNT(*) AS transfer_count
+FROM transfers
+WHERE from_account_id = sqlc.arg(from_account_id)
+AND created_at >= sqlc.arg(since);
+
+-- name: GetUserTransferTotals :one
+SELECT
+    COALESCE(SUM(transfers.amount), 0)::bigint AS total_amount,
+    COUNT(*) AS transfer_count
+FROM transfers
+JOIN accounts ON accounts.id = transfers.from_account_id
+WHERE COALESCE(transfers.initiated_by, accounts.owner) = sqlc.arg(username)
+AND accounts.currency = sqlc.arg(currency)
+AND transfers.created_at >= sqlc.arg(since);
+
+-- name: ListTransfersByReference :many
+SELECT transfers.*, accounts.currency FROM transfers
+JOIN accounts ON accounts.id = transfers.from_account_id
+WHERE transfers.reference = sqlc.arg(reference)
+AND (
+    transfers.from_account_id IN (
+        SELECT account_id FROM account_members WHERE username = sqlc.arg(username) AND status = 'active'
+    )
+    OR transfers.to_account_id IN (
+        SELECT account_id FROM account_members WHERE username = sqlc.arg(username) AND status = 'active'
+    )
+    OR transfers.from_account_id IN (SELECT id FROM accounts WHERE org_id = sqlc.narg(org_id))
+    OR transfers.to_account_id IN (SELECT id FROM accounts WHERE org_id = sqlc.narg(org_id))
+)
+ORDER BY transfers.id
+LIMIT 100;
+
+-- name: ListAccountTransfers :many
+SELECT * FROM transfers
+WHERE (from_account_id = sqlc.arg(account_id) OR to_account_id = sqlc.arg(account_id))
+AND (
+    sqlc.narg(direction)::varchar IS NULL
+    OR (sqlc.narg(direction) = 'out' AND from_account_id = sqlc.arg(account_id))
+    OR (sqlc.narg(direction) = 'in' AND to_account_id = sqlc.arg(account_id))
+)
+AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+ORDER BY id
+LIMIT sqlc.arg(page_limit)
+OFFSET sqlc.arg(page_offset);
+
+-- name: ListAccountTransfersAfter :many
+SELECT * FROM transfers
+WHERE (from_account_id = sqlc.arg(account_id) OR to_account_id = sqlc.arg(account_id))
+AND id > sqlc.arg(cursor_id)
+AND (
+    sqlc.narg(direction)::varchar IS NULL
+    OR (sqlc.narg(direction) = 'out' AND from_account_id = sqlc.arg(account_id))
+    OR (sqlc.narg(direction) = 'in' AND to_account_id = sqlc.arg(account_id))
+)
+AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
+
+-- name: ListAccountTransfersBefore :many
+SELECT * FROM transfers
+WHERE (from_account_id = sqlc.arg(account_id) OR to_account_id = sqlc.arg(account_id))
+AND id < sqlc.arg(cursor_id)
+AND (
+    sqlc.narg(direction)::varchar IS NULL
+    OR (sqlc.narg(direction) = 'out' AND from_account_id = sqlc.arg(account_id))
+    OR (sqlc.narg(direction) = 'in' AND to_account_id = sqlc.arg(account_id))
+)
+AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+ORDER BY id DESC
+LIMIT sqlc.arg(page_limit);
+
+-- name: TransferReferenceExists :one
+SELECT (
+    EXISTS (
+        SELECT 1 FROM transfers
+        WHERE from_account_id = sqlc.arg(from_account_id) AND reference = sqlc.arg(reference)
+    ) OR EXISTS (
+        SELECT 1 FROM transfer_approvals
+        WHERE from_account_id = sqlc.arg(from_account_id) AND reference = sqlc.arg(reference)
+        AND status = 'pending_approval'
+    )
+)::bool AS used;
+
+-- name: LockTransferReference :exec
+SELECT pg_advisory_xact_lock(hashtextextended(sqlc.arg(from_account_id)::bigint || '/' || sqlc.arg(reference)::text, 0));
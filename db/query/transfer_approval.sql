@@ -0,0 +1,64 @@
+-- name: CreateTransferApproval :one
+INSERT INTO transfer_approvals (
+    from_account_id,
+    to_account_id,
+    amount,
+    description,
+    reference,
+    metadata,
+    initiated_by,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetTransferApproval :one
+SELECT * FROM transfer_approvals
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferApprovalForUpdate :one
+SELECT * FROM transfer_approvals
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListPendingTransferApprovals :many
+SELECT transfer_approvals.*, accounts.currency FROM transfer_approvals
+JOIN accounts ON accounts.id = transfer_approvals.from_account_id
+WHERE transfer_approvals.status = 'pending_approval'
+AND transfer_approvals.expires_at > now()
+AND ((
+    sqlc.narg(org_id)::bigint IS NULL AND accounts.org_id IS NULL AND accounts.id IN (
+        SELECT account_id FROM account_members
+        WHERE username = sqlc.arg(username) AND status = 'active'
+    )
+) OR accounts.org_id = sqlc.narg(org_id))
+ORDER BY transfer_approvals.id;
+
+-- name: DecideTransferApproval :one
+UPDATE transfer_approvals
+SET status = $2, decided_by = $3, transfer_id = $4, decided_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: ExpireTransferApprovals :many
+UPDATE transfer_approvals
+SET status = 'expired', decided_at = now()
+WHERE status = 'pending_approval' AND expires_at <= $1
+RETURNING *;
+
+-- name: CreateTransferApprovalEvent :one
+INSERT INTO transfer_approval_events (
+    approval_id,
+    action,
+    actor,
+    note
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: ListTransferApprovalEvents :many
+SELECT * FROM transfer_approval_events
+WHERE approval_id = $1
+ORDER BY id;
@@ -0,0 +1,28 @@
+-- name: CreateTransferLimit :one
+INSERT INTO transfer_limits (
+    scope,
+    account_id,
+    username,
+    tier,
+    account_type,
+    currency,
+    max_single_amount,
+    daily_amount,
+    monthly_amount,
+    daily_count,
+    monthly_count
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+)
+RETURNING *;
+
+-- name: ListApplicableTransferLimits :many
+SELECT * FROM transfer_limits
+WHERE (currency IS NULL OR currency = sqlc.arg(currency)::varchar)
+AND (
+    (scope = 'account' AND account_id = sqlc.arg(account_id)::bigint)
+    OR (scope = 'user' AND username = sqlc.arg(username)::varchar)
+    OR (scope = 'tier' AND tier = sqlc.arg(tier)::varchar)
+    OR (scope = 'account_type' AND account_type = sqlc.arg(account_type)::varchar)
+)
+ORDER BY id;
@@ -7,22 +7,18 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
-const addAccountBalance = `-- name: AddAccountBalance :one
+const closeAccount = `-- name: CloseAccount :one
 UPDATE accounts
-SET balance = balance + $1
-WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+SET closed_at = now(), status = 'closed'
+WHERE id = $1 AND closed_at IS NULL
+RETURNING id, owner, balance, currency, created_at, closed_at, status, type, purpose, org_id
 `
 
-type AddAccountBalanceParams struct {
-	Amount int64 `json:"amount"`
-	ID     int64 `json:"id"`
-}
-
-func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, addAccountBalance, arg.Amount, arg.ID)
+func (q *Queries) CloseAccount(ctx context.Context, id int64) (Account, error) {
+	row := q.db.QueryRowContext(ctx, closeAccount, id)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -30,6 +26,11 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.Status,
+		&i.Type,
+		&i.Purpose,
+		&i.OrgID,
 	)
 	return i, err
 }
@@ -38,21 +39,29 @@ const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (
     owner,
     balance,
-    currency
+    currency,
+    type,
+    org_id
 ) VALUES (
-    $1, $2, $3
+    $1, 0, $2, $3, $4
 )
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, closed_at, status, type, purpose, org_id
 `
 
 type CreateAccountParams struct {
-	Owner    string `json:"owner"`
-	Balance  int64  `json:"balance"`
-	Currency string `json:"currency"`
+	Owner    string        `json:"owner"`
+	Currency string        `json:"currency"`
+	Type     string        `json:"type"`
+	OrgID    sql.NullInt64 `json:"org_id"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, createAccount, arg.Owner, arg.Balance, arg.Currency)
+	row := q.db.QueryRowContext(ctx, createAccount,
+		arg.Owner,
+		arg.Currency,
+		arg.Type,
+		arg.OrgID,
+	)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -60,22 +69,53 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.Status,
+		&i.Type,
+		&i.Purpose,
+		&i.OrgID,
 	)
 	return i, err
 }
 
-const deleteAccount = `-- name: DeleteAccount :exec
-DELETE FROM accounts
-WHERE id = $1
+const createSystemAccount = `-- name: CreateSystemAccount :one
+INSERT INTO accounts (
+    owner,
+    balance,
+    currency,
+    type,
+    purpose
+) VALUES (
+    'bank', 0, $1, 'system', $2
+)
+RETURNING id, owner, balance, currency, created_at, closed_at, status, type, purpose, org_id
 `
 
-func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteAccount, id)
-	return err
+type CreateSystemAccountParams struct {
+	Currency string `json:"currency"`
+	Purpose  string `json:"purpose"`
+}
+
+func (q *Queries) CreateSystemAccount(ctx context.Context, arg CreateSystemAccountParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, createSystemAccount, arg.Currency, arg.Purpose)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.Status,
+		&i.Type,
+		&i.Purpose,
+		&i.OrgID,
+	)
+	return i, err
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, closed_at, status, type, purpose, org_id FROM accounts
 WHERE id = $1 LIMIT 1
 `
 
@@ -88,12 +128,17 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.Status,
+		&i.Type,
+		&i.Purpose,
+		&i.OrgID,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, closed_at, status, type, purpose, org_id FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE
 `
@@ -107,26 +152,101 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.Status,
+		&i.Type,
+		&i.Purpose,
+		&i.OrgID,
+	)
+	return i, err
+}
+
+const getOwnerAccount = `-- name: GetOwnerAccount :one
+SELECT id, owner, balance, currency, created_at, closed_at, status, type, purpose, org_id FROM accounts
+WHERE owner = $1 AND currency = $2 AND type = $3 AND org_id IS NULL
+LIMIT 1
+`
+
+type GetOwnerAccountParams struct {
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+	Type     string `json:"type"`
+}
+
+func (q *Queries) GetOwnerAccount(ctx context.Context, arg GetOwnerAccountParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getOwnerAccount, arg.Owner, arg.Currency, arg.Type)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.Status,
+		&i.Type,
+		&i.Purpose,
+		&i.OrgID,
+	)
+	return i, err
+}
+
+const getSystemAccount = `-- name: GetSystemAccount :one
+SELECT id, owner, balance, currency, created_at, closed_at, status, type, purpose, org_id FROM accounts
+WHERE owner = 'bank' AND type = 'system' AND currency = $1 AND purpose = $2
+LIMIT 1
+`
+
+type GetSystemAccountParams struct {
+	Currency string `json:"currency"`
+	Purpose  string `json:"purpose"`
+}
+
+func (q *Queries) GetSystemAccount(ctx context.Context, arg GetSystemAccountParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getSystemAccount, arg.Currency, arg.Purpose)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.Status,
+		&i.Type,
+		&i.Purpose,
+		&i.OrgID,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
-WHERE owner = $1
+SELECT id, owner, balance, currency, created_at, closed_at, status, type, purpose, org_id FROM accounts
+WHERE (
+    $1::bigint IS NULL AND id IN (
+        SELECT account_id FROM account_members
+        WHERE username = $2 AND status = 'active'
+    )
+) OR org_id = $1
 ORDER BY id
-LIMIT $2
-OFFSET $3
+LIMIT $3
+OFFSET $4
 `
 
 type ListAccountsParams struct {
-	Owner  string `json:"owner"`
-	Limit  int32  `json:"limit"`
-	Offset int32  `json:"offset"`
+	OrgID    sql.NullInt64 `json:"org_id"`
+	Username string        `json:"username"`
+	Limit    int32         `json:"limit"`
+	Offset   int32         `json:"offset"`
 }
 
 func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
-	rows, err := q.db.QueryContext(ctx, listAccounts, arg.Owner, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listAccounts,
+		arg.OrgID,
+		arg.Username,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +260,11 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.ClosedAt,
+			&i.Status,
+			&i.Type,
+			&i.Purpose,
+			&i.OrgID,
 		); err != nil {
 			return nil, err
 		}
@@ -154,20 +279,175 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 	return items, nil
 }
 
-const updateAccount = `-- name: UpdateAccount :one
+const listAccountsAfter = `-- name: ListAccountsAfter :many
+SELECT id, owner, balance, currency, created_at, closed_at, status, type, purpose, org_id FROM accounts
+WHERE ((
+    $1::bigint IS NULL AND id IN (
+        SELECT account_id FROM account_members
+        WHERE username = $2 AND status = 'active'
+    )
+) OR org_id = $1)
+AND id > $3
+ORDER BY id
+LIMIT $4
+`
+
+type ListAccountsAfterParams struct {
+	OrgID     sql.NullInt64 `json:"org_id"`
+	Username  string        `json:"username"`
+	CursorID  int64         `json:"cursor_id"`
+	PageLimit int32         `json:"page_limit"`
+}
+
+func (q *Queries) ListAccountsAfter(ctx context.Context, arg ListAccountsAfterParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsAfter,
+		arg.OrgID,
+		arg.Username,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.ClosedAt,
+			&i.Status,
+			&i.Type,
+			&i.Purpose,
+			&i.OrgID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsBefore = `-- name: ListAccountsBefore :many
+SELECT id, owner, balance, currency, created_at, closed_at, status, type, purpose, org_id FROM accounts
+WHERE ((
+    $1::bigint IS NULL AND id IN (
+        SELECT account_id FROM account_members
+        WHERE username = $2 AND status = 'active'
+    )
+) OR org_id = $1)
+AND id < $3
+ORDER BY id DESC
+LIMIT $4
+`
+
+type ListAccountsBeforeParams struct {
+	OrgID     sql.NullInt64 `json:"org_id"`
+	Username  string        `json:"username"`
+	CursorID  int64         `json:"cursor_id"`
+	PageLimit int32         `json:"page_limit"`
+}
+
+func (q *Queries) ListAccountsBefore(ctx context.Context, arg ListAccountsBeforeParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsBefore,
+		arg.OrgID,
+		arg.Username,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.ClosedAt,
+			&i.Status,
+			&i.Type,
+			&i.Purpose,
+			&i.OrgID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const openOwnerAccount = `-- name: OpenOwnerAccount :one
+INSERT INTO accounts (
+    owner,
+    balance,
+    currency,
+    type
+) VALUES (
+    $1, 0, $2, $3
+)
+ON CONFLICT (owner, currency, type, purpose) WHERE org_id IS NULL DO NOTHING
+RETURNING id, owner, balance, currency, created_at, closed_at, status, type, purpose, org_id
+`
+
+type OpenOwnerAccountParams struct {
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+	Type     string `json:"type"`
+}
+
+func (q *Queries) OpenOwnerAccount(ctx context.Context, arg OpenOwnerAccountParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, openOwnerAccount, arg.Owner, arg.Currency, arg.Type)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.Status,
+		&i.Type,
+		&i.Purpose,
+		&i.OrgID,
+	)
+	return i, err
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
 UPDATE accounts
-SET balance = $2
-WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+SET status = $2
+WHERE id = $1 AND status <> 'closed'
+RETURNING id, owner, balance, currency, created_at, closed_at, status, type, purpose, org_id
 `
 
-type UpdateAccountParams struct {
-	ID      int64 `json:"id"`
-	Balance int64 `json:"balance"`
+type UpdateAccountStatusParams struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
 }
 
-func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, updateAccount, arg.ID, arg.Balance)
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountStatus, arg.ID, arg.Status)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -175,6 +455,11 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.Status,
+		&i.Type,
+		&i.Purpose,
+		&i.OrgID,
 	)
 	return i, err
 }
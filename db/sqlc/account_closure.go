@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrAccountClosed   = errors.New("account is closed")
+	ErrAccountNotEmpty = errors.New("account balance must be zero to close it")
+)
+
+type CloseAccountTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// SweepAccountID receives the remaining balance, zero means the balance must already be zero
+	SweepAccountID int64 `json:"sweep_account_id"`
+	// Actor is recorded in the status history
+	Actor string `json:"actor"`
+}
+
+type CloseAccountTxResult struct {
+	Account Account `json:"account"`
+	// Sweep is set when the remaining balance was moved to SweepAccountID
+	Sweep *TransferTxResult `json:"sweep,omitempty"`
+}
+
+// CloseAccountTx closes an account, moving its remaining balance to the sweep account first.
+// Closed accounts keep their rows and history, they just cannot send or receive money anymore.
+func (store *SQLStore) CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error) {
+	var result CloseAccountTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		// frozen and debit blocked accounts stay open until staff lift the block
+		if err := CheckAccountStatus(account, AccountDebit); err != nil {
+			return err
+		}
+
+		if account.Balance != 0 {
+			if arg.SweepAccountID == 0 || account.Balance < 0 {
+				return ErrAccountNotEmpty
+			}
+			if arg.SweepAccountID == account.ID {
+				return errors.New("cannot sweep an account into itself")
+			}
+
+			sweepAccount, err := q.GetAccount(ctx, arg.SweepAccountID)
+			if err != nil {
+				return err
+			}
+			if sweepAccount.Currency != account.Currency {
+				return fmt.Errorf("sweep account %d does not support currency %s", sweepAccount.ID, account.Currency)
+			}
+			if err := CheckAccountStatus(sweepAccount, AccountCredit); err != nil {
+				return err
+			}
+
+			if err := lockAccounts(ctx, q, account.ID, sweepAccount.ID); err != nil {
+				return err
+			}
+			sweep, err := transferMoney(ctx, q, TransferTxParams{
+				FromAccountID: account.ID,
+				ToAccountID:   sweepAccount.ID,
+				Amount:        account.Balance,
+				Description:   "account closure",
+			})
+			if err != nil {
+				return err
+			}
+			result.Sweep = &sweep
+		}
+
+		result.Account, err = q.CloseAccount(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateAccountStatusHistory(ctx, CreateAccountStatusHistoryParams{
+			AccountID:  account.ID,
+			FromStatus: account.Status,
+			ToStatus:   AccountStatusClosed,
+			Reason:     StatusReasonCustomerRequest,
+			Actor:      arg.Actor,
+		})
+		return err
+	})
+	return result, err
+}
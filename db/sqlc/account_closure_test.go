@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseAccountTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	account := CreateRandomAccount(t)
+	sweepAccount, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    CreateRandomUser(t).Username,
+		Currency: account.Currency,
+		Type:     AccountTypeChecking,
+	})
+	require.NoError(t, err)
+
+	// a non-empty account cannot be closed without a sweep account
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: account.ID})
+	require.ErrorIs(t, err, ErrAccountNotEmpty)
+
+	result, err := store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:      account.ID,
+		SweepAccountID: sweepAccount.ID,
+		Actor:          account.Owner,
+	})
+	require.NoError(t, err)
+	require.True(t, result.Account.ClosedAt.Valid)
+	require.Equal(t, AccountStatusClosed, result.Account.Status)
+	require.Zero(t, result.Account.Balance)
+	require.NotNil(t, result.Sweep)
+	require.Equal(t, account.Balance, result.Sweep.Transfer.Amount)
+	require.Equal(t, sweepAccount.Balance+account.Balance, result.Sweep.ToAccount.Balance)
+
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: account.ID})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	// closed accounts can neither send nor receive money
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   sweepAccount.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: sweepAccount.ID,
+		ToAccountID:   account.ID,
+		Amount:        1,
+	})
+	require.Error(t, err)
+
+	account, err = testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Zero(t, account.Balance)
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	MemberRoleOwner   = "owner"
+	MemberRoleSpender = "spender"
+	MemberRoleViewer  = "viewer"
+
+	MemberStatusInvited = "invited"
+	MemberStatusActive  = "active"
+
+	// AccountPermissionView allows reading the account, its transfers and entries
+	AccountPermissionView = "view"
+	// AccountPermissionSpend also allows sending money from the account
+	AccountPermissionSpend = "spend"
+	// AccountPermissionApprove also allows approving transfers held for a second pair of eyes
+	AccountPermissionApprove = "approve"
+	// AccountPermissionManage also allows managing members and closing the account
+	AccountPermissionManage = "manage"
+)
+
+// ErrLastAccountOwner is returned when removing a member would leave the account without an active owner
+var ErrLastAccountOwner = errors.New("an account must keep at least one active owner")
+
+// Can reports whether the member has accepted the invitation and its role grants the permission
+func (member AccountMember) Can(permission string) bool {
+	if member.Status != MemberStatusActive {
+		return false
+	}
+	switch member.Role {
+	case MemberRoleOwner:
+		return true
+	case MemberRoleSpender:
+		return permission == AccountPermissionView || permission == AccountPermissionSpend
+	case MemberRoleViewer:
+		return permission == AccountPermissionView
+	}
+	return false
+}
+
+type RemoveAccountMemberTxParams struct {
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+}
+
+// RemoveAccountMemberTx removes a member or cancels an invitation, the last active owner cannot be removed
+func (store *SQLStore) RemoveAccountMemberTx(ctx context.Context, arg RemoveAccountMemberTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		// locking the account serializes concurrent removals of its owners
+		if _, err := q.GetAccountForUpdate(ctx, arg.AccountID); err != nil {
+			return err
+		}
+
+		member, err := q.GetAccountMember(ctx, GetAccountMemberParams{
+			AccountID: arg.AccountID,
+			Username:  arg.Username,
+		})
+		if err != nil {
+			return err
+		}
+
+		if member.Role == MemberRoleOwner && member.Status == MemberStatusActive {
+			owners, err := q.CountActiveAccountOwners(ctx, arg.AccountID)
+			if err != nil {
+				return err
+			}
+			if owners <= 1 {
+				return ErrLastAccountOwner
+			}
+		}
+
+		return q.DeleteAccountMember(ctx, DeleteAccountMemberParams{
+			AccountID: arg.AccountID,
+			Username:  arg.Username,
+		})
+	})
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountOwnerMember(t *testing.T) {
+	account := CreateRandomAccount(t)
+
+	member, err := testQueries.GetAccountMember(context.Background(), GetAccountMemberParams{
+		AccountID: account.ID,
+		Username:  account.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, MemberRoleOwner, member.Role)
+	require.Equal(t, MemberStatusActive, member.Status)
+	require.True(t, member.AcceptedAt.Valid)
+	require.True(t, member.Can(AccountPermissionManage))
+}
+
+func TestInviteAccountMember(t *testing.T) {
+	account := CreateRandomAccount(t)
+	user := CreateRandomUser(t)
+
+	invited, err := testQueries.CreateAccountMember(context.Background(), CreateAccountMemberParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+		Role:      MemberRoleSpender,
+		InvitedBy: account.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, MemberStatusInvited, invited.Status)
+	require.False(t, invited.AcceptedAt.Valid)
+	require.False(t, invited.Can(AccountPermissionView))
+
+	accounts, err := testQueries.ListAccounts(context.Background(), ListAccountsParams{Username: user.Username, Limit: 5})
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+
+	accepted, err := testQueries.AcceptAccountMember(context.Background(), AcceptAccountMemberParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, MemberStatusActive, accepted.Status)
+	require.True(t, accepted.Can(AccountPermissionSpend))
+	require.False(t, accepted.Can(AccountPermissionManage))
+
+	accounts, err = testQueries.ListAccounts(context.Background(), ListAccountsParams{Username: user.Username, Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+
+	members, err := testQueries.ListAccountMembers(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+
+	_, err = testQueries.CreateAccountMember(context.Background(), CreateAccountMemberParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+		Role:      MemberRoleViewer,
+		InvitedBy: account.Owner,
+	})
+	require.Error(t, err)
+}
+
+func TestRemoveAccountMemberTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	account := CreateRandomAccount(t)
+	user := CreateRandomUser(t)
+
+	err := store.RemoveAccountMemberTx(context.Background(), RemoveAccountMemberTxParams{
+		AccountID: account.ID,
+		Username:  account.Owner,
+	})
+	require.ErrorIs(t, err, ErrLastAccountOwner)
+
+	_, err = testQueries.CreateAccountMember(context.Background(), CreateAccountMemberParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+		Role:      MemberRoleOwner,
+		InvitedBy: account.Owner,
+	})
+	require.NoError(t, err)
+
+	// an invited owner does not count until the invitation is accepted
+	err = store.RemoveAccountMemberTx(context.Background(), RemoveAccountMemberTxParams{
+		AccountID: account.ID,
+		Username:  account.Owner,
+	})
+	require.ErrorIs(t, err, ErrLastAccountOwner)
+
+	_, err = testQueries.AcceptAccountMember(context.Background(), AcceptAccountMemberParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+	})
+	require.NoError(t, err)
+
+	err = store.RemoveAccountMemberTx(context.Background(), RemoveAccountMemberTxParams{
+		AccountID: account.ID,
+		Username:  account.Owner,
+	})
+	require.NoError(t, err)
+
+	members, err := testQueries.ListAccountMembers(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	require.Equal(t, user.Username, members[0].Username)
+}
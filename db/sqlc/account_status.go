@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+const (
+	UserRoleCustomer = "customer"
+	UserRoleStaff    = "staff"
+
+	AccountStatusActive        = "active"
+	AccountStatusFrozen        = "frozen"
+	AccountStatusDebitBlocked  = "debit_blocked"
+	AccountStatusCreditBlocked = "credit_blocked"
+	AccountStatusClosed        = "closed"
+
+	AccountDebit  = "debit"
+	AccountCredit = "credit"
+
+	StatusReasonSuspectedFraud   = "suspected_fraud"
+	StatusReasonComplianceReview = "compliance_review"
+	StatusReasonCourtOrder       = "court_order"
+	StatusReasonCustomerRequest  = "customer_request"
+	StatusReasonResolved         = "resolved"
+
+	// accountStatusViolation is the SQLSTATE raised by the accounts_status_check trigger
+	accountStatusViolation = "BA001"
+)
+
+// ErrAccountStatusViolation is returned when the database refuses a balance change because of the account status
+var ErrAccountStatusViolation = errors.New("account status does not allow this balance change")
+
+// AccountStatusError is returned when the status of an account does not allow a debit or a credit
+type AccountStatusError struct {
+	AccountID int64  `json:"account_id"`
+	Status    string `json:"status"`
+	Operation string `json:"operation"`
+}
+
+func (e *AccountStatusError) Error() string {
+	return fmt.Sprintf("account %d is %s, %ss are not allowed", e.AccountID, e.Status, e.Operation)
+}
+
+func (e *AccountStatusError) Is(target error) bool {
+	return target == ErrAccountStatusViolation
+}
+
+// CheckAccountStatus fails if the account cannot be debited or credited
+func CheckAccountStatus(account Account, operation string) error {
+	switch account.Status {
+	case AccountStatusActive:
+		return nil
+	case AccountStatusClosed:
+		return ErrAccountClosed
+	case AccountStatusDebitBlocked:
+		if operation == AccountCredit {
+			return nil
+		}
+	case AccountStatusCreditBlocked:
+		if operation == AccountDebit {
+			return nil
+		}
+	}
+
+	return &AccountStatusError{
+		AccountID: account.ID,
+		Status:    account.Status,
+		Operation: operation,
+	}
+}
+
+// accountStatusErr turns the error raised by the accounts_status_check trigger into ErrAccountStatusViolation
+func accountStatusErr(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == accountStatusViolation {
+		return fmt.Errorf("%w: %s", ErrAccountStatusViolation, pqErr.Message)
+	}
+	return err
+}
+
+type UpdateAccountStatusTxParams struct {
+	AccountID int64  `json:"account_id"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+	Note      string `json:"note"`
+	Actor     string `json:"actor"`
+}
+
+type UpdateAccountStatusTxResult struct {
+	Account Account              `json:"account"`
+	History AccountStatusHistory `json:"history"`
+}
+
+// UpdateAccountStatusTx changes the status of an open account and records who changed it and why
+func (store *SQLStore) UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (UpdateAccountStatusTxResult, error) {
+	var result UpdateAccountStatusTxResult
+
+	if arg.Status == AccountStatusClosed {
+		return result, errors.New("accounts can only be closed through CloseAccountTx")
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if account.Status == AccountStatusClosed {
+			return ErrAccountClosed
+		}
+
+		result.Account, err = q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			ID:     account.ID,
+			Status: arg.Status,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.History, err = q.CreateAccountStatusHistory(ctx, CreateAccountStatusHistoryParams{
+			AccountID:  account.ID,
+			FromStatus: account.Status,
+			ToStatus:   arg.Status,
+			Reason:     arg.Reason,
+			Note:       arg.Note,
+			Actor:      arg.Actor,
+		})
+		return err
+	})
+	return result, err
+}
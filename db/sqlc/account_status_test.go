@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateAccountStatusTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	account1 := CreateRandomAccount(t)
+	account2 := CreateRandomAccount(t)
+	staff := CreateRandomUser(t)
+
+	result, err := store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account1.ID,
+		Status:    AccountStatusDebitBlocked,
+		Reason:    StatusReasonComplianceReview,
+		Note:      "pending documents",
+		Actor:     staff.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusDebitBlocked, result.Account.Status)
+	require.Equal(t, AccountStatusActive, result.History.FromStatus)
+	require.Equal(t, AccountStatusDebitBlocked, result.History.ToStatus)
+	require.Equal(t, staff.Username, result.History.Actor)
+
+	// debit blocked accounts can still receive money
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        1,
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrAccountStatusViolation)
+
+	// the trigger stops callers that skip TransferTx
+	_, err = testQueries.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID: account1.ID,
+		Amount:    -1,
+		Metadata:  json.RawMessage(`{}`),
+	})
+	require.Error(t, err)
+	require.ErrorIs(t, accountStatusErr(err), ErrAccountStatusViolation)
+
+	_, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account1.ID,
+		Status:    AccountStatusActive,
+		Reason:    StatusReasonResolved,
+		Actor:     staff.Username,
+	})
+	require.NoError(t, err)
+
+	history, err := testQueries.ListAccountStatusHistory(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, AccountStatusActive, history[1].ToStatus)
+}
+
+func TestCheckAccountStatus(t *testing.T) {
+	testCases := []struct {
+		status string
+		debit  bool
+		credit bool
+	}{
+		{AccountStatusActive, true, true},
+		{AccountStatusFrozen, false, false},
+		{AccountStatusDebitBlocked, false, true},
+		{AccountStatusCreditBlocked, true, false},
+		{AccountStatusClosed, false, false},
+	}
+
+	for _, tc := range testCases {
+		account := Account{ID: 1, Status: tc.status}
+		require.Equal(t, tc.debit, CheckAccountStatus(account, AccountDebit) == nil, tc.status)
+		require.Equal(t, tc.credit, CheckAccountStatus(account, AccountCredit) == nil, tc.status)
+	}
+
+	require.ErrorIs(t, CheckAccountStatus(Account{Status: AccountStatusClosed}, AccountDebit), ErrAccountClosed)
+	require.ErrorIs(t, CheckAccountStatus(Account{Status: AccountStatusFrozen}, AccountDebit), ErrAccountStatusViolation)
+}
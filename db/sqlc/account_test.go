@@ -4,28 +4,34 @@ import (
 	"context"
 	"database/sql"
 	"testing"
-	"time"
 
 	"github.com/lordofthemind/backendMasterGo/utils"
 	"github.com/stretchr/testify/require"
 )
 
+// CreateRandomAccount opens a checking account and funds it with a random amount
 func CreateRandomAccount(t *testing.T) Account {
+	account := CreateRandomEmptyAccount(t)
+	return FundAccount(t, account, utils.NewRandomGenerator().RandomMoney())
+}
+
+// CreateRandomEmptyAccount opens a checking account without any entries
+func CreateRandomEmptyAccount(t *testing.T) Account {
 
 	user := CreateRandomUser(t)
 
 	rg := utils.NewRandomGenerator()
 	arg := CreateAccountParams{
 		Owner:    user.Username,
-		Balance:  rg.RandomMoney(),
 		Currency: rg.RandomCurrency(),
+		Type:     AccountTypeChecking,
 	}
 	account, err := testQueries.CreateAccount(context.Background(), arg)
 	require.NoError(t, err)
 	require.NotEmpty(t, account)
 
 	require.Equal(t, arg.Owner, account.Owner)
-	require.Equal(t, arg.Balance, account.Balance)
+	require.Zero(t, account.Balance)
 	require.Equal(t, arg.Currency, account.Currency)
 	require.NotZero(t, account.ID)
 	require.NotZero(t, account.CreatedAt)
@@ -33,11 +39,55 @@ func CreateRandomAccount(t *testing.T) Account {
 	return account
 }
 
+// systemPurposeTestFunding is the system account tests fund accounts from
+const systemPurposeTestFunding = "test_funding"
+
+// FundAccount moves amount into the account with a transfer from a system account,
+// so the balance has entries behind it like any other balance
+func FundAccount(t *testing.T, account Account, amount int64) Account {
+	if amount == 0 {
+		return account
+	}
+
+	store := NewStore(testDB, TransferPolicy{}).(*SQLStore)
+	var result TransferTxResult
+	err := store.execTx(context.Background(), func(q *Queries) error {
+		funding, err := systemAccount(context.Background(), q, account.Currency, systemPurposeTestFunding)
+		if err != nil {
+			return err
+		}
+		if err := lockAccounts(context.Background(), q, funding.ID, account.ID); err != nil {
+			return err
+		}
+		result, err = transferMoney(context.Background(), q, TransferTxParams{
+			FromAccountID: funding.ID,
+			ToAccountID:   account.ID,
+			Amount:        amount,
+			Description:   "deposit",
+		})
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Balance+amount, result.ToAccount.Balance)
+
+	return result.ToAccount
+}
+
 func TestCreateAccount(t *testing.T) {
 
 	CreateRandomAccount(t)
 }
 
+func TestCreateAccountWithBalance(t *testing.T) {
+	user := CreateRandomUser(t)
+
+	// a balance without an entry behind it is refused from the start
+	_, err := testDB.ExecContext(context.Background(),
+		"INSERT INTO accounts (owner, balance, currency, type) VALUES ($1, 100, $2, $3)",
+		user.Username, utils.USD, AccountTypeChecking)
+	requireAppendOnly(t, err)
+}
+
 func TestGetAccount(t *testing.T) {
 	account1 := CreateRandomAccount(t)
 	account2, err := testQueries.GetAccount(context.Background(), account1.ID)
@@ -52,34 +102,21 @@ func TestGetAccount(t *testing.T) {
 	require.NotZero(t, account2.CreatedAt)
 }
 
-func TestUpdateAccount(t *testing.T) {
-	rg := utils.NewRandomGenerator()
+func TestAccountBalanceFollowsEntries(t *testing.T) {
 	account1 := CreateRandomAccount(t)
-	arg := UpdateAccountParams{
-		ID:      account1.ID,
-		Balance: rg.RandomMoney(),
-	}
-	account2, err := testQueries.UpdateAccount(context.Background(), arg)
+	entry := CreateRandomEntry(t, account1)
+
+	account2, err := testQueries.GetAccount(context.Background(), account1.ID)
 	require.NoError(t, err)
-	require.NotEmpty(t, account2)
+	require.Equal(t, account1.Balance+entry.Amount, account2.Balance)
 
-	// Add assertions here to validate the behavior of UpdateAccount method
-	require.Equal(t, account1.Owner, account2.Owner)
-	require.Equal(t, arg.Balance, account2.Balance)
-	require.Equal(t, account1.Currency, account2.Currency)
-	require.Equal(t, account1.Currency, account2.Currency)
-	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
-}
+	// a balance without an entry behind it is refused
+	_, err = testDB.ExecContext(context.Background(), "UPDATE accounts SET balance = balance + 1 WHERE id = $1", account1.ID)
+	requireAppendOnly(t, err)
 
-func TestDeleteAccount(t *testing.T) {
-	account1 := CreateRandomAccount(t)
-	err := testQueries.DeleteAccount(context.Background(), account1.ID)
+	account3, err := testQueries.GetAccount(context.Background(), account1.ID)
 	require.NoError(t, err)
-
-	account2, err := testQueries.GetAccount(context.Background(), account1.ID)
-	require.Error(t, err)
-	require.EqualError(t, err, sql.ErrNoRows.Error())
-	require.Empty(t, account2)
+	require.Equal(t, account2.Balance, account3.Balance)
 }
 
 func TestListAccounts(t *testing.T) {
@@ -89,9 +126,9 @@ func TestListAccounts(t *testing.T) {
 	}
 
 	arg := ListAccountsParams{
-		Owner:  lastAccount.Owner,
-		Limit:  5,
-		Offset: 0,
+		Username: lastAccount.Owner,
+		Limit:    5,
+		Offset:   0,
 	}
 
 	accounts, err := testQueries.ListAccounts(context.Background(), arg)
@@ -104,3 +141,76 @@ func TestListAccounts(t *testing.T) {
 	}
 
 }
+
+func TestListAccountsKeyset(t *testing.T) {
+	user := CreateRandomUser(t)
+
+	var created []Account
+	for _, currency := range []string{utils.USD, utils.EUR, utils.CAD} {
+		account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+			Owner:    user.Username,
+			Currency: currency,
+			Type:     AccountTypeChecking,
+		})
+		require.NoError(t, err)
+		created = append(created, account)
+	}
+
+	accounts, err := testQueries.ListAccountsAfter(context.Background(), ListAccountsAfterParams{
+		Username:  user.Username,
+		CursorID:  0,
+		PageLimit: 2,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+	require.Equal(t, created[0].ID, accounts[0].ID)
+	require.Equal(t, created[1].ID, accounts[1].ID)
+
+	accounts, err = testQueries.ListAccountsBefore(context.Background(), ListAccountsBeforeParams{
+		Username:  user.Username,
+		CursorID:  created[2].ID,
+		PageLimit: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+	require.Equal(t, created[1].ID, accounts[0].ID)
+}
+
+func TestCreateAccountTypes(t *testing.T) {
+	checking := CreateRandomAccount(t)
+
+	// one account per type and currency
+	arg := CreateAccountParams{
+		Owner:    checking.Owner,
+		Currency: checking.Currency,
+		Type:     AccountTypeSavings,
+	}
+	savings, err := testQueries.CreateAccount(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, AccountTypeSavings, savings.Type)
+
+	_, err = testQueries.CreateAccount(context.Background(), arg)
+	require.Error(t, err)
+
+	// only the bank owns system accounts
+	arg.Type = AccountTypeSystem
+	_, err = testQueries.CreateAccount(context.Background(), arg)
+	require.Error(t, err)
+}
+
+func TestGetSystemAccount(t *testing.T) {
+	arg := GetSystemAccountParams{
+		Currency: utils.NewRandomGenerator().RandomCurrency(),
+		Purpose:  SystemPurposeInterestExpense,
+	}
+
+	account, err := testQueries.GetSystemAccount(context.Background(), arg)
+	if err == sql.ErrNoRows {
+		account, err = testQueries.CreateSystemAccount(context.Background(), CreateSystemAccountParams(arg))
+	}
+	require.NoError(t, err)
+	require.Equal(t, BankUsername, account.Owner)
+	require.Equal(t, AccountTypeSystem, account.Type)
+	require.Equal(t, arg.Currency, account.Currency)
+	require.Equal(t, arg.Purpose, account.Purpose)
+}
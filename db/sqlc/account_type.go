@@ -0,0 +1,13 @@
+package db
+
+const (
+	AccountTypeChecking = "checking"
+	AccountTypeSavings  = "savings"
+	// AccountTypeSystem accounts are owned by BankUsername and hold the bank's side of the books
+	AccountTypeSystem = "system"
+
+	// BankUsername is the user that owns every system account
+	BankUsername = "bank"
+
+	UserRoleSystem = "system"
+)
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ErrAccountNotOpen is returned for the balance of an account at a time before it was opened
+var ErrAccountNotOpen = errors.New("account was not open at that time")
+
+type AccountBalance struct {
+	AccountID int64     `json:"account_id"`
+	At        time.Time `json:"at"`
+	Balance   int64     `json:"balance"`
+	// Snapshot is the end of day snapshot the balance was computed from,
+	// nil when there is none before At and the balance was recomputed from the current one
+	Snapshot *BalanceSnapshot `json:"snapshot"`
+}
+
+// AccountBalanceAt returns the balance of the account at a point in time, see BalanceAt
+func (store *SQLStore) AccountBalanceAt(ctx context.Context, accountID int64, at time.Time) (AccountBalance, error) {
+	var result AccountBalance
+	err := store.ReadTx(ctx, func(q Querier) error {
+		var err error
+		result, err = BalanceAt(ctx, q, accountID, at)
+		return err
+	})
+	return result, err
+}
+
+// BalanceAt returns the balance of the account at a point in time: the latest end of day snapshot
+// before it plus the entries written between the end of that day and at.
+// Run it in ReadTx, so the account, the snapshot and the entries agree.
+func BalanceAt(ctx context.Context, q Querier, accountID int64, at time.Time) (AccountBalance, error) {
+	result := AccountBalance{AccountID: accountID, At: at}
+
+	account, err := q.GetAccount(ctx, accountID)
+	if err != nil {
+		return result, err
+	}
+	if at.Before(account.CreatedAt) {
+		return result, ErrAccountNotOpen
+	}
+
+	snapshot, err := q.GetLatestBalanceSnapshot(ctx, GetLatestBalanceSnapshotParams{
+		AccountID: accountID,
+		Before:    startOfDay(at),
+	})
+	if err == sql.ErrNoRows {
+		result.Balance, err = RecomputeBalanceAt(ctx, q, account, at)
+		return result, err
+	}
+	if err != nil {
+		return result, err
+	}
+
+	total, err := q.SumAccountEntriesBetween(ctx, SumAccountEntriesBetweenParams{
+		AccountID: accountID,
+		StartTime: SnapshotEnd(snapshot.Day),
+		EndTime:   at,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Balance = snapshot.Balance + total
+	result.Snapshot = &snapshot
+	return result, nil
+}
+
+// RecomputeBalanceAt walks back from the current balance of the account over every entry written since at.
+// It needs no snapshot, but reads more entries the further back at is.
+func RecomputeBalanceAt(ctx context.Context, q Querier, account Account, at time.Time) (int64, error) {
+	total, err := q.SumAccountEntriesSince(ctx, SumAccountEntriesSinceParams{
+		AccountID: account.ID,
+		StartTime: at,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return account.Balance - total, nil
+}
+
+// SnapshotEnd is the end of the day of a snapshot, the entries before it are in its balance
+func SnapshotEnd(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, time.UTC)
+}
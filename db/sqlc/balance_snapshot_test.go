@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createEntryAt writes an entry with a time in the past, the application always writes them at now()
+func createEntryAt(t *testing.T, accountID int64, amount int64, createdAt time.Time) {
+	_, err := testDB.ExecContext(context.Background(),
+		"INSERT INTO entries (account_id, amount, created_at) VALUES ($1, $2, $3)", accountID, amount, createdAt)
+	require.NoError(t, err)
+}
+
+func TestAccountBalanceAt(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+	today := startOfDay(time.Now())
+
+	account := CreateRandomAccount(t)
+	_, err := testDB.ExecContext(context.Background(),
+		"UPDATE accounts SET created_at = $2 WHERE id = $1", account.ID, today.AddDate(0, 0, -10))
+	require.NoError(t, err)
+
+	createEntryAt(t, account.ID, 500, today.AddDate(0, 0, -6).Add(9*time.Hour))
+	createEntryAt(t, account.ID, -200, today.AddDate(0, 0, -5).Add(23*time.Hour))
+	createEntryAt(t, account.ID, 300, today.AddDate(0, 0, -4))
+	createEntryAt(t, account.ID, -50, today.AddDate(0, 0, -2).Add(12*time.Hour))
+
+	day := today.AddDate(0, 0, -5)
+	_, err = testQueries.CreateBalanceSnapshots(context.Background(), CreateBalanceSnapshotsParams{
+		Day:    day,
+		DayEnd: SnapshotEnd(day),
+	})
+	require.NoError(t, err)
+
+	current, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		at       time.Time
+		snapshot bool
+	}{
+		{name: "BeforeEntries", at: today.AddDate(0, 0, -9)},
+		{name: "BeforeSnapshotEnd", at: today.AddDate(0, 0, -5).Add(12 * time.Hour)},
+		{name: "AtSnapshotEnd", at: today.AddDate(0, 0, -4), snapshot: true},
+		{name: "AfterSnapshot", at: today.AddDate(0, 0, -2).Add(13 * time.Hour), snapshot: true},
+		{name: "Now", at: time.Now(), snapshot: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			balance, err := store.AccountBalanceAt(context.Background(), account.ID, tc.at)
+			require.NoError(t, err)
+			require.Equal(t, tc.snapshot, balance.Snapshot != nil)
+
+			// the snapshot and the entries after it add up to the full recomputation
+			recomputed, err := RecomputeBalanceAt(context.Background(), testQueries, current, tc.at)
+			require.NoError(t, err)
+			require.Equal(t, recomputed, balance.Balance)
+		})
+	}
+
+	balance, err := store.AccountBalanceAt(context.Background(), account.ID, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, current.Balance, balance.Balance)
+
+	_, err = store.AccountBalanceAt(context.Background(), account.ID, today.AddDate(0, 0, -11))
+	require.ErrorIs(t, err, ErrAccountNotOpen)
+}
+
+func TestCreateBalanceSnapshots(t *testing.T) {
+	today := startOfDay(time.Now())
+	day := today.AddDate(0, 0, -1)
+
+	account := CreateRandomEmptyAccount(t)
+	_, err := testDB.ExecContext(context.Background(),
+		"UPDATE accounts SET created_at = $2 WHERE id = $1", account.ID, day)
+	require.NoError(t, err)
+	createEntryAt(t, account.ID, 700, day.Add(time.Hour))
+
+	arg := CreateBalanceSnapshotsParams{Day: day, DayEnd: SnapshotEnd(day)}
+	rows, err := testQueries.CreateBalanceSnapshots(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, rows)
+
+	snapshots, err := testQueries.ListAccountBalanceSnapshots(context.Background(), ListAccountBalanceSnapshotsParams{
+		AccountID: account.ID,
+		FromDate:  day,
+		ToDate:    day,
+	})
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	require.Equal(t, account.Balance+700, snapshots[0].Balance)
+
+	// entries after the end of the day are not in the snapshot, and existing snapshots are kept
+	createEntryAt(t, account.ID, 100, today)
+	_, err = testQueries.CreateBalanceSnapshots(context.Background(), arg)
+	require.NoError(t, err)
+
+	snapshots, err = testQueries.ListAccountBalanceSnapshots(context.Background(), ListAccountBalanceSnapshotsParams{
+		AccountID: account.ID,
+		FromDate:  day,
+		ToDate:    day,
+	})
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	require.Equal(t, account.Balance+700, snapshots[0].Balance)
+}
@@ -7,37 +7,74 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 )
 
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (
     account_id,
-    amount
+    amount,
+    description,
+    reference,
+    metadata,
+    transfer_id
 ) VALUES (
-    $1, $2
+    $1, $2, $3, $4, $5, $6
 )
-RETURNING id, account_id, amount, created_at
+RETURNING id, account_id, amount, created_at, description, reference, metadata, prev_hash, hash, transfer_id
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	AccountID   int64           `json:"account_id"`
+	Amount      int64           `json:"amount"`
+	Description string          `json:"description"`
+	Reference   string          `json:"reference"`
+	Metadata    json.RawMessage `json:"metadata"`
+	TransferID  sql.NullInt64   `json:"transfer_id"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createEntry,
+		arg.AccountID,
+		arg.Amount,
+		arg.Description,
+		arg.Reference,
+		arg.Metadata,
+		arg.TransferID,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
+		&i.PrevHash,
+		&i.Hash,
+		&i.TransferID,
 	)
 	return i, err
 }
 
+const getAccountChainHead = `-- name: GetAccountChainHead :one
+SELECT hash FROM entries
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetAccountChainHead(ctx context.Context, accountID int64) ([]byte, error) {
+	row := q.db.QueryRowContext(ctx, getAccountChainHead, accountID)
+	var hash []byte
+	err := row.Scan(&hash)
+	return hash, err
+}
+
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, description, reference, metadata, prev_hash, hash, transfer_id FROM entries
 WHERE id = $1 LIMIT 1
 `
 
@@ -49,12 +86,306 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
+		&i.PrevHash,
+		&i.Hash,
+		&i.TransferID,
 	)
 	return i, err
 }
 
+const listAccountChainEntries = `-- name: ListAccountChainEntries :many
+SELECT id, account_id, amount, created_at, description, reference, metadata, prev_hash, hash, transfer_id FROM entries
+WHERE account_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListAccountChainEntries(ctx context.Context, accountID int64) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountChainEntries, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.PrevHash,
+			&i.Hash,
+			&i.TransferID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountChainHeads = `-- name: ListAccountChainHeads :many
+SELECT DISTINCT ON (account_id) account_id, id, hash FROM entries
+WHERE hash IS NOT NULL AND id <= $1
+ORDER BY account_id, id DESC
+`
+
+type ListAccountChainHeadsRow struct {
+	AccountID int64  `json:"account_id"`
+	ID        int64  `json:"id"`
+	Hash      []byte `json:"hash"`
+}
+
+func (q *Queries) ListAccountChainHeads(ctx context.Context, id int64) ([]ListAccountChainHeadsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountChainHeads, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountChainHeadsRow{}
+	for rows.Next() {
+		var i ListAccountChainHeadsRow
+		if err := rows.Scan(&i.AccountID, &i.ID, &i.Hash); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountEntries = `-- name: ListAccountEntries :many
+SELECT id, account_id, amount, created_at, description, reference, metadata, prev_hash, hash, transfer_id FROM entries
+WHERE account_id = $1
+AND (
+    $2::varchar IS NULL
+    OR ($2 = 'out' AND amount < 0)
+    OR ($2 = 'in' AND amount > 0)
+)
+AND ($3::timestamptz IS NULL OR created_at >= $3)
+AND ($4::timestamptz IS NULL OR created_at < $4)
+AND ($5::bigint IS NULL OR abs(amount) >= $5)
+AND ($6::bigint IS NULL OR abs(amount) <= $6)
+ORDER BY id
+LIMIT $7
+OFFSET $8
+`
+
+type ListAccountEntriesParams struct {
+	AccountID  int64          `json:"account_id"`
+	Direction  sql.NullString `json:"direction"`
+	StartTime  sql.NullTime   `json:"start_time"`
+	EndTime    sql.NullTime   `json:"end_time"`
+	MinAmount  sql.NullInt64  `json:"min_amount"`
+	MaxAmount  sql.NullInt64  `json:"max_amount"`
+	PageLimit  int32          `json:"page_limit"`
+	PageOffset int32          `json:"page_offset"`
+}
+
+func (q *Queries) ListAccountEntries(ctx context.Context, arg ListAccountEntriesParams) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountEntries,
+		arg.AccountID,
+		arg.Direction,
+		arg.StartTime,
+		arg.EndTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.PrevHash,
+			&i.Hash,
+			&i.TransferID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountEntriesAfter = `-- name: ListAccountEntriesAfter :many
+SELECT id, account_id, amount, created_at, description, reference, metadata, prev_hash, hash, transfer_id FROM entries
+WHERE account_id = $1
+AND id > $2
+AND (
+    $3::varchar IS NULL
+    OR ($3 = 'out' AND amount < 0)
+    OR ($3 = 'in' AND amount > 0)
+)
+AND ($4::timestamptz IS NULL OR created_at >= $4)
+AND ($5::timestamptz IS NULL OR created_at < $5)
+AND ($6::bigint IS NULL OR abs(amount) >= $6)
+AND ($7::bigint IS NULL OR abs(amount) <= $7)
+ORDER BY id
+LIMIT $8
+`
+
+type ListAccountEntriesAfterParams struct {
+	AccountID int64          `json:"account_id"`
+	CursorID  int64          `json:"cursor_id"`
+	Direction sql.NullString `json:"direction"`
+	StartTime sql.NullTime   `json:"start_time"`
+	EndTime   sql.NullTime   `json:"end_time"`
+	MinAmount sql.NullInt64  `json:"min_amount"`
+	MaxAmount sql.NullInt64  `json:"max_amount"`
+	PageLimit int32          `json:"page_limit"`
+}
+
+func (q *Queries) ListAccountEntriesAfter(ctx context.Context, arg ListAccountEntriesAfterParams) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountEntriesAfter,
+		arg.AccountID,
+		arg.CursorID,
+		arg.Direction,
+		arg.StartTime,
+		arg.EndTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.PrevHash,
+			&i.Hash,
+			&i.TransferID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountEntriesBefore = `-- name: ListAccountEntriesBefore :many
+SELECT id, account_id, amount, created_at, description, reference, metadata, prev_hash, hash, transfer_id FROM entries
+WHERE account_id = $1
+AND id < $2
+AND (
+    $3::varchar IS NULL
+    OR ($3 = 'out' AND amount < 0)
+    OR ($3 = 'in' AND amount > 0)
+)
+AND ($4::timestamptz IS NULL OR created_at >= $4)
+AND ($5::timestamptz IS NULL OR created_at < $5)
+AND ($6::bigint IS NULL OR abs(amount) >= $6)
+AND ($7::bigint IS NULL OR abs(amount) <= $7)
+ORDER BY id DESC
+LIMIT $8
+`
+
+type ListAccountEntriesBeforeParams struct {
+	AccountID int64          `json:"account_id"`
+	CursorID  int64          `json:"cursor_id"`
+	Direction sql.NullString `json:"direction"`
+	StartTime sql.NullTime   `json:"start_time"`
+	EndTime   sql.NullTime   `json:"end_time"`
+	MinAmount sql.NullInt64  `json:"min_amount"`
+	MaxAmount sql.NullInt64  `json:"max_amount"`
+	PageLimit int32          `json:"page_limit"`
+}
+
+func (q *Queries) ListAccountEntriesBefore(ctx context.Context, arg ListAccountEntriesBeforeParams) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountEntriesBefore,
+		arg.AccountID,
+		arg.CursorID,
+		arg.Direction,
+		arg.StartTime,
+		arg.EndTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.PrevHash,
+			&i.Hash,
+			&i.TransferID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, description, reference, metadata, prev_hash, hash, transfer_id FROM entries
 WHERE account_id = $1
 ORDER BY id
 LIMIT $2
@@ -81,6 +412,12 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.PrevHash,
+			&i.Hash,
+			&i.TransferID,
 		); err != nil {
 			return nil, err
 		}
@@ -94,3 +431,62 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 	}
 	return items, nil
 }
+
+const listEntryAccountIDs = `-- name: ListEntryAccountIDs :many
+SELECT DISTINCT account_id FROM entries
+ORDER BY account_id
+`
+
+func (q *Queries) ListEntryAccountIDs(ctx context.Context) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listEntryAccountIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var account_id int64
+		if err := rows.Scan(&account_id); err != nil {
+			return nil, err
+		}
+		items = append(items, account_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setEntryHash = `-- name: SetEntryHash :one
+UPDATE entries
+SET prev_hash = $2, hash = $3
+WHERE id = $1 AND hash IS NULL
+RETURNING id, account_id, amount, created_at, description, reference, metadata, prev_hash, hash, transfer_id
+`
+
+type SetEntryHashParams struct {
+	ID       int64  `json:"id"`
+	PrevHash []byte `json:"prev_hash"`
+	Hash     []byte `json:"hash"`
+}
+
+func (q *Queries) SetEntryHash(ctx context.Context, arg SetEntryHashParams) (Entry, error) {
+	row := q.db.QueryRowContext(ctx, setEntryHash, arg.ID, arg.PrevHash, arg.Hash)
+	var i Entry
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
+		&i.PrevHash,
+		&i.Hash,
+		&i.TransferID,
+	)
+	return i, err
+}
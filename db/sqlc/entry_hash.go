@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"hash"
+)
+
+const (
+	// entryHashVersion prefixes the hashed contents, so the encoding can change without ambiguity
+	entryHashVersion = "entry:v1"
+
+	// ledgerAppendOnlyViolation is the SQLSTATE raised when a write would rewrite entries, transfers or balances
+	ledgerAppendOnlyViolation = "BA002"
+)
+
+// EntryHash is the SHA-256 of the entry and the hash of the previous entry of its account,
+// prevHash is nil for the first entry of the chain
+func EntryHash(entry Entry, prevHash []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(entryHashVersion))
+	writeInt64(h, entry.ID)
+	writeInt64(h, entry.AccountID)
+	writeInt64(h, entry.Amount)
+	// postgres keeps microseconds
+	writeInt64(h, entry.CreatedAt.UnixMicro())
+	writeBytes(h, []byte(entry.Description))
+	writeBytes(h, []byte(entry.Reference))
+	writeBytes(h, entry.Metadata)
+	writeBytes(h, prevHash)
+	return h.Sum(nil)
+}
+
+func writeInt64(h hash.Hash, v int64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(v))
+	h.Write(b[:])
+}
+
+// writeBytes writes the length before the bytes, so adjacent fields cannot run into each other
+func writeBytes(h hash.Hash, b []byte) {
+	writeInt64(h, int64(len(b)))
+	h.Write(b)
+}
+
+// createChainedEntry writes the entry and links it to the hash chain of its account.
+// The account must be locked by the transaction, or two entries could claim the same predecessor.
+func createChainedEntry(ctx context.Context, q *Queries, arg CreateEntryParams) (Entry, error) {
+	prevHash, err := q.GetAccountChainHead(ctx, arg.AccountID)
+	if err != nil && err != sql.ErrNoRows {
+		return Entry{}, err
+	}
+
+	// the hash covers the id and created_at the database assigns
+	entry, err := q.CreateEntry(ctx, arg)
+	if err != nil {
+		return entry, err
+	}
+
+	return q.SetEntryHash(ctx, SetEntryHashParams{
+		ID:       entry.ID,
+		PrevHash: prevHash,
+		Hash:     EntryHash(entry, prevHash),
+	})
+}
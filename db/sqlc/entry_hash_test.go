@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferTxHashChain(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	account1 := CreateRandomEmptyAccount(t)
+	account2 := CreateRandomEmptyAccount(t)
+
+	// concurrent transfers in both directions must still append to each chain one at a time
+	n := 10
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		fromAccountID, toAccountID := account1.ID, account2.ID
+		if i%2 == 1 {
+			fromAccountID, toAccountID = account2.ID, account1.ID
+		}
+
+		go func() {
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: fromAccountID,
+				ToAccountID:   toAccountID,
+				Amount:        10,
+			})
+			errs <- err
+		}()
+	}
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	for _, account := range []Account{account1, account2} {
+		entries, err := testQueries.ListAccountChainEntries(context.Background(), account.ID)
+		require.NoError(t, err)
+		require.Len(t, entries, n)
+
+		var prevHash []byte
+		for _, entry := range entries {
+			require.Equal(t, prevHash, entry.PrevHash)
+			require.Equal(t, EntryHash(entry, prevHash), entry.Hash)
+			prevHash = entry.Hash
+		}
+
+		head, err := testQueries.GetAccountChainHead(context.Background(), account.ID)
+		require.NoError(t, err)
+		require.Equal(t, prevHash, head)
+	}
+}
+
+func TestEntryHash(t *testing.T) {
+	entry := Entry{
+		ID:          1,
+		AccountID:   2,
+		Amount:      -300,
+		CreatedAt:   time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Description: "rent",
+		Reference:   "march",
+		Metadata:    json.RawMessage(`{}`),
+	}
+	hash := EntryHash(entry, nil)
+	require.Len(t, hash, 32)
+	require.Equal(t, hash, EntryHash(entry, nil))
+
+	changed := entry
+	changed.Amount++
+	require.NotEqual(t, hash, EntryHash(changed, nil))
+	require.NotEqual(t, hash, EntryHash(entry, hash))
+
+	// length prefixes keep text from moving between fields
+	moved := entry
+	moved.Description, moved.Reference = "rentmarch", ""
+	require.NotEqual(t, hash, EntryHash(moved, nil))
+}
+
+func requireAppendOnly(t *testing.T, err error) {
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, pq.ErrorCode(ledgerAppendOnlyViolation), pqErr.Code)
+}
+
+func TestEntriesAreAppendOnly(t *testing.T) {
+	account := CreateRandomAccount(t)
+	entry := CreateRandomEntry(t, account)
+
+	// chaining sets the hashes once
+	hash := EntryHash(entry, nil)
+	chained, err := testQueries.SetEntryHash(context.Background(), SetEntryHashParams{ID: entry.ID, Hash: hash})
+	require.NoError(t, err)
+	require.Equal(t, hash, chained.Hash)
+
+	_, err = testQueries.SetEntryHash(context.Background(), SetEntryHashParams{ID: entry.ID, Hash: hash})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testDB.ExecContext(context.Background(), "UPDATE entries SET hash = NULL WHERE id = $1", entry.ID)
+	require.Error(t, err)
+
+	_, err = testDB.ExecContext(context.Background(), "UPDATE entries SET amount = amount + 1 WHERE id = $1", entry.ID)
+	require.Error(t, err)
+
+	_, err = testDB.ExecContext(context.Background(), "DELETE FROM entries WHERE id = $1", entry.ID)
+	require.Error(t, err)
+
+	got, err := testQueries.GetEntry(context.Background(), entry.ID)
+	require.NoError(t, err)
+	require.Equal(t, chained, got)
+}
@@ -2,17 +2,21 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"testing"
 
-	"github.com/lordofthemind/backendMaster/utils"
+	"github.com/lordofthemind/backendMasterGo/utils"
 	"github.com/stretchr/testify/require"
 )
 
 func CreateRandomEntry(t *testing.T, account Account) Entry {
 	rg := utils.NewRandomGenerator()
 	arg := CreateEntryParams{
-		AccountID: account.ID,
-		Amount:    rg.RandomMoney(),
+		AccountID:   account.ID,
+		Amount:      rg.RandomMoney(),
+		Description: rg.RandomString(12),
+		Metadata:    json.RawMessage(`{}`),
 	}
 	entry, err := testQueries.CreateEntry(context.Background(), arg)
 	require.NoError(t, err)
@@ -20,6 +24,7 @@ func CreateRandomEntry(t *testing.T, account Account) Entry {
 
 	require.Equal(t, arg.AccountID, entry.AccountID)
 	require.Equal(t, arg.Amount, entry.Amount)
+	require.Equal(t, arg.Description, entry.Description)
 	require.NotZero(t, entry.ID)
 	require.NotZero(t, entry.CreatedAt)
 
@@ -62,3 +67,71 @@ func TestListEntries(t *testing.T) {
 		require.NotEmpty(t, entry)
 	}
 }
+
+func TestListAccountEntries(t *testing.T) {
+	account := CreateRandomEmptyAccount(t)
+	for _, amount := range []int64{-30, -10, 10, 20, 50} {
+		_, err := testQueries.CreateEntry(context.Background(), CreateEntryParams{
+			AccountID: account.ID,
+			Amount:    amount,
+			Metadata:  json.RawMessage(`{}`),
+		})
+		require.NoError(t, err)
+	}
+
+	entries, err := testQueries.ListAccountEntries(context.Background(), ListAccountEntriesParams{
+		AccountID: account.ID,
+		Direction: sql.NullString{String: "out", Valid: true},
+		PageLimit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		require.Negative(t, entry.Amount)
+	}
+
+	entries, err = testQueries.ListAccountEntries(context.Background(), ListAccountEntriesParams{
+		AccountID: account.ID,
+		MinAmount: sql.NullInt64{Int64: 20, Valid: true},
+		MaxAmount: sql.NullInt64{Int64: 30, Valid: true},
+		PageLimit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	entries, err = testQueries.ListAccountEntries(context.Background(), ListAccountEntriesParams{
+		AccountID:  account.ID,
+		PageLimit:  2,
+		PageOffset: 4,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestListAccountEntriesKeyset(t *testing.T) {
+	account := CreateRandomAccount(t)
+
+	var created []Entry
+	for i := 0; i < 5; i++ {
+		created = append(created, CreateRandomEntry(t, account))
+	}
+
+	entries, err := testQueries.ListAccountEntriesAfter(context.Background(), ListAccountEntriesAfterParams{
+		AccountID: account.ID,
+		CursorID:  created[1].ID,
+		PageLimit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.Equal(t, created[2].ID, entries[0].ID)
+
+	entries, err = testQueries.ListAccountEntriesBefore(context.Background(), ListAccountEntriesBeforeParams{
+		AccountID: account.ID,
+		CursorID:  created[3].ID,
+		PageLimit: 2,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, created[2].ID, entries[0].ID)
+	require.Equal(t, created[1].ID, entries[1].ID)
+}
@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// SystemPurposeInterestExpense is the system account interest is paid from
+	SystemPurposeInterestExpense = "interest_expense"
+
+	// MicrosPerUnit is the number of accrual units in one minor currency unit
+	MicrosPerUnit = 1_000_000
+)
+
+var ErrInterestAlreadyCapitalized = errors.New("interest already capitalized for this period")
+
+type CapitalizeInterestTxParams struct {
+	AccountID   int64     `json:"account_id"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+}
+
+type CapitalizeInterestTxResult struct {
+	Capitalization InterestCapitalization `json:"capitalization"`
+	// Transfer is nil when the accrued interest is less than one minor unit
+	Transfer *TransferTxResult `json:"transfer,omitempty"`
+}
+
+// CapitalizeInterestTx pays the interest accrued by an account during the period from the
+// bank's interest expense account. Only whole minor units are paid, the remainder is carried
+// over to the next capitalization so no fraction of interest is ever lost or created.
+func (store *SQLStore) CapitalizeInterestTx(ctx context.Context, arg CapitalizeInterestTxParams) (CapitalizeInterestTxResult, error) {
+	var result CapitalizeInterestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		var carried int64
+		last, err := q.GetLastInterestCapitalization(ctx, account.ID)
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return err
+		case !last.PeriodStart.Before(arg.PeriodStart):
+			return ErrInterestAlreadyCapitalized
+		default:
+			carried = last.RemainderMicros
+		}
+
+		accrued, err := q.GetUncapitalizedInterest(ctx, GetUncapitalizedInterestParams{
+			AccountID:   account.ID,
+			PeriodStart: arg.PeriodStart,
+			PeriodEnd:   arg.PeriodEnd,
+		})
+		if err != nil {
+			return err
+		}
+
+		total := accrued + carried
+		amount := total / MicrosPerUnit
+
+		var transferID sql.NullInt64
+		if amount > 0 {
+			expense, err := systemAccount(ctx, q, account.Currency, SystemPurposeInterestExpense)
+			if err != nil {
+				return err
+			}
+
+			metadata, err := json.Marshal(map[string]string{
+				"period_start": arg.PeriodStart.Format(time.DateOnly),
+				"period_end":   arg.PeriodEnd.Format(time.DateOnly),
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := lockAccounts(ctx, q, expense.ID, account.ID); err != nil {
+				return err
+			}
+			transfer, err := transferMoney(ctx, q, TransferTxParams{
+				FromAccountID: expense.ID,
+				ToAccountID:   account.ID,
+				Amount:        amount,
+				Description:   "interest",
+				Reference:     fmt.Sprintf("interest-%s-%s", arg.PeriodStart.Format("20060102"), arg.PeriodEnd.Format("20060102")),
+				Metadata:      metadata,
+			})
+			if err != nil {
+				return err
+			}
+			result.Transfer = &transfer
+			transferID = sql.NullInt64{Int64: transfer.Transfer.ID, Valid: true}
+		}
+
+		result.Capitalization, err = q.CreateInterestCapitalization(ctx, CreateInterestCapitalizationParams{
+			AccountID:       account.ID,
+			PeriodStart:     arg.PeriodStart,
+			PeriodEnd:       arg.PeriodEnd,
+			AccruedMicros:   accrued,
+			CarriedMicros:   carried,
+			Amount:          amount,
+			RemainderMicros: total - amount*MicrosPerUnit,
+			TransferID:      transferID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.MarkInterestAccrualsCapitalized(ctx, MarkInterestAccrualsCapitalizedParams{
+			CapitalizationID: result.Capitalization.ID,
+			AccountID:        account.ID,
+			PeriodStart:      arg.PeriodStart,
+			PeriodEnd:        arg.PeriodEnd,
+		})
+	})
+	return result, err
+}
+
+// systemAccount returns the bank's system account for the currency and purpose, opening it on first use
+func systemAccount(ctx context.Context, q *Queries, currency string, purpose string) (Account, error) {
+	account, err := q.GetSystemAccount(ctx, GetSystemAccountParams{
+		Currency: currency,
+		Purpose:  purpose,
+	})
+	if err == sql.ErrNoRows {
+		return q.CreateSystemAccount(ctx, CreateSystemAccountParams{
+			Currency: currency,
+			Purpose:  purpose,
+		})
+	}
+	return account, err
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSavingsAccount(t *testing.T) Account {
+	account := CreateRandomAccount(t)
+
+	savings, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    account.Owner,
+		Currency: account.Currency,
+		Type:     AccountTypeSavings,
+	})
+	require.NoError(t, err)
+	return FundAccount(t, savings, account.Balance)
+}
+
+func TestListInterestBearingBalances(t *testing.T) {
+	account := createRandomSavingsAccount(t)
+
+	// a random day in the past keeps the product key unique across runs
+	effectiveFrom := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(utils.NewRandomGenerator().RandomInt(0, 5000)))
+	_, err := testQueries.CreateInterestRate(context.Background(), CreateInterestRateParams{
+		AccountType:   AccountTypeSavings,
+		Currency:      account.Currency,
+		AnnualRatePpm: 25000,
+		EffectiveFrom: effectiveFrom,
+	})
+	require.NoError(t, err)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	balances, err := testQueries.ListInterestBearingBalances(context.Background(), ListInterestBearingBalancesParams{
+		DayEnd: today.AddDate(0, 0, 1),
+		Day:    today,
+	})
+	require.NoError(t, err)
+
+	var found bool
+	for _, balance := range balances {
+		if balance.AccountID == account.ID {
+			found = true
+			require.Equal(t, account.Balance, balance.Balance)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestCapitalizeInterestTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+	account := createRandomSavingsAccount(t)
+
+	february := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	for i, micros := range []int64{1_000_000, 900_000, 600_000} {
+		rows, err := testQueries.UpsertInterestAccrual(context.Background(), UpsertInterestAccrualParams{
+			AccountID:     account.ID,
+			AccrualDate:   february.AddDate(0, 0, i),
+			Balance:       account.Balance,
+			AnnualRatePpm: 25000,
+			AmountMicros:  micros,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), rows)
+	}
+
+	arg := CapitalizeInterestTxParams{
+		AccountID:   account.ID,
+		PeriodStart: february,
+		PeriodEnd:   february.AddDate(0, 1, -1),
+	}
+	result, err := store.CapitalizeInterestTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(2_500_000), result.Capitalization.AccruedMicros)
+	require.Equal(t, int64(2), result.Capitalization.Amount)
+	require.Equal(t, int64(500_000), result.Capitalization.RemainderMicros)
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, account.Balance+2, result.Transfer.ToAccount.Balance)
+	require.Equal(t, AccountTypeSystem, result.Transfer.FromAccount.Type)
+	require.Equal(t, SystemPurposeInterestExpense, result.Transfer.FromAccount.Purpose)
+
+	// capitalized accruals are frozen
+	rows, err := testQueries.UpsertInterestAccrual(context.Background(), UpsertInterestAccrualParams{
+		AccountID:    account.ID,
+		AccrualDate:  february,
+		AmountMicros: 0,
+	})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	_, err = store.CapitalizeInterestTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInterestAlreadyCapitalized)
+
+	// the remainder is carried over to the next month
+	march := february.AddDate(0, 1, 0)
+	_, err = testQueries.UpsertInterestAccrual(context.Background(), UpsertInterestAccrualParams{
+		AccountID:    account.ID,
+		AccrualDate:  march,
+		Balance:      account.Balance + 2,
+		AmountMicros: 400_000,
+	})
+	require.NoError(t, err)
+
+	result, err = store.CapitalizeInterestTx(context.Background(), CapitalizeInterestTxParams{
+		AccountID:   account.ID,
+		PeriodStart: march,
+		PeriodEnd:   march.AddDate(0, 1, -1),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(500_000), result.Capitalization.CarriedMicros)
+	require.Equal(t, int64(1), result.Capitalization.Amount)
+	require.Zero(t, result.Capitalization.RemainderMicros)
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedgerChecks(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	account1 := CreateRandomAccount(t)
+	account2 := CreateRandomAccount(t)
+
+	kept, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	// an entry written without a transfer moves the balance but is not backed by one
+	stray, err := testQueries.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID: account1.ID,
+		Amount:    -5,
+		Metadata:  json.RawMessage(`{}`),
+	})
+	require.NoError(t, err)
+
+	// an entry naming a transfer it is not a leg of, and a second entry for a leg, are not backed either
+	misplaced, err := testQueries.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID:  account1.ID,
+		Amount:     10,
+		Metadata:   json.RawMessage(`{}`),
+		TransferID: sql.NullInt64{Int64: kept.Transfer.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	duplicate, err := testQueries.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID:  account2.ID,
+		Amount:     10,
+		Metadata:   json.RawMessage(`{}`),
+		TransferID: sql.NullInt64{Int64: kept.Transfer.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	err = store.ReadTx(context.Background(), func(q Querier) error {
+		orphans, err := q.ListOrphanEntries(context.Background())
+		require.NoError(t, err)
+
+		ids := make([]int64, len(orphans))
+		for i, orphan := range orphans {
+			ids[i] = orphan.ID
+		}
+		require.Contains(t, ids, stray.ID)
+		require.Contains(t, ids, misplaced.ID)
+		require.Contains(t, ids, duplicate.ID)
+		require.NotContains(t, ids, kept.FromEntry.ID)
+		require.NotContains(t, ids, kept.ToEntry.ID)
+
+		transfers, err := q.ListUnbalancedTransfers(context.Background())
+		require.NoError(t, err)
+		for _, transfer := range transfers {
+			require.NotEqual(t, kept.Transfer.ID, transfer.ID)
+		}
+
+		totals, err := q.ListCurrencyLedgerTotals(context.Background())
+		require.NoError(t, err)
+		require.NotEmpty(t, totals)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// accounts open empty and every entry moves the balance, even the stray one
+	mismatches, err := testQueries.ListAccountBalanceMismatches(context.Background())
+	require.NoError(t, err)
+	for _, mismatch := range mismatches {
+		require.NotEqual(t, account1.ID, mismatch.ID)
+		require.NotEqual(t, account2.ID, mismatch.ID)
+	}
+}
+
+func TestReadTxIsReadOnly(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	err := store.ReadTx(context.Background(), func(q Querier) error {
+		_, err := q.CreateUser(context.Background(), CreateUserParams{
+			Username:       "readonly",
+			HashedPassword: "secret",
+			FullName:       "Read Only",
+			Email:          "readonly@example.com",
+		})
+		return err
+	})
+	require.Error(t, err)
+}
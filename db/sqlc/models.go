@@ -5,32 +5,338 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 )
 
 type Account struct {
-	ID        int64     `json:"id"`
+	ID int64 `json:"id"`
+	// user who opened the account, access is granted through account_members
 	Owner     string    `json:"owner"`
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// closed accounts keep their history but cannot send or receive money
+	ClosedAt sql.NullTime `json:"closed_at"`
+	Status   string       `json:"status"`
+	// system accounts belong to the bank
+	Type string `json:"type"`
+	// what a system account is used for, empty for customer accounts
+	Purpose string `json:"purpose"`
+	// organization owning the account, its members act on its behalf
+	OrgID sql.NullInt64 `json:"org_id"`
+}
+
+type AccountMember struct {
+	ID         int64        `json:"id"`
+	AccountID  int64        `json:"account_id"`
+	Username   string       `json:"username"`
+	Role       string       `json:"role"`
+	Status     string       `json:"status"`
+	InvitedBy  string       `json:"invited_by"`
+	CreatedAt  time.Time    `json:"created_at"`
+	AcceptedAt sql.NullTime `json:"accepted_at"`
+}
+
+type AccountStatusHistory struct {
+	ID         int64  `json:"id"`
+	AccountID  int64  `json:"account_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Reason     string `json:"reason"`
+	Note       string `json:"note"`
+	// user who changed the status
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BalanceSnapshot struct {
+	AccountID int64     `json:"account_id"`
+	Day       time.Time `json:"day"`
+	// balance at the end of the day, midnight UTC
+	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Entry struct {
 	ID        int64 `json:"id"`
 	AccountID int64 `json:"account_id"`
 	// can be negative or positive
-	Amount    int64     `json:"amount"`
+	Amount      int64           `json:"amount"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Description string          `json:"description"`
+	Reference   string          `json:"reference"`
+	Metadata    json.RawMessage `json:"metadata"`
+	// hash of the previous entry of the account, NULL for the first entry of the chain
+	PrevHash []byte `json:"prev_hash"`
+	// SHA-256 of the entry and prev_hash, NULL for entries written before the chain
+	Hash []byte `json:"hash"`
+	// transfer the entry is a leg of, null for an entry no transfer accounts for
+	TransferID sql.NullInt64 `json:"transfer_id"`
+}
+
+type InterestAccrual struct {
+	ID            int64     `json:"id"`
+	AccountID     int64     `json:"account_id"`
+	AccrualDate   time.Time `json:"accrual_date"`
+	Balance       int64     `json:"balance"`
+	AnnualRatePpm int64     `json:"annual_rate_ppm"`
+	// millionths of the minor currency unit
+	AmountMicros     int64         `json:"amount_micros"`
+	CapitalizationID sql.NullInt64 `json:"capitalization_id"`
+	CreatedAt        time.Time     `json:"created_at"`
+}
+
+type InterestCapitalization struct {
+	ID            int64     `json:"id"`
+	AccountID     int64     `json:"account_id"`
+	PeriodStart   time.Time `json:"period_start"`
+	PeriodEnd     time.Time `json:"period_end"`
+	AccruedMicros int64     `json:"accrued_micros"`
+	CarriedMicros int64     `json:"carried_micros"`
+	Amount        int64     `json:"amount"`
+	// carried over to the next capitalization
+	RemainderMicros int64         `json:"remainder_micros"`
+	TransferID      sql.NullInt64 `json:"transfer_id"`
+	CreatedAt       time.Time     `json:"created_at"`
+}
+
+type InterestRate struct {
+	ID          int64  `json:"id"`
+	AccountType string `json:"account_type"`
+	Currency    string `json:"currency"`
+	// annual rate in parts per million, 4.25% is 42500
+	AnnualRatePpm int64     `json:"annual_rate_ppm"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type LedgerCheckpoint struct {
+	ID int64 `json:"id"`
+	// Merkle root of the chain heads of every account, ordered by account id
+	Root     []byte `json:"root"`
+	Accounts int64  `json:"accounts"`
+	// newest entry covered by the checkpoint
+	LastEntryID int64     `json:"last_entry_id"`
+	PublicKey   []byte    `json:"public_key"`
+	Signature   []byte    `json:"signature"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Notification struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Kind     string `json:"kind"`
+	Message  string `json:"message"`
+	// ids of the objects the notification is about
+	Data      json.RawMessage `json:"data"`
+	ReadAt    sql.NullTime    `json:"read_at"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type Organization struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedBy string    `json:"created_by"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type OrganizationMember struct {
+	ID        int64     `json:"id"`
+	OrgID     int64     `json:"org_id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	AddedBy   string    `json:"added_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Payee struct {
+	ID        int64         `json:"id"`
+	Owner     string        `json:"owner"`
+	Name      string        `json:"name"`
+	AccountID sql.NullInt64 `json:"account_id"`
+	// pays the checking account of the user in the currency of each transfer
+	Username  sql.NullString `json:"username"`
+	CreatedAt time.Time      `json:"created_at"`
+	// deleted payees are kept for the cooling-off of their account
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+type PaymentBatch struct {
+	ID        int64  `json:"id"`
+	Owner     string `json:"owner"`
+	MessageID string `json:"message_id"`
+	// ISO 20022 message and version of the file, such as pain.001.001.09
+	MessageName      string    `json:"message_name"`
+	MessageCreatedAt time.Time `json:"message_created_at"`
+	InitiatingParty  string    `json:"initiating_party"`
+	Transactions     int64     `json:"transactions"`
+	// control sum declared by the file, empty when it has none
+	ControlSum string    `json:"control_sum"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type PaymentBatchTransaction struct {
+	ID            int64  `json:"id"`
+	BatchID       int64  `json:"batch_id"`
+	PaymentInfoID string `json:"payment_info_id"`
+	InstructionID string `json:"instruction_id"`
+	EndToEndID    string `json:"end_to_end_id"`
+	// account identification as written in the file
+	DebtorAccount   string `json:"debtor_account"`
+	CreditorAccount string `json:"creditor_account"`
+	// instructed amount as written in the file
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+	// ISO 20022 status when the file was imported, a pending transaction follows its approval
+	Status string `json:"status"`
+	// ISO 20022 status reason code of a rejection
+	ReasonCode string        `json:"reason_code"`
+	Reason     string        `json:"reason"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	ApprovalID sql.NullInt64 `json:"approval_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type PaymentLink struct {
+	ID          int64  `json:"id"`
+	Owner       string `json:"owner"`
+	ToAccountID int64  `json:"to_account_id"`
+	// fixed amount of the link, NULL lets the payer choose it
+	Amount      sql.NullInt64 `json:"amount"`
+	Currency    string        `json:"currency"`
+	Description string        `json:"description"`
+	SingleUse   bool          `json:"single_use"`
+	// used once a single-use link is paid
+	Status    string       `json:"status"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type PaymentLinkPayment struct {
+	ID         int64     `json:"id"`
+	LinkID     int64     `json:"link_id"`
+	TransferID int64     `json:"transfer_id"`
+	Payer      string    `json:"payer"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type PaymentRequest struct {
+	ID        int64  `json:"id"`
+	Requester string `json:"requester"`
+	Payer     string `json:"payer"`
+	// account of the requester credited when the request is paid
+	ToAccountID int64  `json:"to_account_id"`
+	Amount      int64  `json:"amount"`
+	Currency    string `json:"currency"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	// unique, a request is paid by exactly one transfer
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+	DecidedAt  sql.NullTime  `json:"decided_at"`
+}
+
+type PayoutImport struct {
+	ID        int64     `json:"id"`
+	Owner     string    `json:"owner"`
+	RowCount  int64     `json:"row_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PayoutImportRow struct {
+	ID       int64 `json:"id"`
+	ImportID int64 `json:"import_id"`
+	// line of the row in the uploaded file, the header is line 1
+	Line          int64  `json:"line"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	Description   string `json:"description"`
+	Reference     string `json:"reference"`
+	// outcome when the file was imported, a pending row follows its approval
+	Status     string        `json:"status"`
+	Error      string        `json:"error"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	ApprovalID sql.NullInt64 `json:"approval_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type Statement struct {
+	ID          int64     `json:"id"`
+	AccountID   int64     `json:"account_id"`
+	PeriodStart time.Time `json:"period_start"`
+	// last day covered by the statement
+	PeriodEnd time.Time `json:"period_end"`
+	Format    string    `json:"format"`
+	// key of the statement document in the blob storage
+	BlobKey        string    `json:"blob_key"`
+	Size           int64     `json:"size"`
+	Sha256         []byte    `json:"sha256"`
+	OpeningBalance int64     `json:"opening_balance"`
+	ClosingBalance int64     `json:"closing_balance"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
 type Transfer struct {
 	ID            int64 `json:"id"`
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
 	// must be positive
-	Amount    int64     `json:"amount"`
-	CreatedAt time.Time `json:"created_at"`
+	Amount      int64           `json:"amount"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Description string          `json:"description"`
+	Reference   string          `json:"reference"`
+	Metadata    json.RawMessage `json:"metadata"`
+	// user who made the transfer, null for transfers made by the bank and for transfers made before it was recorded, which count against the owner of the account
+	InitiatedBy sql.NullString `json:"initiated_by"`
+}
+
+type TransferApproval struct {
+	ID            int64           `json:"id"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Description   string          `json:"description"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
+	Status        string          `json:"status"`
+	InitiatedBy   string          `json:"initiated_by"`
+	DecidedBy     sql.NullString  `json:"decided_by"`
+	TransferID    sql.NullInt64   `json:"transfer_id"`
+	ExpiresAt     time.Time       `json:"expires_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DecidedAt     sql.NullTime    `json:"decided_at"`
+}
+
+type TransferApprovalEvent struct {
+	ID         int64  `json:"id"`
+	ApprovalID int64  `json:"approval_id"`
+	Action     string `json:"action"`
+	// user who took the decision, NULL when the request expired
+	Actor     sql.NullString `json:"actor"`
+	Note      string         `json:"note"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type TransferLimit struct {
+	ID        int64          `json:"id"`
+	Scope     string         `json:"scope"`
+	AccountID sql.NullInt64  `json:"account_id"`
+	Username  sql.NullString `json:"username"`
+	Tier      sql.NullString `json:"tier"`
+	// required for amount limits, null applies count limits to every currency
+	Currency        sql.NullString `json:"currency"`
+	MaxSingleAmount sql.NullInt64  `json:"max_single_amount"`
+	DailyAmount     sql.NullInt64  `json:"daily_amount"`
+	MonthlyAmount   sql.NullInt64  `json:"monthly_amount"`
+	DailyCount      sql.NullInt64  `json:"daily_count"`
+	MonthlyCount    sql.NullInt64  `json:"monthly_count"`
+	CreatedAt       time.Time      `json:"created_at"`
+	AccountType     sql.NullString `json:"account_type"`
 }
 
 type User struct {
@@ -40,4 +346,6 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	Tier              string    `json:"tier"`
+	Role              string    `json:"role"`
 }
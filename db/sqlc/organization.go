@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	OrgRoleAdmin    = "admin"
+	OrgRoleApprover = "approver"
+	OrgRoleViewer   = "viewer"
+)
+
+// ErrLastOrganizationAdmin is returned when removing a member would leave the organization without an admin
+var ErrLastOrganizationAdmin = errors.New("an organization must keep at least one admin")
+
+// Can reports whether the role grants the permission on the accounts of the organization,
+// admins manage them, approvers send money and approve held transfers and viewers only read
+func (member OrganizationMember) Can(permission string) bool {
+	switch member.Role {
+	case OrgRoleAdmin:
+		return true
+	case OrgRoleApprover:
+		return permission == AccountPermissionView || permission == AccountPermissionSpend ||
+			permission == AccountPermissionApprove
+	case OrgRoleViewer:
+		return permission == AccountPermissionView
+	}
+	return false
+}
+
+type CreateOrganizationTxParams struct {
+	Name      string `json:"name"`
+	CreatedBy string `json:"created_by"`
+}
+
+type CreateOrganizationTxResult struct {
+	Organization Organization       `json:"organization"`
+	Member       OrganizationMember `json:"member"`
+}
+
+// CreateOrganizationTx creates an organization with its creator as the first admin
+func (store *SQLStore) CreateOrganizationTx(ctx context.Context, arg CreateOrganizationTxParams) (CreateOrganizationTxResult, error) {
+	var result CreateOrganizationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Organization, err = q.CreateOrganization(ctx, CreateOrganizationParams{
+			Name:      arg.Name,
+			CreatedBy: arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Member, err = q.CreateOrganizationMember(ctx, CreateOrganizationMemberParams{
+			OrgID:    result.Organization.ID,
+			Username: arg.CreatedBy,
+			Role:     OrgRoleAdmin,
+			AddedBy:  arg.CreatedBy,
+		})
+		return err
+	})
+	return result, err
+}
+
+type RemoveOrganizationMemberTxParams struct {
+	OrgID    int64  `json:"org_id"`
+	Username string `json:"username"`
+}
+
+// RemoveOrganizationMemberTx removes a member, the last admin cannot be removed
+func (store *SQLStore) RemoveOrganizationMemberTx(ctx context.Context, arg RemoveOrganizationMemberTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		// locking the organization serializes concurrent removals of its admins
+		if _, err := q.GetOrganizationForUpdate(ctx, arg.OrgID); err != nil {
+			return err
+		}
+
+		member, err := q.GetOrganizationMember(ctx, GetOrganizationMemberParams{
+			OrgID:    arg.OrgID,
+			Username: arg.Username,
+		})
+		if err != nil {
+			return err
+		}
+
+		if member.Role == OrgRoleAdmin {
+			admins, err := q.CountOrganizationAdmins(ctx, arg.OrgID)
+			if err != nil {
+				return err
+			}
+			if admins <= 1 {
+				return ErrLastOrganizationAdmin
+			}
+		}
+
+		return q.DeleteOrganizationMember(ctx, DeleteOrganizationMemberParams{
+			OrgID:    arg.OrgID,
+			Username: arg.Username,
+		})
+	})
+}
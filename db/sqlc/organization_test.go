@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomOrganization(t *testing.T) CreateOrganizationTxResult {
+	store := NewStore(testDB, TransferPolicy{})
+	user := CreateRandomUser(t)
+
+	result, err := store.CreateOrganizationTx(context.Background(), CreateOrganizationTxParams{
+		Name:      utils.NewRandomGenerator().RandomString(8),
+		CreatedBy: user.Username,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, result.Organization.ID)
+	require.Equal(t, user.Username, result.Organization.CreatedBy)
+	require.Equal(t, result.Organization.ID, result.Member.OrgID)
+	require.Equal(t, OrgRoleAdmin, result.Member.Role)
+	return result
+}
+
+func TestCreateOrganizationTx(t *testing.T) {
+	result := createRandomOrganization(t)
+
+	organizations, err := testQueries.ListUserOrganizations(context.Background(), result.Member.Username)
+	require.NoError(t, err)
+	require.Len(t, organizations, 1)
+	require.Equal(t, result.Organization.ID, organizations[0].ID)
+}
+
+func TestOrganizationAccounts(t *testing.T) {
+	result := createRandomOrganization(t)
+	admin := result.Member.Username
+	orgID := sql.NullInt64{Int64: result.Organization.ID, Valid: true}
+
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    admin,
+		Currency: utils.USD,
+		Type:     AccountTypeChecking,
+		OrgID:    orgID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, orgID, account.OrgID)
+
+	// organization accounts are shared through the organization, not account members
+	_, err = testQueries.GetAccountMember(context.Background(), GetAccountMemberParams{
+		AccountID: account.ID,
+		Username:  admin,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// the admin can still open a personal account in the same currency
+	personal, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    admin,
+		Currency: utils.USD,
+		Type:     AccountTypeChecking,
+	})
+	require.NoError(t, err)
+
+	accounts, err := testQueries.ListAccounts(context.Background(), ListAccountsParams{OrgID: orgID, Username: admin, Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+
+	accounts, err = testQueries.ListAccounts(context.Background(), ListAccountsParams{Username: admin, Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, personal.ID, accounts[0].ID)
+}
+
+func TestRemoveOrganizationMemberTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	result := createRandomOrganization(t)
+	admin := result.Member.Username
+	user := CreateRandomUser(t)
+
+	err := store.RemoveOrganizationMemberTx(context.Background(), RemoveOrganizationMemberTxParams{
+		OrgID:    result.Organization.ID,
+		Username: admin,
+	})
+	require.ErrorIs(t, err, ErrLastOrganizationAdmin)
+
+	member, err := testQueries.CreateOrganizationMember(context.Background(), CreateOrganizationMemberParams{
+		OrgID:    result.Organization.ID,
+		Username: user.Username,
+		Role:     OrgRoleApprover,
+		AddedBy:  admin,
+	})
+	require.NoError(t, err)
+	require.True(t, member.Can(AccountPermissionSpend))
+	require.False(t, member.Can(AccountPermissionManage))
+
+	err = store.RemoveOrganizationMemberTx(context.Background(), RemoveOrganizationMemberTxParams{
+		OrgID:    result.Organization.ID,
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+
+	members, err := testQueries.ListOrganizationMembers(context.Background(), result.Organization.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	require.Equal(t, admin, members[0].Username)
+}
@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayees(t *testing.T) {
+	user := CreateRandomUser(t)
+	friend := CreateRandomUser(t)
+	account := CreateRandomAccount(t)
+
+	accountPayee, err := testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:     user.Username,
+		Name:      "Landlord",
+		AccountID: sql.NullInt64{Int64: account.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.NotZero(t, accountPayee.CreatedAt)
+
+	userPayee, err := testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:    user.Username,
+		Name:     "Friend",
+		Username: sql.NullString{String: friend.Username, Valid: true},
+	})
+	require.NoError(t, err)
+
+	// a payee points to either an account or a user
+	_, err = testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:     user.Username,
+		Name:      "Both",
+		AccountID: sql.NullInt64{Int64: account.ID, Valid: true},
+		Username:  sql.NullString{String: friend.Username, Valid: true},
+	})
+	require.Error(t, err)
+
+	// the same target cannot be saved twice
+	_, err = testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:    user.Username,
+		Name:     "Friend again",
+		Username: sql.NullString{String: friend.Username, Valid: true},
+	})
+	require.Error(t, err)
+
+	payees, err := testQueries.ListPayees(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, payees, 2)
+	require.Equal(t, userPayee.ID, payees[0].ID)
+	require.Equal(t, accountPayee.ID, payees[1].ID)
+
+	_, err = testQueries.DeletePayee(context.Background(), DeletePayeeParams{ID: userPayee.ID, Owner: friend.Username})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.DeletePayee(context.Background(), DeletePayeeParams{ID: userPayee.ID, Owner: user.Username})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetPayee(context.Background(), userPayee.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// a deleted payee is kept for its cooling-off and the target can be saved again
+	newPayees, err := testQueries.ListNewPayees(context.Background(), ListNewPayeesParams{
+		Owner:        user.Username,
+		CreatedAfter: accountPayee.CreatedAt.Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	require.Len(t, newPayees, 2)
+	require.True(t, newPayees[1].DeletedAt.Valid)
+
+	_, err = testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:    user.Username,
+		Name:     "Friend again",
+		Username: sql.NullString{String: friend.Username, Valid: true},
+	})
+	require.NoError(t, err)
+
+	payees, err = testQueries.ListPayees(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, payees, 2)
+}
+
+func TestPayeeCoolingOff(t *testing.T) {
+	account := CreateRandomAccount(t)
+	account = FundAccount(t, account, 100000)
+	recipient, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    CreateRandomUser(t).Username,
+		Currency: account.Currency,
+		Type:     AccountTypeChecking,
+	})
+	require.NoError(t, err)
+
+	limit, err := utils.NewMoney(10000, account.Currency)
+	require.NoError(t, err)
+	policy := TransferPolicy{
+		PayeeCoolingOff:    time.Hour,
+		PayeeCoolingLimits: map[string]utils.Money{account.Currency: limit},
+	}
+	store := NewStore(testDB, policy)
+
+	transfer := func(store Store, amount int64) error {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account.ID,
+			ToAccountID:   recipient.ID,
+			Amount:        amount,
+			InitiatedBy:   account.Owner,
+		})
+		return err
+	}
+
+	// without a payee nothing is capped
+	require.NoError(t, transfer(store, 10001))
+
+	payee, err := testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:    account.Owner,
+		Name:     "Recipient",
+		Username: sql.NullString{String: recipient.Owner, Valid: true},
+	})
+	require.NoError(t, err)
+
+	var coolingOffErr *PayeeCoolingOffError
+	err = transfer(store, 10001)
+	require.ErrorAs(t, err, &coolingOffErr)
+	require.Equal(t, payee.ID, coolingOffErr.PayeeID)
+	require.WithinDuration(t, payee.CreatedAt.Add(time.Hour), coolingOffErr.Until, time.Second)
+	require.NoError(t, transfer(store, 10000))
+
+	// held transfers and dry runs are refused the same way
+	_, err = store.CreateTransferApprovalTx(context.Background(), CreateTransferApprovalTxParams{
+		TransferTxParams: TransferTxParams{
+			FromAccountID: account.ID,
+			ToAccountID:   recipient.ID,
+			Amount:        10001,
+			InitiatedBy:   account.Owner,
+		},
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.ErrorAs(t, err, &coolingOffErr)
+	err = store.CheckPayeeCoolingOff(context.Background(), account.Owner, recipient, 10001)
+	require.ErrorAs(t, err, &coolingOffErr)
+
+	// deleting the payee does not end its cooling-off
+	_, err = testQueries.DeletePayee(context.Background(), DeletePayeeParams{ID: payee.ID, Owner: account.Owner})
+	require.NoError(t, err)
+	require.ErrorAs(t, transfer(store, 10001), &coolingOffErr)
+
+	// once the cooling-off is over the payee no longer caps transfers
+	policy.PayeeCoolingOff = time.Nanosecond
+	require.NoError(t, transfer(NewStore(testDB, policy), 10001))
+}
+
+func TestGetOwnerAccount(t *testing.T) {
+	account := CreateRandomAccount(t)
+
+	got, err := testQueries.GetOwnerAccount(context.Background(), GetOwnerAccountParams{
+		Owner:    account.Owner,
+		Currency: account.Currency,
+		Type:     AccountTypeChecking,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.ID, got.ID)
+
+	_, err = testQueries.GetOwnerAccount(context.Background(), GetOwnerAccountParams{
+		Owner:    account.Owner,
+		Currency: account.Currency,
+		Type:     AccountTypeSavings,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+type PayNewAccountTxParams struct {
+	// Owner is paid on their checking account in Currency, it is opened when they have none
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+	// Transfer is executed to the account, or held for approval when Hold is set
+	Transfer TransferTxParams                `json:"transfer"`
+	Hold     *CreateTransferApprovalTxParams `json:"hold,omitempty"`
+}
+
+type PayNewAccountTxResult struct {
+	Account  Account           `json:"account"`
+	Transfer *TransferTxResult `json:"transfer,omitempty"`
+	Approval *TransferApproval `json:"approval,omitempty"`
+}
+
+// PayNewAccountTx pays a user who may have no account in the currency yet. The account is opened in
+// the same transaction as the transfer or its approval, so a payment that fails leaves no account behind.
+func (store *SQLStore) PayNewAccountTx(ctx context.Context, arg PayNewAccountTxParams) (PayNewAccountTxResult, error) {
+	var result PayNewAccountTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Account, err = q.OpenOwnerAccount(ctx, OpenOwnerAccountParams{
+			Owner:    arg.Owner,
+			Currency: arg.Currency,
+			Type:     AccountTypeChecking,
+		})
+		// the owner or a concurrent payment opened it first
+		if err == sql.ErrNoRows {
+			result.Account, err = q.GetOwnerAccount(ctx, GetOwnerAccountParams{
+				Owner:    arg.Owner,
+				Currency: arg.Currency,
+				Type:     AccountTypeChecking,
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		transfer := arg.Transfer
+		transfer.ToAccountID = result.Account.ID
+
+		if arg.Hold != nil {
+			hold := *arg.Hold
+			hold.TransferTxParams = transfer
+
+			approval, err := store.requestTransferApproval(ctx, q, hold)
+			if err != nil {
+				return err
+			}
+			result.Approval = &approval
+			return nil
+		}
+
+		executed, err := store.executeTransfer(ctx, q, transfer)
+		if err != nil {
+			return err
+		}
+		result.Transfer = &executed
+		return nil
+	})
+	return result, err
+}
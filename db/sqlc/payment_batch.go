@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Statuses of an imported payment batch transaction, the ISO 20022 transaction status codes
+const (
+	PaymentBatchStatusSettled  = "ACSC"
+	PaymentBatchStatusPending  = "PDNG"
+	PaymentBatchStatusRejected = "RJCT"
+)
+
+type ExecutePaymentBatchTransactionTxParams struct {
+	// Transaction is recorded with the status and the id of the transfer or the approval
+	Transaction CreatePaymentBatchTransactionParams `json:"transaction"`
+	// Transfer is executed, or held for approval when Hold is set
+	Transfer TransferTxParams                `json:"transfer"`
+	Hold     *CreateTransferApprovalTxParams `json:"hold,omitempty"`
+}
+
+type ExecutePaymentBatchTransactionTxResult struct {
+	Transaction PaymentBatchTransaction `json:"transaction"`
+	Transfer    *TransferTxResult       `json:"transfer,omitempty"`
+	Approval    *TransferApproval       `json:"approval,omitempty"`
+}
+
+// ExecutePaymentBatchTransactionTx executes a transaction of an imported payment batch as in TransferTx,
+// or holds it for approval, and records it as settled or pending. The transfer and the record are
+// committed together, so a batch never moves money it does not report. When the transfer fails nothing
+// is recorded, the caller records the rejection.
+func (store *SQLStore) ExecutePaymentBatchTransactionTx(ctx context.Context, arg ExecutePaymentBatchTransactionTxParams) (ExecutePaymentBatchTransactionTxResult, error) {
+	var result ExecutePaymentBatchTransactionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		transaction := arg.Transaction
+		transaction.ReasonCode = ""
+		transaction.Reason = ""
+
+		if arg.Hold != nil {
+			hold := *arg.Hold
+			hold.TransferTxParams = arg.Transfer
+
+			approval, err := store.requestTransferApproval(ctx, q, hold)
+			if err != nil {
+				return err
+			}
+			result.Approval = &approval
+			transaction.Status = PaymentBatchStatusPending
+			transaction.ApprovalID = sql.NullInt64{Int64: approval.ID, Valid: true}
+		} else {
+			transfer, err := store.executeTransfer(ctx, q, arg.Transfer)
+			if err != nil {
+				return err
+			}
+			result.Transfer = &transfer
+			transaction.Status = PaymentBatchStatusSettled
+			transaction.TransferID = sql.NullInt64{Int64: transfer.Transfer.ID, Valid: true}
+		}
+
+		var err error
+		result.Transaction, err = q.CreatePaymentBatchTransaction(ctx, transaction)
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPaymentBatch(t *testing.T) PaymentBatch {
+	user := CreateRandomUser(t)
+	rg := utils.NewRandomGenerator()
+
+	arg := CreatePaymentBatchParams{
+		Owner:            user.Username,
+		MessageID:        rg.RandomString(12),
+		MessageName:      "pain.001.001.09",
+		MessageCreatedAt: time.Now().UTC().Truncate(time.Second),
+		InitiatingParty:  user.FullName,
+		Transactions:     2,
+		ControlSum:       "0.20",
+	}
+
+	batch, err := testQueries.CreatePaymentBatch(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, batch.ID)
+	require.Equal(t, arg.Owner, batch.Owner)
+	require.Equal(t, arg.MessageID, batch.MessageID)
+	require.Equal(t, arg.ControlSum, batch.ControlSum)
+	require.NotZero(t, batch.CreatedAt)
+	return batch
+}
+
+func TestCreatePaymentBatchDuplicateMessage(t *testing.T) {
+	batch := createRandomPaymentBatch(t)
+
+	_, err := testQueries.CreatePaymentBatch(context.Background(), CreatePaymentBatchParams{
+		Owner:            batch.Owner,
+		MessageID:        batch.MessageID,
+		MessageName:      batch.MessageName,
+		MessageCreatedAt: batch.MessageCreatedAt,
+		Transactions:     1,
+		ControlSum:       "0.10",
+	})
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, "unique_violation", pqErr.Code.Name())
+}
+
+func TestExecutePaymentBatchTransactionTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+	batch := createRandomPaymentBatch(t)
+	account1 := CreateRandomAccount(t)
+	account2 := CreateRandomAccount(t)
+
+	transaction := func(endToEndID string) CreatePaymentBatchTransactionParams {
+		return CreatePaymentBatchTransactionParams{
+			BatchID:         batch.ID,
+			PaymentInfoID:   "P-1",
+			EndToEndID:      endToEndID,
+			DebtorAccount:   strconv.FormatInt(account1.ID, 10),
+			CreditorAccount: strconv.FormatInt(account2.ID, 10),
+			Amount:          "0.10",
+			Currency:        account1.Currency,
+		}
+	}
+	transfer := TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Description:   "salary",
+		InitiatedBy:   batch.Owner,
+	}
+
+	settled, err := store.ExecutePaymentBatchTransactionTx(context.Background(), ExecutePaymentBatchTransactionTxParams{
+		Transaction: transaction("E2E-1"),
+		Transfer:    transfer,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, settled.Transfer)
+	require.Nil(t, settled.Approval)
+	require.Equal(t, PaymentBatchStatusSettled, settled.Transaction.Status)
+	require.Equal(t, settled.Transfer.Transfer.ID, settled.Transaction.TransferID.Int64)
+	require.Equal(t, account1.Balance-10, settled.Transfer.FromAccount.Balance)
+
+	held, err := store.ExecutePaymentBatchTransactionTx(context.Background(), ExecutePaymentBatchTransactionTxParams{
+		Transaction: transaction("E2E-2"),
+		Transfer:    transfer,
+		Hold:        &CreateTransferApprovalTxParams{ExpiresAt: time.Now().Add(time.Hour)},
+	})
+	require.NoError(t, err)
+	require.Nil(t, held.Transfer)
+	require.NotNil(t, held.Approval)
+	require.Equal(t, PaymentBatchStatusPending, held.Transaction.Status)
+	require.Equal(t, held.Approval.ID, held.Transaction.ApprovalID.Int64)
+	require.False(t, held.Transaction.TransferID.Valid)
+
+	transactions, err := testQueries.ListPaymentBatchTransactions(context.Background(), batch.ID)
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+	require.Equal(t, "E2E-1", transactions[0].EndToEndID)
+	require.Equal(t, "E2E-2", transactions[1].EndToEndID)
+	require.Equal(t, ApprovalStatusPending, transactions[1].ApprovalStatus)
+	require.Equal(t, sql.NullInt64{}, transactions[1].ApprovalTransferID)
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	PaymentLinkStatusActive   = "active"
+	PaymentLinkStatusUsed     = "used"
+	PaymentLinkStatusDisabled = "disabled"
+)
+
+var (
+	// ErrPaymentLinkInactive is returned when paying a link that was disabled or already used
+	ErrPaymentLinkInactive = errors.New("payment link is no longer active")
+	// ErrPaymentLinkExpired is returned when paying a link after its expiry
+	ErrPaymentLinkExpired = errors.New("payment link has expired")
+	// ErrPaymentLinkAmount is returned when the amount paid differs from the fixed amount of the link
+	ErrPaymentLinkAmount = errors.New("amount differs from the amount of the payment link")
+)
+
+type PayPaymentLinkTxParams struct {
+	LinkID        int64  `json:"link_id"`
+	Payer         string `json:"payer"`
+	FromAccountID int64  `json:"from_account_id"`
+	Amount        int64  `json:"amount"`
+}
+
+type PayPaymentLinkTxResult struct {
+	Link     PaymentLink        `json:"link"`
+	Payment  PaymentLinkPayment `json:"payment"`
+	Transfer TransferTxResult   `json:"transfer"`
+}
+
+// PayPaymentLinkTx pays an active link from the account of the payer. The link is locked for the transfer,
+// so a single-use link is marked used by the one payment that goes through.
+func (store *SQLStore) PayPaymentLinkTx(ctx context.Context, arg PayPaymentLinkTxParams) (PayPaymentLinkTxResult, error) {
+	var result PayPaymentLinkTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		link, err := q.GetPaymentLinkForUpdate(ctx, arg.LinkID)
+		if err != nil {
+			return err
+		}
+		if link.Status != PaymentLinkStatusActive {
+			return ErrPaymentLinkInactive
+		}
+		if link.ExpiresAt.Valid && !link.ExpiresAt.Time.After(time.Now()) {
+			return ErrPaymentLinkExpired
+		}
+		if link.Amount.Valid && link.Amount.Int64 != arg.Amount {
+			return ErrPaymentLinkAmount
+		}
+
+		result.Transfer, err = store.executeTransfer(ctx, q, TransferTxParams{
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   link.ToAccountID,
+			Amount:        arg.Amount,
+			Description:   link.Description,
+			Reference:     paymentLinkReference(link.ID),
+			InitiatedBy:   arg.Payer,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Payment, err = q.CreatePaymentLinkPayment(ctx, CreatePaymentLinkPaymentParams{
+			LinkID:     link.ID,
+			TransferID: result.Transfer.Transfer.ID,
+			Payer:      arg.Payer,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Link = link
+		if link.SingleUse {
+			result.Link, err = q.UpdatePaymentLinkStatus(ctx, UpdatePaymentLinkStatusParams{
+				ID:     link.ID,
+				Status: PaymentLinkStatusUsed,
+			})
+		}
+		return err
+	})
+	return result, err
+}
+
+// paymentLinkReference is the reference of the transfers paying the link
+func paymentLinkReference(linkID int64) string {
+	return "payment-link:" + strconv.FormatInt(linkID, 10)
+}
@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPaymentLink(t *testing.T, amount sql.NullInt64, singleUse bool) (PaymentLink, Account) {
+	toAccount := CreateRandomAccount(t)
+
+	link, err := testQueries.CreatePaymentLink(context.Background(), CreatePaymentLinkParams{
+		Owner:       toAccount.Owner,
+		ToAccountID: toAccount.ID,
+		Amount:      amount,
+		Currency:    toAccount.Currency,
+		Description: "coffee beans",
+		SingleUse:   singleUse,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PaymentLinkStatusActive, link.Status)
+	return link, toAccount
+}
+
+func TestPaySingleUsePaymentLinkTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	link, toAccount := createRandomPaymentLink(t, sql.NullInt64{Int64: 10, Valid: true}, true)
+	fromAccount := CreateRandomAccount(t)
+
+	arg := PayPaymentLinkTxParams{
+		LinkID:        link.ID,
+		Payer:         fromAccount.Owner,
+		FromAccountID: fromAccount.ID,
+		Amount:        10,
+	}
+
+	_, err := store.PayPaymentLinkTx(context.Background(), PayPaymentLinkTxParams{
+		LinkID:        link.ID,
+		Payer:         fromAccount.Owner,
+		FromAccountID: fromAccount.ID,
+		Amount:        11,
+	})
+	require.ErrorIs(t, err, ErrPaymentLinkAmount)
+
+	// concurrent payments go through exactly once
+	n := 3
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.PayPaymentLinkTx(context.Background(), arg)
+			errs <- err
+		}()
+	}
+
+	paid := 0
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err != nil {
+			require.ErrorIs(t, err, ErrPaymentLinkInactive)
+			continue
+		}
+		paid++
+	}
+	require.Equal(t, 1, paid)
+
+	link, err = testQueries.GetPaymentLink(context.Background(), link.ID)
+	require.NoError(t, err)
+	require.Equal(t, PaymentLinkStatusUsed, link.Status)
+
+	payments, err := testQueries.ListPaymentLinkPayments(context.Background(), link.ID)
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+	require.Equal(t, fromAccount.Owner, payments[0].Payer)
+
+	account, err := testQueries.GetAccount(context.Background(), toAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, toAccount.Balance+10, account.Balance)
+}
+
+func TestPayReusablePaymentLinkTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	link, _ := createRandomPaymentLink(t, sql.NullInt64{}, false)
+	fromAccount := CreateRandomAccount(t)
+
+	for _, amount := range []int64{3, 7} {
+		result, err := store.PayPaymentLinkTx(context.Background(), PayPaymentLinkTxParams{
+			LinkID:        link.ID,
+			Payer:         fromAccount.Owner,
+			FromAccountID: fromAccount.ID,
+			Amount:        amount,
+		})
+		require.NoError(t, err)
+		require.Equal(t, PaymentLinkStatusActive, result.Link.Status)
+		require.Equal(t, amount, result.Transfer.Transfer.Amount)
+		require.Equal(t, result.Transfer.Transfer.ID, result.Payment.TransferID)
+	}
+
+	_, err := testQueries.UpdatePaymentLinkStatus(context.Background(), UpdatePaymentLinkStatusParams{
+		ID:     link.ID,
+		Status: PaymentLinkStatusDisabled,
+	})
+	require.NoError(t, err)
+
+	_, err = store.PayPaymentLinkTx(context.Background(), PayPaymentLinkTxParams{
+		LinkID:        link.ID,
+		Payer:         fromAccount.Owner,
+		FromAccountID: fromAccount.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrPaymentLinkInactive)
+}
+
+func TestPayExpiredPaymentLinkTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+	toAccount := CreateRandomAccount(t)
+	fromAccount := CreateRandomAccount(t)
+
+	link, err := testQueries.CreatePaymentLink(context.Background(), CreatePaymentLinkParams{
+		Owner:       toAccount.Owner,
+		ToAccountID: toAccount.ID,
+		Currency:    toAccount.Currency,
+		ExpiresAt:   sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = store.PayPaymentLinkTx(context.Background(), PayPaymentLinkTxParams{
+		LinkID:        link.ID,
+		Payer:         fromAccount.Owner,
+		FromAccountID: fromAccount.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrPaymentLinkExpired)
+}
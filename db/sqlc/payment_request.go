@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	PaymentRequestStatusPending   = "pending"
+	PaymentRequestStatusPaid      = "paid"
+	PaymentRequestStatusDeclined  = "declined"
+	PaymentRequestStatusCancelled = "cancelled"
+	PaymentRequestStatusExpired   = "expired"
+)
+
+var (
+	// ErrPaymentRequestNotPending is returned when answering a payment request that was already paid, declined, cancelled or expired
+	ErrPaymentRequestNotPending = errors.New("payment request is not pending")
+	// ErrPaymentRequestExpired is returned when answering a payment request after its expiry
+	ErrPaymentRequestExpired = errors.New("payment request has expired")
+	// ErrPaymentRequestPayer is returned when someone other than the payer tries to pay a request
+	ErrPaymentRequestPayer = errors.New("payment request is addressed to another user")
+)
+
+type PayPaymentRequestTxParams struct {
+	RequestID     int64  `json:"request_id"`
+	Payer         string `json:"payer"`
+	FromAccountID int64  `json:"from_account_id"`
+}
+
+type PayPaymentRequestTxResult struct {
+	Request  PaymentRequest   `json:"request"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// PayPaymentRequestTx pays a pending request from the account of the payer. The transfer runs as in TransferTx,
+// in the transaction that locks the request and marks it paid, so a request is never paid twice.
+// A request past its expiry is marked expired and ErrPaymentRequestExpired is returned.
+func (store *SQLStore) PayPaymentRequestTx(ctx context.Context, arg PayPaymentRequestTxParams) (PayPaymentRequestTxResult, error) {
+	var result PayPaymentRequestTxResult
+	expired := false
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		request, err := q.GetPaymentRequestForUpdate(ctx, arg.RequestID)
+		if err != nil {
+			return err
+		}
+		if request.Payer != arg.Payer {
+			return ErrPaymentRequestPayer
+		}
+		if request.Status != PaymentRequestStatusPending {
+			return ErrPaymentRequestNotPending
+		}
+
+		// the expiry is committed so the request shows as expired, the caller still gets an error
+		if !request.ExpiresAt.After(time.Now()) {
+			expired = true
+			result.Request, err = q.DecidePaymentRequest(ctx, DecidePaymentRequestParams{
+				ID:     request.ID,
+				Status: PaymentRequestStatusExpired,
+			})
+			return err
+		}
+
+		result.Transfer, err = store.executeTransfer(ctx, q, TransferTxParams{
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   request.ToAccountID,
+			Amount:        request.Amount,
+			Description:   request.Description,
+			Reference:     paymentRequestReference(request.ID),
+			InitiatedBy:   arg.Payer,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Request, err = q.DecidePaymentRequest(ctx, DecidePaymentRequestParams{
+			ID:         request.ID,
+			Status:     PaymentRequestStatusPaid,
+			TransferID: sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+	if err == nil && expired {
+		err = ErrPaymentRequestExpired
+	}
+	return result, err
+}
+
+// paymentRequestReference is the reference of the transfer paying the request
+func paymentRequestReference(requestID int64) string {
+	return "payment-request:" + strconv.FormatInt(requestID, 10)
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPaymentRequest(t *testing.T, expiresAt time.Time) (PaymentRequest, Account) {
+	toAccount := CreateRandomAccount(t)
+	payer := CreateRandomUser(t)
+
+	request, err := testQueries.CreatePaymentRequest(context.Background(), CreatePaymentRequestParams{
+		Requester:   toAccount.Owner,
+		Payer:       payer.Username,
+		ToAccountID: toAccount.ID,
+		Amount:      10,
+		Currency:    toAccount.Currency,
+		Description: "dinner",
+		ExpiresAt:   expiresAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestStatusPending, request.Status)
+	require.False(t, request.TransferID.Valid)
+	require.False(t, request.DecidedAt.Valid)
+	return request, toAccount
+}
+
+func TestPayPaymentRequestTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	request, toAccount := createRandomPaymentRequest(t, time.Now().Add(time.Hour))
+	fromAccount := CreateRandomAccount(t)
+
+	arg := PayPaymentRequestTxParams{
+		RequestID:     request.ID,
+		Payer:         request.Payer,
+		FromAccountID: fromAccount.ID,
+	}
+
+	_, err := store.PayPaymentRequestTx(context.Background(), PayPaymentRequestTxParams{
+		RequestID:     request.ID,
+		Payer:         request.Requester,
+		FromAccountID: fromAccount.ID,
+	})
+	require.ErrorIs(t, err, ErrPaymentRequestPayer)
+
+	// concurrent accepts pay the request exactly once
+	n := 3
+	errs := make(chan error)
+	results := make(chan PayPaymentRequestTxResult)
+	for i := 0; i < n; i++ {
+		go func() {
+			result, err := store.PayPaymentRequestTx(context.Background(), arg)
+			errs <- err
+			results <- result
+		}()
+	}
+
+	paid := 0
+	for i := 0; i < n; i++ {
+		err := <-errs
+		result := <-results
+		if err != nil {
+			require.ErrorIs(t, err, ErrPaymentRequestNotPending)
+			continue
+		}
+		paid++
+		require.Equal(t, PaymentRequestStatusPaid, result.Request.Status)
+		require.Equal(t, result.Transfer.Transfer.ID, result.Request.TransferID.Int64)
+		require.Equal(t, "dinner", result.Transfer.Transfer.Description)
+	}
+	require.Equal(t, 1, paid)
+
+	account, err := testQueries.GetAccount(context.Background(), toAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, toAccount.Balance+10, account.Balance)
+}
+
+func TestPayExpiredPaymentRequestTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	request, _ := createRandomPaymentRequest(t, time.Now().Add(-time.Minute))
+	fromAccount := CreateRandomAccount(t)
+
+	result, err := store.PayPaymentRequestTx(context.Background(), PayPaymentRequestTxParams{
+		RequestID:     request.ID,
+		Payer:         request.Payer,
+		FromAccountID: fromAccount.ID,
+	})
+	require.ErrorIs(t, err, ErrPaymentRequestExpired)
+	require.Equal(t, PaymentRequestStatusExpired, result.Request.Status)
+
+	// the expiry was committed
+	request, err = testQueries.GetPaymentRequest(context.Background(), request.ID)
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestStatusExpired, request.Status)
+
+	_, err = testQueries.DecidePaymentRequest(context.Background(), DecidePaymentRequestParams{
+		ID:     request.ID,
+		Status: PaymentRequestStatusDeclined,
+	})
+	require.Error(t, err)
+}
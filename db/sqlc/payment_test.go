@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayNewAccountTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+	account := CreateRandomAccount(t)
+	recipient := CreateRandomUser(t)
+
+	owner := GetOwnerAccountParams{Owner: recipient.Username, Currency: account.Currency, Type: AccountTypeChecking}
+	pay := func(fromAccountID int64, hold *CreateTransferApprovalTxParams) (PayNewAccountTxResult, error) {
+		return store.PayNewAccountTx(context.Background(), PayNewAccountTxParams{
+			Owner:    recipient.Username,
+			Currency: account.Currency,
+			Transfer: TransferTxParams{FromAccountID: fromAccountID, Amount: 10, InitiatedBy: account.Owner},
+			Hold:     hold,
+		})
+	}
+
+	// a payment that fails leaves no account behind
+	_, err := pay(account.ID+1_000_000, nil)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = testQueries.GetOwnerAccount(context.Background(), owner)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	paid, err := pay(account.ID, nil)
+	require.NoError(t, err)
+	require.Equal(t, recipient.Username, paid.Account.Owner)
+	require.Equal(t, AccountTypeChecking, paid.Account.Type)
+	require.NotNil(t, paid.Transfer)
+	require.Nil(t, paid.Approval)
+	require.Equal(t, paid.Account.ID, paid.Transfer.Transfer.ToAccountID)
+	require.Equal(t, int64(10), paid.Transfer.ToAccount.Balance)
+
+	member, err := testQueries.GetAccountMember(context.Background(), GetAccountMemberParams{
+		AccountID: paid.Account.ID,
+		Username:  recipient.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, MemberRoleOwner, member.Role)
+
+	// the next payment goes to the same account, held ones as well
+	held, err := pay(account.ID, &CreateTransferApprovalTxParams{ExpiresAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	require.Equal(t, paid.Account.ID, held.Account.ID)
+	require.Nil(t, held.Transfer)
+	require.NotNil(t, held.Approval)
+	require.Equal(t, paid.Account.ID, held.Approval.ToAccountID)
+	require.Equal(t, ApprovalStatusPending, held.Approval.Status)
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// Statuses of an imported payout row
+const (
+	PayoutStatusExecuted = "executed"
+	PayoutStatusPending  = "pending"
+	PayoutStatusFailed   = "failed"
+)
+
+// ErrReferenceUsed is returned when the reference of a row was used by another transfer or pending
+// approval of the account since the file was validated
+var ErrReferenceUsed = errors.New("reference was already used by a transfer from the account")
+
+type ExecutePayoutImportRowTxParams struct {
+	// Row is recorded with the status and the id of the transfer or the approval
+	Row CreatePayoutImportRowParams `json:"row"`
+	// Transfer is executed, or held for approval when Hold is set
+	Transfer TransferTxParams                `json:"transfer"`
+	Hold     *CreateTransferApprovalTxParams `json:"hold,omitempty"`
+}
+
+type ExecutePayoutImportRowTxResult struct {
+	Row      PayoutImportRow   `json:"row"`
+	Transfer *TransferTxResult `json:"transfer,omitempty"`
+	Approval *TransferApproval `json:"approval,omitempty"`
+}
+
+// ExecutePayoutImportRowTx executes a row of an imported payout file as in TransferTx, or holds it for
+// approval, and records it as executed or pending in the same transaction. The reference is checked
+// again under a lock held until the transaction ends, so two imports cannot both use it. When the
+// transfer fails nothing is recorded, the caller records the failure.
+func (store *SQLStore) ExecutePayoutImportRowTx(ctx context.Context, arg ExecutePayoutImportRowTxParams) (ExecutePayoutImportRowTxResult, error) {
+	var result ExecutePayoutImportRowTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		row := arg.Row
+		row.Error = ""
+
+		if arg.Transfer.Reference != "" {
+			reference := TransferReferenceExistsParams{
+				FromAccountID: arg.Transfer.FromAccountID,
+				Reference:     arg.Transfer.Reference,
+			}
+			if err := q.LockTransferReference(ctx, LockTransferReferenceParams(reference)); err != nil {
+				return err
+			}
+			used, err := q.TransferReferenceExists(ctx, reference)
+			if err != nil {
+				return err
+			}
+			if used {
+				return ErrReferenceUsed
+			}
+		}
+
+		if arg.Hold != nil {
+			hold := *arg.Hold
+			hold.TransferTxParams = arg.Transfer
+
+			approval, err := store.requestTransferApproval(ctx, q, hold)
+			if err != nil {
+				return err
+			}
+			result.Approval = &approval
+			row.Status = PayoutStatusPending
+			row.ApprovalID = sql.NullInt64{Int64: approval.ID, Valid: true}
+		} else {
+			transfer, err := store.executeTransfer(ctx, q, arg.Transfer)
+			if err != nil {
+				return err
+			}
+			result.Transfer = &transfer
+			row.Status = PayoutStatusExecuted
+			row.TransferID = sql.NullInt64{Int64: transfer.Transfer.ID, Valid: true}
+		}
+
+		var err error
+		result.Row, err = q.CreatePayoutImportRow(ctx, row)
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPayoutImport(t *testing.T) PayoutImport {
+	user := CreateRandomUser(t)
+
+	payoutImport, err := testQueries.CreatePayoutImport(context.Background(), CreatePayoutImportParams{
+		Owner:    user.Username,
+		RowCount: 2,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, payoutImport.ID)
+	require.Equal(t, user.Username, payoutImport.Owner)
+	require.Equal(t, int64(2), payoutImport.RowCount)
+	require.NotZero(t, payoutImport.CreatedAt)
+	return payoutImport
+}
+
+func TestExecutePayoutImportRowTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+	payoutImport := createRandomPayoutImport(t)
+	account1 := CreateRandomAccount(t)
+	account2 := CreateRandomAccount(t)
+
+	row := func(line int64, reference string) CreatePayoutImportRowParams {
+		return CreatePayoutImportRowParams{
+			ImportID:      payoutImport.ID,
+			Line:          line,
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+			Currency:      account1.Currency,
+			Reference:     reference,
+		}
+	}
+	transfer := func(reference string) TransferTxParams {
+		return TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+			Reference:     reference,
+			InitiatedBy:   payoutImport.Owner,
+		}
+	}
+
+	executed, err := store.ExecutePayoutImportRowTx(context.Background(), ExecutePayoutImportRowTxParams{
+		Row:      row(2, "PAY-1"),
+		Transfer: transfer("PAY-1"),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, executed.Transfer)
+	require.Nil(t, executed.Approval)
+	require.Equal(t, PayoutStatusExecuted, executed.Row.Status)
+	require.Equal(t, executed.Transfer.Transfer.ID, executed.Row.TransferID.Int64)
+	require.Equal(t, account1.Balance-10, executed.Transfer.FromAccount.Balance)
+
+	held, err := store.ExecutePayoutImportRowTx(context.Background(), ExecutePayoutImportRowTxParams{
+		Row:      row(3, "PAY-2"),
+		Transfer: transfer("PAY-2"),
+		Hold:     &CreateTransferApprovalTxParams{ExpiresAt: time.Now().Add(time.Hour)},
+	})
+	require.NoError(t, err)
+	require.Nil(t, held.Transfer)
+	require.NotNil(t, held.Approval)
+	require.Equal(t, PayoutStatusPending, held.Row.Status)
+	require.Equal(t, held.Approval.ID, held.Row.ApprovalID.Int64)
+
+	for _, reference := range []string{"PAY-1", "PAY-2"} {
+		used, err := testQueries.TransferReferenceExists(context.Background(), TransferReferenceExistsParams{
+			FromAccountID: account1.ID,
+			Reference:     reference,
+		})
+		require.NoError(t, err)
+		require.True(t, used, reference)
+	}
+	used, err := testQueries.TransferReferenceExists(context.Background(), TransferReferenceExistsParams{
+		FromAccountID: account2.ID,
+		Reference:     "PAY-1",
+	})
+	require.NoError(t, err)
+	require.False(t, used)
+
+	// a later import reusing the reference of a transfer or of a pending approval fails and records nothing
+	for i, reference := range []string{"PAY-1", "PAY-2"} {
+		_, err := store.ExecutePayoutImportRowTx(context.Background(), ExecutePayoutImportRowTxParams{
+			Row:      row(int64(4+i), reference),
+			Transfer: transfer(reference),
+		})
+		require.ErrorIs(t, err, ErrReferenceUsed, reference)
+	}
+
+	rows, err := testQueries.ListPayoutImportRows(context.Background(), payoutImport.ID)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, int64(2), rows[0].Line)
+	require.Equal(t, int64(3), rows[1].Line)
+	require.Equal(t, ApprovalStatusPending, rows[1].ApprovalStatus)
+	require.Equal(t, sql.NullInt64{}, rows[1].ApprovalTransferID)
+}
@@ -6,25 +6,134 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
 type Querier interface {
-	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AcceptAccountMember(ctx context.Context, arg AcceptAccountMemberParams) (AccountMember, error)
+	CloseAccount(ctx context.Context, id int64) (Account, error)
+	CountActiveAccountOwners(ctx context.Context, accountID int64) (int64, error)
+	CountOrganizationAdmins(ctx context.Context, orgID int64) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountMember(ctx context.Context, arg CreateAccountMemberParams) (AccountMember, error)
+	CreateAccountStatusHistory(ctx context.Context, arg CreateAccountStatusHistoryParams) (AccountStatusHistory, error)
+	CreateBalanceSnapshots(ctx context.Context, arg CreateBalanceSnapshotsParams) (int64, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateInterestCapitalization(ctx context.Context, arg CreateInterestCapitalizationParams) (InterestCapitalization, error)
+	CreateInterestRate(ctx context.Context, arg CreateInterestRateParams) (InterestRate, error)
+	CreateLedgerCheckpoint(ctx context.Context, arg CreateLedgerCheckpointParams) (LedgerCheckpoint, error)
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error)
+	CreateOrganizationMember(ctx context.Context, arg CreateOrganizationMemberParams) (OrganizationMember, error)
+	CreatePayee(ctx context.Context, arg CreatePayeeParams) (Payee, error)
+	CreatePaymentBatch(ctx context.Context, arg CreatePaymentBatchParams) (PaymentBatch, error)
+	CreatePaymentBatchTransaction(ctx context.Context, arg CreatePaymentBatchTransactionParams) (PaymentBatchTransaction, error)
+	CreatePaymentLink(ctx context.Context, arg CreatePaymentLinkParams) (PaymentLink, error)
+	CreatePaymentLinkPayment(ctx context.Context, arg CreatePaymentLinkPaymentParams) (PaymentLinkPayment, error)
+	CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error)
+	CreatePayoutImport(ctx context.Context, arg CreatePayoutImportParams) (PayoutImport, error)
+	CreatePayoutImportRow(ctx context.Context, arg CreatePayoutImportRowParams) (PayoutImportRow, error)
+	CreateStatement(ctx context.Context, arg CreateStatementParams) (Statement, error)
+	CreateSystemAccount(ctx context.Context, arg CreateSystemAccountParams) (Account, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error)
+	CreateTransferApprovalEvent(ctx context.Context, arg CreateTransferApprovalEventParams) (TransferApprovalEvent, error)
+	CreateTransferLimit(ctx context.Context, arg CreateTransferLimitParams) (TransferLimit, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
-	DeleteAccount(ctx context.Context, id int64) error
-	DeleteTransfer(ctx context.Context, id int64) error
+	DecidePaymentRequest(ctx context.Context, arg DecidePaymentRequestParams) (PaymentRequest, error)
+	DecideTransferApproval(ctx context.Context, arg DecideTransferApprovalParams) (TransferApproval, error)
+	DeleteAccountMember(ctx context.Context, arg DeleteAccountMemberParams) error
+	DeleteOrganizationMember(ctx context.Context, arg DeleteOrganizationMemberParams) error
+	DeletePayee(ctx context.Context, arg DeletePayeeParams) (Payee, error)
+	ExpireTransferApprovals(ctx context.Context, expiresAt time.Time) ([]TransferApproval, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountChainHead(ctx context.Context, accountID int64) ([]byte, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountMember(ctx context.Context, arg GetAccountMemberParams) (AccountMember, error)
+	GetAccountTransferTotals(ctx context.Context, arg GetAccountTransferTotalsParams) (GetAccountTransferTotalsRow, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetLastInterestCapitalization(ctx context.Context, accountID int64) (InterestCapitalization, error)
+	GetLatestBalanceSnapshot(ctx context.Context, arg GetLatestBalanceSnapshotParams) (BalanceSnapshot, error)
+	GetLedgerCheckpoint(ctx context.Context, id int64) (LedgerCheckpoint, error)
+	GetOrganization(ctx context.Context, id int64) (Organization, error)
+	GetOrganizationForUpdate(ctx context.Context, id int64) (Organization, error)
+	GetOrganizationMember(ctx context.Context, arg GetOrganizationMemberParams) (OrganizationMember, error)
+	GetOwnerAccount(ctx context.Context, arg GetOwnerAccountParams) (Account, error)
+	GetPayee(ctx context.Context, id int64) (Payee, error)
+	GetPaymentBatch(ctx context.Context, id int64) (PaymentBatch, error)
+	GetPaymentLink(ctx context.Context, id int64) (PaymentLink, error)
+	GetPaymentLinkForUpdate(ctx context.Context, id int64) (PaymentLink, error)
+	GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error)
+	GetPaymentRequestForUpdate(ctx context.Context, id int64) (PaymentRequest, error)
+	GetPayoutImport(ctx context.Context, id int64) (PayoutImport, error)
+	GetStatement(ctx context.Context, id int64) (Statement, error)
+	GetSystemAccount(ctx context.Context, arg GetSystemAccountParams) (Account, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferApproval(ctx context.Context, id int64) (TransferApproval, error)
+	GetTransferApprovalForUpdate(ctx context.Context, id int64) (TransferApproval, error)
+	GetUncapitalizedInterest(ctx context.Context, arg GetUncapitalizedInterestParams) (int64, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	GetUserTransferTotals(ctx context.Context, arg GetUserTransferTotalsParams) (GetUserTransferTotalsRow, error)
+	ListAccountBalanceMismatches(ctx context.Context) ([]ListAccountBalanceMismatchesRow, error)
+	ListAccountBalanceSnapshots(ctx context.Context, arg ListAccountBalanceSnapshotsParams) ([]BalanceSnapshot, error)
+	ListAccountChainEntries(ctx context.Context, accountID int64) ([]Entry, error)
+	ListAccountChainHeads(ctx context.Context, id int64) ([]ListAccountChainHeadsRow, error)
+	ListAccountEntries(ctx context.Context, arg ListAccountEntriesParams) ([]Entry, error)
+	ListAccountEntriesAfter(ctx context.Context, arg ListAccountEntriesAfterParams) ([]Entry, error)
+	ListAccountEntriesBefore(ctx context.Context, arg ListAccountEntriesBeforeParams) ([]Entry, error)
+	ListAccountInterestAccruals(ctx context.Context, arg ListAccountInterestAccrualsParams) ([]InterestAccrual, error)
+	ListAccountMembers(ctx context.Context, accountID int64) ([]AccountMember, error)
+	ListAccountStatements(ctx context.Context, accountID int64) ([]Statement, error)
+	ListAccountStatusHistory(ctx context.Context, accountID int64) ([]AccountStatusHistory, error)
+	ListAccountTransfers(ctx context.Context, arg ListAccountTransfersParams) ([]Transfer, error)
+	ListAccountTransfersAfter(ctx context.Context, arg ListAccountTransfersAfterParams) ([]Transfer, error)
+	ListAccountTransfersBefore(ctx context.Context, arg ListAccountTransfersBeforeParams) ([]Transfer, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListAccountsAfter(ctx context.Context, arg ListAccountsAfterParams) ([]Account, error)
+	ListAccountsBefore(ctx context.Context, arg ListAccountsBeforeParams) ([]Account, error)
+	ListAccountsWithUncapitalizedInterest(ctx context.Context, arg ListAccountsWithUncapitalizedInterestParams) ([]int64, error)
+	ListApplicableTransferLimits(ctx context.Context, arg ListApplicableTransferLimitsParams) ([]TransferLimit, error)
+	ListCurrencyLedgerTotals(ctx context.Context) ([]ListCurrencyLedgerTotalsRow, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListEntryAccountIDs(ctx context.Context) ([]int64, error)
+	ListIncomingPaymentRequests(ctx context.Context, payer string) ([]PaymentRequest, error)
+	ListInterestBearingBalances(ctx context.Context, arg ListInterestBearingBalancesParams) ([]ListInterestBearingBalancesRow, error)
+	ListInterestRates(ctx context.Context) ([]InterestRate, error)
+	ListLedgerCheckpoints(ctx context.Context, limit int32) ([]LedgerCheckpoint, error)
+	ListNewPayees(ctx context.Context, arg ListNewPayeesParams) ([]Payee, error)
+	ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]Notification, error)
+	ListOrganizationMembers(ctx context.Context, orgID int64) ([]OrganizationMember, error)
+	ListOrphanEntries(ctx context.Context) ([]ListOrphanEntriesRow, error)
+	ListOutgoingPaymentRequests(ctx context.Context, requester string) ([]PaymentRequest, error)
+	ListPayees(ctx context.Context, owner string) ([]Payee, error)
+	ListPaymentBatchTransactions(ctx context.Context, batchID int64) ([]ListPaymentBatchTransactionsRow, error)
+	ListPaymentBatches(ctx context.Context, arg ListPaymentBatchesParams) ([]PaymentBatch, error)
+	ListPaymentLinkPayments(ctx context.Context, linkID int64) ([]PaymentLinkPayment, error)
+	ListPaymentLinks(ctx context.Context, owner string) ([]PaymentLink, error)
+	ListPayoutImportRows(ctx context.Context, importID int64) ([]ListPayoutImportRowsRow, error)
+	ListPayoutImports(ctx context.Context, arg ListPayoutImportsParams) ([]PayoutImport, error)
+	ListPendingTransferApprovals(ctx context.Context, arg ListPendingTransferApprovalsParams) ([]ListPendingTransferApprovalsRow, error)
+	ListStatementAccounts(ctx context.Context, arg ListStatementAccountsParams) ([]Account, error)
+	ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error)
+	ListTransferApprovalEvents(ctx context.Context, approvalID int64) ([]TransferApprovalEvent, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
-	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	ListTransfersByReference(ctx context.Context, arg ListTransfersByReferenceParams) ([]ListTransfersByReferenceRow, error)
+	ListUnbalancedTransfers(ctx context.Context) ([]ListUnbalancedTransfersRow, error)
+	ListUserOrganizations(ctx context.Context, username string) ([]Organization, error)
+	LockTransferReference(ctx context.Context, arg LockTransferReferenceParams) error
+	MarkInterestAccrualsCapitalized(ctx context.Context, arg MarkInterestAccrualsCapitalizedParams) error
+	MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (Notification, error)
+	OpenOwnerAccount(ctx context.Context, arg OpenOwnerAccountParams) (Account, error)
+	SetEntryHash(ctx context.Context, arg SetEntryHashParams) (Entry, error)
+	SumAccountEntriesBetween(ctx context.Context, arg SumAccountEntriesBetweenParams) (int64, error)
+	SumAccountEntriesSince(ctx context.Context, arg SumAccountEntriesSinceParams) (int64, error)
+	SumStatementEntries(ctx context.Context, arg SumStatementEntriesParams) (SumStatementEntriesRow, error)
+	TransferReferenceExists(ctx context.Context, arg TransferReferenceExistsParams) (bool, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
+	UpdatePaymentLinkStatus(ctx context.Context, arg UpdatePaymentLinkStatusParams) (PaymentLink, error)
+	UpsertInterestAccrual(ctx context.Context, arg UpsertInterestAccrualParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
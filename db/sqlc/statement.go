@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// NotificationStatementReady tells the members of an account that a statement was archived
+	NotificationStatementReady = "statement_ready"
+)
+
+type ArchiveStatementTxResult struct {
+	Statement     Statement      `json:"statement"`
+	Notifications []Notification `json:"notifications"`
+}
+
+// ArchiveStatementTx indexes a statement already written to the blob storage and notifies every
+// user who can view the account, the statement and its notifications are recorded together or not at all.
+func (store *SQLStore) ArchiveStatementTx(ctx context.Context, arg CreateStatementParams) (ArchiveStatementTxResult, error) {
+	var result ArchiveStatementTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		result.Statement, err = q.CreateStatement(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(map[string]int64{
+			"account_id":   account.ID,
+			"statement_id": result.Statement.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		viewers, err := accountViewers(ctx, q, account)
+		if err != nil {
+			return err
+		}
+
+		message := fmt.Sprintf("Your %s statement of account %d for %s is ready",
+			strings.ToUpper(arg.Format), account.ID, arg.PeriodStart.Format("January 2006"))
+		for _, username := range viewers {
+			notification, err := q.CreateNotification(ctx, CreateNotificationParams{
+				Username: username,
+				Kind:     NotificationStatementReady,
+				Message:  message,
+				Data:     data,
+			})
+			if err != nil {
+				return err
+			}
+			result.Notifications = append(result.Notifications, notification)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// accountViewers lists the users allowed to view the account, the members of its organization
+// or its active account members
+func accountViewers(ctx context.Context, q *Queries, account Account) ([]string, error) {
+	var viewers []string
+
+	if account.OrgID.Valid {
+		members, err := q.ListOrganizationMembers(ctx, account.OrgID.Int64)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			if member.Can(AccountPermissionView) {
+				viewers = append(viewers, member.Username)
+			}
+		}
+		return viewers, nil
+	}
+
+	members, err := q.ListAccountMembers(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if member.Can(AccountPermissionView) {
+			viewers = append(viewers, member.Username)
+		}
+	}
+	return viewers, nil
+}
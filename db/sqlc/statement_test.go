@@ -0,0 +1,244 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListStatementEntries(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	account1 := CreateRandomEmptyAccount(t)
+	account2 := CreateRandomEmptyAccount(t)
+	owner2, err := testQueries.GetUser(context.Background(), account2.Owner)
+	require.NoError(t, err)
+
+	start := time.Now().Add(-time.Minute)
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Description:   "dinner",
+		Reference:     "inv-1",
+	})
+	require.NoError(t, err)
+
+	// an entry without a transfer has no counterparty, even when it looks like a leg of one
+	stray, err := testQueries.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID: account1.ID,
+		Amount:    -10,
+		Reference: "inv-1",
+		Metadata:  json.RawMessage(`{}`),
+	})
+	require.NoError(t, err)
+
+	arg := ListStatementEntriesParams{
+		AccountID: account1.ID,
+		StartTime: start,
+		EndTime:   time.Now().Add(time.Minute),
+		PageLimit: 10,
+	}
+	entries, err := testQueries.ListStatementEntries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	require.Equal(t, result.FromEntry.ID, entries[0].ID)
+	require.Equal(t, int64(-10), entries[0].Amount)
+	require.Equal(t, result.Transfer.ID, entries[0].TransferID)
+	require.Equal(t, owner2.FullName, entries[0].Counterparty)
+	require.Equal(t, "dinner", entries[0].Description)
+	require.Equal(t, "inv-1", entries[0].Reference)
+
+	require.Equal(t, stray.ID, entries[1].ID)
+	require.Zero(t, entries[1].TransferID)
+	require.Empty(t, entries[1].Counterparty)
+
+	// pages continue after the last entry
+	arg.AfterID = entries[0].ID
+	entries, err = testQueries.ListStatementEntries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, stray.ID, entries[0].ID)
+}
+
+func TestArchiveStatementTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+	account := CreateRandomAccount(t)
+
+	periodStart := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	arg := CreateStatementParams{
+		AccountID:      account.ID,
+		PeriodStart:    periodStart,
+		PeriodEnd:      time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		Format:         "pdf",
+		BlobKey:        fmt.Sprintf("statements/%d/2026-03.pdf", account.ID),
+		Size:           10,
+		Sha256:         []byte{1, 2, 3},
+		OpeningBalance: 100,
+		ClosingBalance: 250,
+	}
+
+	// the account is listed until its statement is archived
+	listArg := ListStatementAccountsParams{
+		PeriodEndTime: time.Now().Add(time.Minute),
+		AfterID:       account.ID - 1,
+		PeriodStart:   periodStart,
+		Format:        "pdf",
+		PageLimit:     1,
+	}
+	accounts, err := testQueries.ListStatementAccounts(context.Background(), listArg)
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+
+	result, err := store.ArchiveStatementTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.BlobKey, result.Statement.BlobKey)
+	require.Equal(t, arg.Sha256, result.Statement.Sha256)
+	require.Len(t, result.Notifications, 1)
+	require.Equal(t, account.Owner, result.Notifications[0].Username)
+	require.Equal(t, NotificationStatementReady, result.Notifications[0].Kind)
+	require.JSONEq(t, fmt.Sprintf(`{"account_id": %d, "statement_id": %d}`, account.ID, result.Statement.ID),
+		string(result.Notifications[0].Data))
+	require.False(t, result.Notifications[0].ReadAt.Valid)
+
+	accounts, err = testQueries.ListStatementAccounts(context.Background(), listArg)
+	require.NoError(t, err)
+	for _, listed := range accounts {
+		require.NotEqual(t, account.ID, listed.ID)
+	}
+
+	statements, err := testQueries.ListAccountStatements(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, statements, 1)
+	require.Equal(t, result.Statement, statements[0])
+
+	// a month is archived once, and the failed attempt notifies nobody
+	arg.BlobKey += ".retry"
+	_, err = store.ArchiveStatementTx(context.Background(), arg)
+	require.Error(t, err)
+
+	notifications, err := testQueries.ListNotifications(context.Background(), ListNotificationsParams{
+		Username:  account.Owner,
+		PageLimit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+
+	// archived statements are never changed
+	_, err = testDB.ExecContext(context.Background(), "UPDATE statements SET size = 0 WHERE id = $1", result.Statement.ID)
+	requireAppendOnly(t, err)
+
+	_, err = testDB.ExecContext(context.Background(), "DELETE FROM statements WHERE id = $1", result.Statement.ID)
+	requireAppendOnly(t, err)
+}
+
+func TestArchiveStatementTxJointAccount(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+	account := CreateRandomAccount(t)
+
+	// every active member sees the statement, an invitation not accepted yet gives no access
+	addMember := func(role string, accept bool) User {
+		user := CreateRandomUser(t)
+		_, err := testQueries.CreateAccountMember(context.Background(), CreateAccountMemberParams{
+			AccountID: account.ID,
+			Username:  user.Username,
+			Role:      role,
+			InvitedBy: account.Owner,
+		})
+		require.NoError(t, err)
+		if accept {
+			_, err = testQueries.AcceptAccountMember(context.Background(), AcceptAccountMemberParams{
+				AccountID: account.ID,
+				Username:  user.Username,
+			})
+			require.NoError(t, err)
+		}
+		return user
+	}
+	coOwner := addMember(MemberRoleOwner, true)
+	viewer := addMember(MemberRoleViewer, true)
+	invited := addMember(MemberRoleSpender, false)
+
+	result, err := store.ArchiveStatementTx(context.Background(), CreateStatementParams{
+		AccountID:   account.ID,
+		PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		Format:      "pdf",
+		BlobKey:     fmt.Sprintf("statements/%d/2026-03.pdf", account.ID),
+		Size:        10,
+		Sha256:      []byte{1, 2, 3},
+	})
+	require.NoError(t, err)
+
+	var notified []string
+	for _, notification := range result.Notifications {
+		require.Equal(t, NotificationStatementReady, notification.Kind)
+		notified = append(notified, notification.Username)
+	}
+	require.ElementsMatch(t, []string{account.Owner, coOwner.Username, viewer.Username}, notified)
+
+	notifications, err := testQueries.ListNotifications(context.Background(), ListNotificationsParams{
+		Username:  invited.Username,
+		PageLimit: 10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, notifications)
+}
+
+func TestNotifications(t *testing.T) {
+	user := CreateRandomUser(t)
+	other := CreateRandomUser(t)
+
+	notification, err := testQueries.CreateNotification(context.Background(), CreateNotificationParams{
+		Username: user.Username,
+		Kind:     NotificationStatementReady,
+		Message:  "ready",
+		Data:     json.RawMessage(`{}`),
+	})
+	require.NoError(t, err)
+
+	unread, err := testQueries.ListNotifications(context.Background(), ListNotificationsParams{
+		Username:   user.Username,
+		UnreadOnly: true,
+		PageLimit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, unread, 1)
+
+	// only the recipient can read it
+	_, err = testQueries.MarkNotificationRead(context.Background(), MarkNotificationReadParams{
+		ID:       notification.ID,
+		Username: other.Username,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	read, err := testQueries.MarkNotificationRead(context.Background(), MarkNotificationReadParams{
+		ID:       notification.ID,
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+	require.True(t, read.ReadAt.Valid)
+
+	// reading it again keeps the first read time
+	again, err := testQueries.MarkNotificationRead(context.Background(), MarkNotificationReadParams{
+		ID:       notification.ID,
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, read.ReadAt, again.ReadAt)
+
+	unread, err = testQueries.ListNotifications(context.Background(), ListNotificationsParams{
+		Username:   user.Username,
+		UnreadOnly: true,
+		PageLimit:  10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, unread)
+}
@@ -3,23 +3,46 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 )
 
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	AccountLimits(ctx context.Context, accountID int64, username string) ([]LimitStatus, error)
+	AccountBalanceAt(ctx context.Context, accountID int64, at time.Time) (AccountBalance, error)
+	CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error)
+	UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (UpdateAccountStatusTxResult, error)
+	RemoveAccountMemberTx(ctx context.Context, arg RemoveAccountMemberTxParams) error
+	CreateOrganizationTx(ctx context.Context, arg CreateOrganizationTxParams) (CreateOrganizationTxResult, error)
+	RemoveOrganizationMemberTx(ctx context.Context, arg RemoveOrganizationMemberTxParams) error
+	CapitalizeInterestTx(ctx context.Context, arg CapitalizeInterestTxParams) (CapitalizeInterestTxResult, error)
+	CreateTransferApprovalTx(ctx context.Context, arg CreateTransferApprovalTxParams) (TransferApproval, error)
+	DecideTransferApprovalTx(ctx context.Context, arg DecideTransferApprovalTxParams) (DecideTransferApprovalTxResult, error)
+	ExpireTransferApprovalsTx(ctx context.Context, now time.Time) ([]TransferApproval, error)
+	PayPaymentRequestTx(ctx context.Context, arg PayPaymentRequestTxParams) (PayPaymentRequestTxResult, error)
+	PayPaymentLinkTx(ctx context.Context, arg PayPaymentLinkTxParams) (PayPaymentLinkTxResult, error)
+	PayNewAccountTx(ctx context.Context, arg PayNewAccountTxParams) (PayNewAccountTxResult, error)
+	ArchiveStatementTx(ctx context.Context, arg CreateStatementParams) (ArchiveStatementTxResult, error)
+	ExecutePaymentBatchTransactionTx(ctx context.Context, arg ExecutePaymentBatchTransactionTxParams) (ExecutePaymentBatchTransactionTxResult, error)
+	ExecutePayoutImportRowTx(ctx context.Context, arg ExecutePayoutImportRowTxParams) (ExecutePayoutImportRowTxResult, error)
+	CheckPayeeCoolingOff(ctx context.Context, initiator string, account Account, amount int64) error
+	ReadTx(ctx context.Context, fn func(Querier) error) error
 }
 
 type SQLStore struct {
 	*Queries
-	db *sql.DB
+	db     *sql.DB
+	policy TransferPolicy
 }
 
-func NewStore(db *sql.DB) Store {
+func NewStore(db *sql.DB, policy TransferPolicy) Store {
 	return &SQLStore{
 		db:      db,
 		Queries: New(db),
+		policy:  policy,
 	}
 }
 
@@ -41,10 +64,30 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 	return tx.Commit()
 }
 
+// ReadTx runs fn in a read-only repeatable read transaction, so every query of fn sees the same snapshot
+func (store *SQLStore) ReadTx(ctx context.Context, fn func(Querier) error) error {
+	tx, err := store.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(New(tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
 type TransferTxParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Description   string          `json:"description"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
+	// InitiatedBy is the user making the transfer, user and tier limits count against them.
+	// It is empty for transfers the bank makes, those count against the owner of the account.
+	InitiatedBy string `json:"initiated_by"`
 }
 
 type TransferTxResult struct {
@@ -55,110 +98,151 @@ type TransferTxResult struct {
 	ToEntry     Entry    `json:"to_entry"`
 }
 
-var txKey = struct{}{}
-
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
+		result, err = store.executeTransfer(ctx, q, arg)
+		return err
+	})
+	return result, err
+}
 
-		// txName := ctx.Value(txKey).(string)
-
-		// fmt.Println(txName, "creating transfer")
-
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
-
-		// fmt.Println(txName, "creating entry 1")
-
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
-
-		// fmt.Println(txName, "creating entry 2")
+// executeTransfer checks the approval threshold, account statuses, transfer limits and payee cooling-off then
+// moves the money. A transfer above the approval threshold fails with ErrApprovalRequired, it is held with
+// requestTransferApproval instead. It must run inside a transaction.
+func (store *SQLStore) executeTransfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	return store.checkedTransfer(ctx, q, arg, true)
+}
 
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
+// executeApprovedTransfer is executeTransfer for a transfer a second user approved, the approval threshold
+// does not apply. It must run inside a transaction.
+func (store *SQLStore) executeApprovedTransfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	return store.checkedTransfer(ctx, q, arg, false)
+}
 
-		// fmt.Println(txName, "get account 1 for update")
+// checkedTransfer runs the checks of executeTransfer, the approval threshold only with checkThreshold
+func (store *SQLStore) checkedTransfer(ctx context.Context, q *Queries, arg TransferTxParams, checkThreshold bool) (TransferTxResult, error) {
+	fromAccount, err := q.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+	if err := CheckAccountStatus(fromAccount, AccountDebit); err != nil {
+		return TransferTxResult{}, err
+	}
+	if checkThreshold && store.policy.requiresApproval(fromAccount.Currency, arg.Amount) {
+		return TransferTxResult{}, ErrApprovalRequired
+	}
 
-		// account1, err := q.GetAccountForUpdate(ctx, arg.FromAccountID)
-		// if err != nil {
-		// 	return err
-		// }
+	toAccount, err := q.GetAccount(ctx, arg.ToAccountID)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+	if err := CheckAccountStatus(toAccount, AccountCredit); err != nil {
+		return TransferTxResult{}, err
+	}
 
-		// fmt.Println(txName, "update account 1")
+	initiator := arg.InitiatedBy
+	if initiator == "" {
+		initiator = fromAccount.Owner
+	}
 
-		// result.FromAccount, err = q.UpdateAccount(ctx, UpdateAccountParams{
-		// 	ID:      arg.FromAccountID,
-		// 	Balance: account1.Balance - arg.Amount,
-		// })
-		// if err != nil {
-		// 	return err
-		// }
+	// locking the initiator serializes their transfers, so user and tier limits always see the
+	// totals of every transfer they committed before. Members of a joint account are different
+	// initiators, locking both accounts serializes the transfers of the account as well. The account
+	// locks are held until the transaction ends, so they also cover the appends of transferMoney.
+	user, err := q.GetUserForUpdate(ctx, initiator)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+	if err := lockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID); err != nil {
+		return TransferTxResult{}, err
+	}
 
-		if arg.FromAccountID < arg.ToAccountID {
+	now := time.Now()
+	err = checkTransferLimits(ctx, q, fromAccount, user, arg.Amount, now)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+	err = store.policy.checkPayeeCoolingOff(ctx, q, initiator, toAccount, arg.Amount, now)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
 
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-			if err != nil {
-				return err
-			}
+	return transferMoney(ctx, q, arg)
+}
 
-		} else {
+// transferMoney records the transfer and appends its entries to the hash chains of the accounts,
+// the entries move the balances. It must run inside a transaction holding the locks of both accounts,
+// taken with lockAccounts, so the appends to their hash chains are serialized. It does not check
+// transfer limits.
+func transferMoney(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+	var err error
 
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
-			if err != nil {
-				return err
-			}
+	metadata := arg.Metadata
+	if len(metadata) == 0 {
+		metadata = json.RawMessage(`{}`)
+	}
 
-		}
-		return nil
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Description:   arg.Description,
+		Reference:     arg.Reference,
+		Metadata:      metadata,
+		InitiatedBy:   sql.NullString{String: arg.InitiatedBy, Valid: arg.InitiatedBy != ""},
+	})
+	if err != nil {
+		return result, err
+	}
 
-		// fmt.Println(txName, "get account 2 for update")
+	// the entries_apply trigger moves the balance of the account as each entry is written,
+	// and the accounts_status_check trigger catches status changes committed after the accounts were checked
+	transferID := sql.NullInt64{Int64: result.Transfer.ID, Valid: true}
+	result.FromEntry, err = createChainedEntry(ctx, q, CreateEntryParams{
+		AccountID:   arg.FromAccountID,
+		Amount:      -arg.Amount,
+		Description: arg.Description,
+		Reference:   arg.Reference,
+		Metadata:    metadata,
+		TransferID:  transferID,
+	})
+	if err != nil {
+		return result, accountStatusErr(err)
+	}
 
-		// account2, err := q.GetAccountForUpdate(ctx, arg.ToAccountID)
-		// if err != nil {
-		// 	return err
-		// }
+	result.ToEntry, err = createChainedEntry(ctx, q, CreateEntryParams{
+		AccountID:   arg.ToAccountID,
+		Amount:      arg.Amount,
+		Description: arg.Description,
+		Reference:   arg.Reference,
+		Metadata:    metadata,
+		TransferID:  transferID,
+	})
+	if err != nil {
+		return result, accountStatusErr(err)
+	}
 
-		// fmt.Println(txName, "update account 2")
+	result.FromAccount, err = q.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return result, err
+	}
 
-		// result.ToAccount, err = q.UpdateAccount(ctx, UpdateAccountParams{
-		// 	ID:      arg.ToAccountID,
-		// 	Balance: account2.Balance + arg.Amount,
-		// })
-	})
+	result.ToAccount, err = q.GetAccount(ctx, arg.ToAccountID)
 	return result, err
 }
 
-func addMoney(ctx context.Context, q *Queries, accountID1 int64, amount1 int64, accountID2 int64, amount2 int64) (account1 Account, account2 Account, err error) {
-	account1, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID1,
-		Amount: amount1,
-	})
-	if err != nil {
-		return
+// lockAccounts locks both accounts in id order, so transfers in opposite directions cannot deadlock
+func lockAccounts(ctx context.Context, q *Queries, accountID1 int64, accountID2 int64) error {
+	if accountID2 < accountID1 {
+		accountID1, accountID2 = accountID2, accountID1
 	}
-
-	account2, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID2,
-		Amount: amount2,
-	})
-	return
+	if _, err := q.GetAccountForUpdate(ctx, accountID1); err != nil {
+		return err
+	}
+	_, err := q.GetAccountForUpdate(ctx, accountID2)
+	return err
 }
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 
@@ -9,7 +10,7 @@ import (
 )
 
 func TestTransferTx(t *testing.T) {
-	store := NewStore(testDB)
+	store := NewStore(testDB, TransferPolicy{})
 
 	account1 := CreateRandomAccount(t)
 	account2 := CreateRandomAccount(t)
@@ -24,10 +25,8 @@ func TestTransferTx(t *testing.T) {
 	results := make(chan TransferTxResult)
 
 	for i := 0; i < n; i++ {
-		txName := fmt.Sprintf("tx %d", i+1)
 		go func() {
-			ctx := context.WithValue(context.Background(), txKey, txName)
-			result, err := store.TransferTx(ctx, TransferTxParams{
+			result, err := store.TransferTx(context.Background(), TransferTxParams{
 				FromAccountID: account1.ID,
 				ToAccountID:   account2.ID,
 				Amount:        amount,
@@ -62,6 +61,7 @@ func TestTransferTx(t *testing.T) {
 		require.NotEmpty(t, fromEntry)
 		require.Equal(t, account1.ID, fromEntry.AccountID)
 		require.Equal(t, -amount, fromEntry.Amount)
+		require.Equal(t, transfer.ID, fromEntry.TransferID.Int64)
 		require.NotZero(t, fromEntry.ID)
 		require.NotZero(t, fromEntry.CreatedAt)
 
@@ -72,6 +72,7 @@ func TestTransferTx(t *testing.T) {
 		require.NotEmpty(t, toEntry)
 		require.Equal(t, account2.ID, toEntry.AccountID)
 		require.Equal(t, amount, toEntry.Amount)
+		require.Equal(t, transfer.ID, toEntry.TransferID.Int64)
 		require.NotZero(t, toEntry.ID)
 		require.NotZero(t, toEntry.CreatedAt)
 
@@ -118,7 +119,7 @@ func TestTransferTx(t *testing.T) {
 }
 
 func TestTransferTxDeadlock(t *testing.T) {
-	store := NewStore(testDB)
+	store := NewStore(testDB, TransferPolicy{})
 
 	account1 := CreateRandomAccount(t)
 	account2 := CreateRandomAccount(t)
@@ -168,3 +169,167 @@ func TestTransferTxDeadlock(t *testing.T) {
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 
 }
+
+func TestTransferTxLimitExceeded(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	account1 := CreateRandomAccount(t)
+	account2 := CreateRandomAccount(t)
+	CreateRandomAccountLimit(t, account1)
+
+	// a single transfer above the maximum is rejected
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        101,
+	})
+	var limitErr *LimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitPeriodSingle, limitErr.Period)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+	})
+	require.NoError(t, err)
+
+	// the daily total only has 50 left
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        60,
+	})
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitPeriodDaily, limitErr.Period)
+	require.Equal(t, LimitKindAmount, limitErr.Kind)
+	require.Equal(t, int64(50), limitErr.Remaining)
+
+	limits, err := store.AccountLimits(context.Background(), account1.ID, account1.Owner)
+	require.NoError(t, err)
+	require.NotEmpty(t, limits)
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-100, updatedAccount1.Balance)
+}
+
+func TestTransferTxUserLimitCountsInitiator(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	account1 := CreateRandomAccount(t)
+	account2 := CreateRandomAccount(t)
+	spender := CreateRandomUser(t)
+
+	_, err := testQueries.CreateAccountMember(context.Background(), CreateAccountMemberParams{
+		AccountID: account1.ID,
+		Username:  spender.Username,
+		Role:      MemberRoleSpender,
+		InvitedBy: account1.Owner,
+	})
+	require.NoError(t, err)
+	_, err = testQueries.AcceptAccountMember(context.Background(), AcceptAccountMemberParams{
+		AccountID: account1.ID,
+		Username:  spender.Username,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateTransferLimit(context.Background(), CreateTransferLimitParams{
+		Scope:       LimitScopeUser,
+		Username:    sql.NullString{String: account1.Owner, Valid: true},
+		Currency:    sql.NullString{String: account1.Currency, Valid: true},
+		DailyAmount: sql.NullInt64{Int64: 50, Valid: true},
+	})
+	require.NoError(t, err)
+
+	// the spender's transfers don't use up the daily limit of the owner
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        50,
+		InitiatedBy:   spender.Username,
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        50,
+		InitiatedBy:   account1.Owner,
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+		InitiatedBy:   account1.Owner,
+	})
+	var limitErr *LimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitScopeUser, limitErr.Scope)
+}
+
+func TestTransferTxWithDetails(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	account1 := CreateRandomAccount(t)
+	account2 := CreateRandomAccount(t)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Description:   "rent",
+		Reference:     "INV-42",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "rent", result.Transfer.Description)
+	require.Equal(t, "INV-42", result.Transfer.Reference)
+	require.JSONEq(t, `{}`, string(result.Transfer.Metadata))
+	require.Equal(t, "INV-42", result.FromEntry.Reference)
+	require.Equal(t, "INV-42", result.ToEntry.Reference)
+}
+
+func TestTransferTxSavingsWithdrawals(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	checking := CreateRandomAccount(t)
+	savings, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    checking.Owner,
+		Currency: checking.Currency,
+		Type:     AccountTypeSavings,
+	})
+	require.NoError(t, err)
+	savings = FundAccount(t, savings, 1000)
+
+	// savings accounts are limited to six withdrawals a month
+	for i := 0; i < 6; i++ {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: savings.ID,
+			ToAccountID:   checking.ID,
+			Amount:        10,
+		})
+		require.NoError(t, err)
+	}
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: savings.ID,
+		ToAccountID:   checking.ID,
+		Amount:        10,
+	})
+	var limitErr *LimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitScopeAccountType, limitErr.Scope)
+	require.Equal(t, LimitPeriodMonthly, limitErr.Period)
+	require.Equal(t, LimitKindCount, limitErr.Kind)
+
+	// deposits into savings are not limited
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: checking.ID,
+		ToAccountID:   savings.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+}
@@ -7,27 +7,46 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
 )
 
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
     from_account_id,
     to_account_id,
-    amount
+    amount,
+    description,
+    reference,
+    metadata,
+    initiated_by
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5, $6, $7
 )
-RETURNING id, from_account_id, to_account_id, amount, created_at
+RETURNING id, from_account_id, to_account_id, amount, created_at, description, reference, metadata, initiated_by
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Description   string          `json:"description"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
+	InitiatedBy   sql.NullString  `json:"initiated_by"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Description,
+		arg.Reference,
+		arg.Metadata,
+		arg.InitiatedBy,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -35,22 +54,42 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
+		&i.InitiatedBy,
 	)
 	return i, err
 }
 
-const deleteTransfer = `-- name: DeleteTransfer :exec
-DELETE FROM transfers
-WHERE id = $1
+const getAccountTransferTotals = `-- name: GetAccountTransferTotals :one
+SELECT
+    COALESCE(SUM(amount), 0)::bigint AS total_amount,
+    COUNT(*) AS transfer_count
+FROM transfers
+WHERE from_account_id = $1
+AND created_at >= $2
 `
 
-func (q *Queries) DeleteTransfer(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteTransfer, id)
-	return err
+type GetAccountTransferTotalsParams struct {
+	FromAccountID int64     `json:"from_account_id"`
+	Since         time.Time `json:"since"`
+}
+
+type GetAccountTransferTotalsRow struct {
+	TotalAmount   int64 `json:"total_amount"`
+	TransferCount int64 `json:"transfer_count"`
+}
+
+func (q *Queries) GetAccountTransferTotals(ctx context.Context, arg GetAccountTransferTotalsParams) (GetAccountTransferTotalsRow, error) {
+	row := q.db.QueryRowContext(ctx, getAccountTransferTotals, arg.FromAccountID, arg.Since)
+	var i GetAccountTransferTotalsRow
+	err := row.Scan(&i.TotalAmount, &i.TransferCount)
+	return i, err
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, description, reference, metadata, initiated_by FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
@@ -63,12 +102,255 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
+		&i.InitiatedBy,
 	)
 	return i, err
 }
 
+const getUserTransferTotals = `-- name: GetUserTransferTotals :one
+SELECT
+    COALESCE(SUM(transfers.amount), 0)::bigint AS total_amount,
+    COUNT(*) AS transfer_count
+FROM transfers
+JOIN accounts ON accounts.id = transfers.from_account_id
+WHERE COALESCE(transfers.initiated_by, accounts.owner) = $1
+AND accounts.currency = $2
+AND transfers.created_at >= $3
+`
+
+type GetUserTransferTotalsParams struct {
+	Username string    `json:"username"`
+	Currency string    `json:"currency"`
+	Since    time.Time `json:"since"`
+}
+
+type GetUserTransferTotalsRow struct {
+	TotalAmount   int64 `json:"total_amount"`
+	TransferCount int64 `json:"transfer_count"`
+}
+
+func (q *Queries) GetUserTransferTotals(ctx context.Context, arg GetUserTransferTotalsParams) (GetUserTransferTotalsRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserTransferTotals, arg.Username, arg.Currency, arg.Since)
+	var i GetUserTransferTotalsRow
+	err := row.Scan(&i.TotalAmount, &i.TransferCount)
+	return i, err
+}
+
+const listAccountTransfers = `-- name: ListAccountTransfers :many
+SELECT id, from_account_id, to_account_id, amount, created_at, description, reference, metadata, initiated_by FROM transfers
+WHERE (from_account_id = $1 OR to_account_id = $1)
+AND (
+    $2::varchar IS NULL
+    OR ($2 = 'out' AND from_account_id = $1)
+    OR ($2 = 'in' AND to_account_id = $1)
+)
+AND ($3::timestamptz IS NULL OR created_at >= $3)
+AND ($4::timestamptz IS NULL OR created_at < $4)
+AND ($5::bigint IS NULL OR amount >= $5)
+AND ($6::bigint IS NULL OR amount <= $6)
+ORDER BY id
+LIMIT $7
+OFFSET $8
+`
+
+type ListAccountTransfersParams struct {
+	AccountID  int64          `json:"account_id"`
+	Direction  sql.NullString `json:"direction"`
+	StartTime  sql.NullTime   `json:"start_time"`
+	EndTime    sql.NullTime   `json:"end_time"`
+	MinAmount  sql.NullInt64  `json:"min_amount"`
+	MaxAmount  sql.NullInt64  `json:"max_amount"`
+	PageLimit  int32          `json:"page_limit"`
+	PageOffset int32          `json:"page_offset"`
+}
+
+func (q *Queries) ListAccountTransfers(ctx context.Context, arg ListAccountTransfersParams) ([]Transfer, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountTransfers,
+		arg.AccountID,
+		arg.Direction,
+		arg.StartTime,
+		arg.EndTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.InitiatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountTransfersAfter = `-- name: ListAccountTransfersAfter :many
+SELECT id, from_account_id, to_account_id, amount, created_at, description, reference, metadata, initiated_by FROM transfers
+WHERE (from_account_id = $1 OR to_account_id = $1)
+AND id > $2
+AND (
+    $3::varchar IS NULL
+    OR ($3 = 'out' AND from_account_id = $1)
+    OR ($3 = 'in' AND to_account_id = $1)
+)
+AND ($4::timestamptz IS NULL OR created_at >= $4)
+AND ($5::timestamptz IS NULL OR created_at < $5)
+AND ($6::bigint IS NULL OR amount >= $6)
+AND ($7::bigint IS NULL OR amount <= $7)
+ORDER BY id
+LIMIT $8
+`
+
+type ListAccountTransfersAfterParams struct {
+	AccountID int64          `json:"account_id"`
+	CursorID  int64          `json:"cursor_id"`
+	Direction sql.NullString `json:"direction"`
+	StartTime sql.NullTime   `json:"start_time"`
+	EndTime   sql.NullTime   `json:"end_time"`
+	MinAmount sql.NullInt64  `json:"min_amount"`
+	MaxAmount sql.NullInt64  `json:"max_amount"`
+	PageLimit int32          `json:"page_limit"`
+}
+
+func (q *Queries) ListAccountTransfersAfter(ctx context.Context, arg ListAccountTransfersAfterParams) ([]Transfer, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountTransfersAfter,
+		arg.AccountID,
+		arg.CursorID,
+		arg.Direction,
+		arg.StartTime,
+		arg.EndTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.InitiatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountTransfersBefore = `-- name: ListAccountTransfersBefore :many
+SELECT id, from_account_id, to_account_id, amount, created_at, description, reference, metadata, initiated_by FROM transfers
+WHERE (from_account_id = $1 OR to_account_id = $1)
+AND id < $2
+AND (
+    $3::varchar IS NULL
+    OR ($3 = 'out' AND from_account_id = $1)
+    OR ($3 = 'in' AND to_account_id = $1)
+)
+AND ($4::timestamptz IS NULL OR created_at >= $4)
+AND ($5::timestamptz IS NULL OR created_at < $5)
+AND ($6::bigint IS NULL OR amount >= $6)
+AND ($7::bigint IS NULL OR amount <= $7)
+ORDER BY id DESC
+LIMIT $8
+`
+
+type ListAccountTransfersBeforeParams struct {
+	AccountID int64          `json:"account_id"`
+	CursorID  int64          `json:"cursor_id"`
+	Direction sql.NullString `json:"direction"`
+	StartTime sql.NullTime   `json:"start_time"`
+	EndTime   sql.NullTime   `json:"end_time"`
+	MinAmount sql.NullInt64  `json:"min_amount"`
+	MaxAmount sql.NullInt64  `json:"max_amount"`
+	PageLimit int32          `json:"page_limit"`
+}
+
+func (q *Queries) ListAccountTransfersBefore(ctx context.Context, arg ListAccountTransfersBeforeParams) ([]Transfer, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountTransfersBefore,
+		arg.AccountID,
+		arg.CursorID,
+		arg.Direction,
+		arg.StartTime,
+		arg.EndTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.InitiatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, description, reference, metadata, initiated_by FROM transfers
 WHERE from_account_id = $1 OR to_account_id = $1
 ORDER BY id
 LIMIT $2
@@ -96,6 +378,10 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.InitiatedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -109,3 +395,113 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 	}
 	return items, nil
 }
+
+const listTransfersByReference = `-- name: ListTransfersByReference :many
+SELECT transfers.id, transfers.from_account_id, transfers.to_account_id, transfers.amount, transfers.created_at, transfers.description, transfers.reference, transfers.metadata, transfers.initiated_by, accounts.currency FROM transfers
+JOIN accounts ON accounts.id = transfers.from_account_id
+WHERE transfers.reference = $1
+AND (
+    transfers.from_account_id IN (
+        SELECT account_id FROM account_members WHERE username = $2 AND status = 'active'
+    )
+    OR transfers.to_account_id IN (
+        SELECT account_id FROM account_members WHERE username = $2 AND status = 'active'
+    )
+    OR transfers.from_account_id IN (SELECT id FROM accounts WHERE org_id = $3)
+    OR transfers.to_account_id IN (SELECT id FROM accounts WHERE org_id = $3)
+)
+ORDER BY transfers.id
+LIMIT 100
+`
+
+type ListTransfersByReferenceParams struct {
+	Reference string        `json:"reference"`
+	Username  string        `json:"username"`
+	OrgID     sql.NullInt64 `json:"org_id"`
+}
+
+type ListTransfersByReferenceRow struct {
+	ID            int64           `json:"id"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Description   string          `json:"description"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
+	InitiatedBy   sql.NullString  `json:"initiated_by"`
+	Currency      string          `json:"currency"`
+}
+
+func (q *Queries) ListTransfersByReference(ctx context.Context, arg ListTransfersByReferenceParams) ([]ListTransfersByReferenceRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTransfersByReference, arg.Reference, arg.Username, arg.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTransfersByReferenceRow{}
+	for rows.Next() {
+		var i ListTransfersByReferenceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.InitiatedBy,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockTransferReference = `-- name: LockTransferReference :exec
+SELECT pg_advisory_xact_lock(hashtextextended($1::bigint || '/' || $2::text, 0))
+`
+
+type LockTransferReferenceParams struct {
+	FromAccountID int64  `json:"from_account_id"`
+	Reference     string `json:"reference"`
+}
+
+func (q *Queries) LockTransferReference(ctx context.Context, arg LockTransferReferenceParams) error {
+	_, err := q.db.ExecContext(ctx, lockTransferReference, arg.FromAccountID, arg.Reference)
+	return err
+}
+
+const transferReferenceExists = `-- name: TransferReferenceExists :one
+SELECT (
+    EXISTS (
+        SELECT 1 FROM transfers
+        WHERE from_account_id = $1 AND reference = $2
+    ) OR EXISTS (
+        SELECT 1 FROM transfer_approvals
+        WHERE from_account_id = $1 AND reference = $2
+        AND status = 'pending_approval'
+    )
+)::bool AS used
+`
+
+type TransferReferenceExistsParams struct {
+	FromAccountID int64  `json:"from_account_id"`
+	Reference     string `json:"reference"`
+}
+
+func (q *Queries) TransferReferenceExists(ctx context.Context, arg TransferReferenceExistsParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, transferReferenceExists, arg.FromAccountID, arg.Reference)
+	var used bool
+	err := row.Scan(&used)
+	return used, err
+}
@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+const (
+	ApprovalStatusPending  = "pending_approval"
+	ApprovalStatusApproved = "approved"
+	ApprovalStatusRejected = "rejected"
+	ApprovalStatusExpired  = "expired"
+
+	ApprovalActionRequested = "requested"
+	ApprovalActionApproved  = "approved"
+	ApprovalActionRejected  = "rejected"
+	ApprovalActionExpired   = "expired"
+)
+
+var (
+	// ErrApprovalNotPending is returned when deciding on a transfer approval that was already decided
+	ErrApprovalNotPending = errors.New("transfer approval is not pending")
+	// ErrApprovalExpired is returned when deciding on a transfer approval after its expiry
+	ErrApprovalExpired = errors.New("transfer approval has expired")
+	// ErrSelfApproval is returned when the initiator of a transfer tries to decide on it
+	ErrSelfApproval = errors.New("a transfer cannot be approved or rejected by its initiator")
+	// ErrApprovalRequired is returned when a transfer above the approval threshold is executed without being held
+	ErrApprovalRequired = errors.New("the amount is above the approval threshold, the transfer must be approved by a second user")
+)
+
+// CreateTransferApprovalTxParams holds the transfer of TransferTxParams, its InitiatedBy cannot approve it
+type CreateTransferApprovalTxParams struct {
+	TransferTxParams
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CreateTransferApprovalTx holds a transfer until a second user approves it
+func (store *SQLStore) CreateTransferApprovalTx(ctx context.Context, arg CreateTransferApprovalTxParams) (TransferApproval, error) {
+	var approval TransferApproval
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		approval, err = store.requestTransferApproval(ctx, q, arg)
+		return err
+	})
+	return approval, err
+}
+
+// requestTransferApproval records the held transfer and the request event, a transfer the payee cooling-off
+// refuses is not held. It must run inside a transaction.
+func (store *SQLStore) requestTransferApproval(ctx context.Context, q *Queries, arg CreateTransferApprovalTxParams) (TransferApproval, error) {
+	toAccount, err := q.GetAccount(ctx, arg.ToAccountID)
+	if err != nil {
+		return TransferApproval{}, err
+	}
+	err = store.policy.checkPayeeCoolingOff(ctx, q, arg.InitiatedBy, toAccount, arg.Amount, time.Now())
+	if err != nil {
+		return TransferApproval{}, err
+	}
+
+	metadata := arg.Metadata
+	if len(metadata) == 0 {
+		metadata = []byte(`{}`)
+	}
+
+	approval, err := q.CreateTransferApproval(ctx, CreateTransferApprovalParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Description:   arg.Description,
+		Reference:     arg.Reference,
+		Metadata:      metadata,
+		InitiatedBy:   arg.InitiatedBy,
+		ExpiresAt:     arg.ExpiresAt,
+	})
+	if err != nil {
+		return approval, err
+	}
+
+	_, err = q.CreateTransferApprovalEvent(ctx, CreateTransferApprovalEventParams{
+		ApprovalID: approval.ID,
+		Action:     ApprovalActionRequested,
+		Actor:      sql.NullString{String: arg.InitiatedBy, Valid: true},
+	})
+	return approval, err
+}
+
+type DecideTransferApprovalTxParams struct {
+	ApprovalID int64  `json:"approval_id"`
+	Approver   string `json:"approver"`
+	Approve    bool   `json:"approve"`
+	Note       string `json:"note"`
+}
+
+type DecideTransferApprovalTxResult struct {
+	Approval TransferApproval  `json:"approval"`
+	Transfer *TransferTxResult `json:"transfer,omitempty"`
+}
+
+// DecideTransferApprovalTx approves or rejects a pending transfer, approving executes it.
+// A request past its expiry is marked expired and ErrApprovalExpired is returned.
+func (store *SQLStore) DecideTransferApprovalTx(ctx context.Context, arg DecideTransferApprovalTxParams) (DecideTransferApprovalTxResult, error) {
+	var result DecideTransferApprovalTxResult
+	expired := false
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		approval, err := q.GetTransferApprovalForUpdate(ctx, arg.ApprovalID)
+		if err != nil {
+			return err
+		}
+		if approval.Status != ApprovalStatusPending {
+			return ErrApprovalNotPending
+		}
+
+		// the expiry is committed so the audit trail records it, the caller still gets an error
+		if !approval.ExpiresAt.After(time.Now()) {
+			expired = true
+			result.Approval, err = expireApproval(ctx, q, approval)
+			return err
+		}
+
+		if approval.InitiatedBy == arg.Approver {
+			return ErrSelfApproval
+		}
+
+		status, action := ApprovalStatusRejected, ApprovalActionRejected
+		var transferID sql.NullInt64
+		if arg.Approve {
+			transfer, err := store.executeApprovedTransfer(ctx, q, TransferTxParams{
+				FromAccountID: approval.FromAccountID,
+				ToAccountID:   approval.ToAccountID,
+				Amount:        approval.Amount,
+				Description:   approval.Description,
+				Reference:     approval.Reference,
+				Metadata:      approval.Metadata,
+				InitiatedBy:   approval.InitiatedBy,
+			})
+			if err != nil {
+				return err
+			}
+			result.Transfer = &transfer
+
+			status, action = ApprovalStatusApproved, ApprovalActionApproved
+			transferID = sql.NullInt64{Int64: transfer.Transfer.ID, Valid: true}
+		}
+
+		result.Approval, err = q.DecideTransferApproval(ctx, DecideTransferApprovalParams{
+			ID:         approval.ID,
+			Status:     status,
+			DecidedBy:  sql.NullString{String: arg.Approver, Valid: true},
+			TransferID: transferID,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateTransferApprovalEvent(ctx, CreateTransferApprovalEventParams{
+			ApprovalID: approval.ID,
+			Action:     action,
+			Actor:      sql.NullString{String: arg.Approver, Valid: true},
+			Note:       arg.Note,
+		})
+		return err
+	})
+	if err == nil && expired {
+		err = ErrApprovalExpired
+	}
+	return result, err
+}
+
+// ExpireTransferApprovalsTx marks every request still pending at now as expired
+func (store *SQLStore) ExpireTransferApprovalsTx(ctx context.Context, now time.Time) ([]TransferApproval, error) {
+	var approvals []TransferApproval
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		approvals, err = q.ExpireTransferApprovals(ctx, now)
+		if err != nil {
+			return err
+		}
+
+		for _, approval := range approvals {
+			_, err = q.CreateTransferApprovalEvent(ctx, CreateTransferApprovalEventParams{
+				ApprovalID: approval.ID,
+				Action:     ApprovalActionExpired,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return approvals, err
+}
+
+func expireApproval(ctx context.Context, q *Queries, approval TransferApproval) (TransferApproval, error) {
+	approval, err := q.DecideTransferApproval(ctx, DecideTransferApprovalParams{
+		ID:     approval.ID,
+		Status: ApprovalStatusExpired,
+	})
+	if err != nil {
+		return approval, err
+	}
+
+	_, err = q.CreateTransferApprovalEvent(ctx, CreateTransferApprovalEventParams{
+		ApprovalID: approval.ID,
+		Action:     ApprovalActionExpired,
+	})
+	return approval, err
+}
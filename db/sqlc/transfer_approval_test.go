@@ -0,0 +1,178 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTransferApproval(t *testing.T, store Store, expiresAt time.Time) (TransferApproval, Account, Account) {
+	account1 := CreateRandomAccount(t)
+	account2 := CreateRandomAccount(t)
+
+	approval, err := store.CreateTransferApprovalTx(context.Background(), CreateTransferApprovalTxParams{
+		TransferTxParams: TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+			Description:   "invoice",
+			InitiatedBy:   account1.Owner,
+		},
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ApprovalStatusPending, approval.Status)
+	require.False(t, approval.DecidedAt.Valid)
+	require.False(t, approval.TransferID.Valid)
+	return approval, account1, account2
+}
+
+func TestApproveTransferTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	approval, account1, account2 := createRandomTransferApproval(t, store, time.Now().Add(time.Hour))
+	approver := CreateRandomUser(t)
+
+	_, err := store.DecideTransferApprovalTx(context.Background(), DecideTransferApprovalTxParams{
+		ApprovalID: approval.ID,
+		Approver:   approval.InitiatedBy,
+		Approve:    true,
+	})
+	require.ErrorIs(t, err, ErrSelfApproval)
+
+	result, err := store.DecideTransferApprovalTx(context.Background(), DecideTransferApprovalTxParams{
+		ApprovalID: approval.ID,
+		Approver:   approver.Username,
+		Approve:    true,
+		Note:       "looks good",
+	})
+	require.NoError(t, err)
+	require.Equal(t, ApprovalStatusApproved, result.Approval.Status)
+	require.Equal(t, approver.Username, result.Approval.DecidedBy.String)
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Approval.TransferID.Int64)
+	require.Equal(t, "invoice", result.Transfer.Transfer.Description)
+	require.Equal(t, account1.Balance-10, result.Transfer.FromAccount.Balance)
+	require.Equal(t, account2.Balance+10, result.Transfer.ToAccount.Balance)
+
+	_, err = store.DecideTransferApprovalTx(context.Background(), DecideTransferApprovalTxParams{
+		ApprovalID: approval.ID,
+		Approver:   approver.Username,
+	})
+	require.ErrorIs(t, err, ErrApprovalNotPending)
+
+	events, err := testQueries.ListTransferApprovalEvents(context.Background(), approval.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, ApprovalActionRequested, events[0].Action)
+	require.Equal(t, approval.InitiatedBy, events[0].Actor.String)
+	require.Equal(t, ApprovalActionApproved, events[1].Action)
+	require.Equal(t, "looks good", events[1].Note)
+}
+
+func TestRejectTransferTx(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+
+	approval, account1, _ := createRandomTransferApproval(t, store, time.Now().Add(time.Hour))
+	approver := CreateRandomUser(t)
+
+	result, err := store.DecideTransferApprovalTx(context.Background(), DecideTransferApprovalTxParams{
+		ApprovalID: approval.ID,
+		Approver:   approver.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ApprovalStatusRejected, result.Approval.Status)
+	require.Nil(t, result.Transfer)
+	require.False(t, result.Approval.TransferID.Valid)
+
+	account, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, account.Balance)
+}
+
+func TestExpireTransferApprovals(t *testing.T) {
+	store := NewStore(testDB, TransferPolicy{})
+	approver := CreateRandomUser(t)
+
+	stale, _, _ := createRandomTransferApproval(t, store, time.Now().Add(-time.Minute))
+
+	// deciding on a stale request records its expiry and fails
+	result, err := store.DecideTransferApprovalTx(context.Background(), DecideTransferApprovalTxParams{
+		ApprovalID: stale.ID,
+		Approver:   approver.Username,
+		Approve:    true,
+	})
+	require.ErrorIs(t, err, ErrApprovalExpired)
+	require.Equal(t, ApprovalStatusExpired, result.Approval.Status)
+
+	approval, _, _ := createRandomTransferApproval(t, store, time.Now().Add(time.Minute))
+
+	expired, err := store.ExpireTransferApprovalsTx(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	ids := make([]int64, len(expired))
+	for i, e := range expired {
+		require.Equal(t, ApprovalStatusExpired, e.Status)
+		ids[i] = e.ID
+	}
+	require.Contains(t, ids, approval.ID)
+	require.NotContains(t, ids, stale.ID)
+
+	events, err := testQueries.ListTransferApprovalEvents(context.Background(), approval.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, ApprovalActionExpired, events[1].Action)
+	require.False(t, events[1].Actor.Valid)
+}
+
+func TestApprovalThresholdTx(t *testing.T) {
+	account1 := CreateRandomAccount(t)
+	account1 = FundAccount(t, account1, 100000)
+	account2, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    CreateRandomUser(t).Username,
+		Currency: account1.Currency,
+		Type:     AccountTypeChecking,
+	})
+	require.NoError(t, err)
+
+	threshold, err := utils.NewMoney(1000, account1.Currency)
+	require.NoError(t, err)
+	store := NewStore(testDB, TransferPolicy{
+		ApprovalThresholds: map[string]utils.Money{account1.Currency: threshold},
+	})
+
+	arg := TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1001,
+		InitiatedBy:   account1.Owner,
+	}
+
+	// no caller skips the threshold by going to the store directly
+	_, err = store.TransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrApprovalRequired)
+
+	arg.Amount = 1000
+	_, err = store.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	// only an approved transfer goes above it
+	arg.Amount = 1001
+	approval, err := store.CreateTransferApprovalTx(context.Background(), CreateTransferApprovalTxParams{
+		TransferTxParams: arg,
+		ExpiresAt:        time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	result, err := store.DecideTransferApprovalTx(context.Background(), DecideTransferApprovalTxParams{
+		ApprovalID: approval.ID,
+		Approver:   CreateRandomUser(t).Username,
+		Approve:    true,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, int64(1001), result.Transfer.Transfer.Amount)
+}
@@ -0,0 +1,231 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	LimitScopeAccount = "account"
+	LimitScopeUser    = "user"
+	LimitScopeTier    = "tier"
+	// LimitScopeAccountType limits apply to each account of the type on its own
+	LimitScopeAccountType = "account_type"
+
+	LimitPeriodSingle  = "single"
+	LimitPeriodDaily   = "daily"
+	LimitPeriodMonthly = "monthly"
+
+	LimitKindAmount = "amount"
+	LimitKindCount  = "count"
+)
+
+// LimitStatus describes how much of a single configured limit has been used
+type LimitStatus struct {
+	LimitID   int64  `json:"limit_id"`
+	Scope     string `json:"scope"`
+	Period    string `json:"period"`
+	Kind      string `json:"kind"`
+	Limit     int64  `json:"limit"`
+	Used      int64  `json:"used"`
+	Remaining int64  `json:"remaining"`
+}
+
+// LimitExceededError is returned by TransferTx when a transfer would break a limit
+type LimitExceededError struct {
+	LimitStatus
+	Requested int64 `json:"requested"`
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s %s %s limit exceeded: limit %d, remaining %d", e.Scope, e.Period, e.Kind, e.Limit, e.Remaining)
+}
+
+// transferUsage lazily loads the outgoing transfer totals used to evaluate limits
+type transferUsage struct {
+	q        *Queries
+	account  Account
+	username string
+	now      time.Time
+	totals   map[string]GetAccountTransferTotalsRow
+}
+
+func newTransferUsage(q *Queries, account Account, username string, now time.Time) *transferUsage {
+	return &transferUsage{
+		q:        q,
+		account:  account,
+		username: username,
+		now:      now,
+		totals:   make(map[string]GetAccountTransferTotalsRow),
+	}
+}
+
+func (u *transferUsage) get(ctx context.Context, scope string, period string) (GetAccountTransferTotalsRow, error) {
+	if period == LimitPeriodSingle {
+		return GetAccountTransferTotalsRow{}, nil
+	}
+
+	since := startOfDay(u.now)
+	if period == LimitPeriodMonthly {
+		since = startOfMonth(u.now)
+	}
+
+	// account and account type limits only look at this account, user and tier limits
+	// look at every transfer the user made in the same currency, from any account
+	perAccount := scope == LimitScopeAccount || scope == LimitScopeAccountType
+
+	key := LimitScopeAccount
+	if !perAccount {
+		key = LimitScopeUser
+	}
+	key += "/" + period
+
+	if totals, ok := u.totals[key]; ok {
+		return totals, nil
+	}
+
+	var totals GetAccountTransferTotalsRow
+	if perAccount {
+		row, err := u.q.GetAccountTransferTotals(ctx, GetAccountTransferTotalsParams{
+			FromAccountID: u.account.ID,
+			Since:         since,
+		})
+		if err != nil {
+			return totals, err
+		}
+		totals = row
+	} else {
+		row, err := u.q.GetUserTransferTotals(ctx, GetUserTransferTotalsParams{
+			Username: u.username,
+			Currency: u.account.Currency,
+			Since:    since,
+		})
+		if err != nil {
+			return totals, err
+		}
+		totals = GetAccountTransferTotalsRow(row)
+	}
+
+	u.totals[key] = totals
+	return totals, nil
+}
+
+// evaluateTransferLimits returns the usage of every limit that applies to transfers the user makes from the account
+func evaluateTransferLimits(ctx context.Context, q *Queries, account Account, user User, now time.Time) ([]LimitStatus, error) {
+	limits, err := q.ListApplicableTransferLimits(ctx, ListApplicableTransferLimitsParams{
+		Currency:    account.Currency,
+		AccountID:   account.ID,
+		Username:    user.Username,
+		Tier:        user.Tier,
+		AccountType: account.Type,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usage := newTransferUsage(q, account, user.Username, now)
+	statuses := []LimitStatus{}
+
+	for _, limit := range limits {
+		checks := []struct {
+			period string
+			kind   string
+			value  sql.NullInt64
+		}{
+			{LimitPeriodSingle, LimitKindAmount, limit.MaxSingleAmount},
+			{LimitPeriodDaily, LimitKindAmount, limit.DailyAmount},
+			{LimitPeriodMonthly, LimitKindAmount, limit.MonthlyAmount},
+			{LimitPeriodDaily, LimitKindCount, limit.DailyCount},
+			{LimitPeriodMonthly, LimitKindCount, limit.MonthlyCount},
+		}
+
+		for _, check := range checks {
+			if !check.value.Valid {
+				continue
+			}
+			// minor units differ between currencies, limits created before amount limits
+			// required a currency only keep their count limits
+			if check.kind == LimitKindAmount && !limit.Currency.Valid {
+				continue
+			}
+
+			totals, err := usage.get(ctx, limit.Scope, check.period)
+			if err != nil {
+				return nil, err
+			}
+
+			used := totals.TotalAmount
+			if check.kind == LimitKindCount {
+				used = totals.TransferCount
+			}
+
+			remaining := check.value.Int64 - used
+			if remaining < 0 {
+				remaining = 0
+			}
+
+			statuses = append(statuses, LimitStatus{
+				LimitID:   limit.ID,
+				Scope:     limit.Scope,
+				Period:    check.period,
+				Kind:      check.kind,
+				Limit:     check.value.Int64,
+				Used:      used,
+				Remaining: remaining,
+			})
+		}
+	}
+
+	return statuses, nil
+}
+
+// checkTransferLimits fails with a LimitExceededError if the amount does not fit in every limit
+func checkTransferLimits(ctx context.Context, q *Queries, account Account, user User, amount int64, now time.Time) error {
+	statuses, err := evaluateTransferLimits(ctx, q, account, user, now)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		requested := amount
+		if status.Kind == LimitKindCount {
+			requested = 1
+		}
+
+		if requested > status.Remaining {
+			return &LimitExceededError{
+				LimitStatus: status,
+				Requested:   requested,
+			}
+		}
+	}
+
+	return nil
+}
+
+// AccountLimits returns the usage of every limit that applies to transfers the user makes from the account
+func (store *SQLStore) AccountLimits(ctx context.Context, accountID int64, username string) ([]LimitStatus, error) {
+	account, err := store.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := store.GetUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return evaluateTransferLimits(ctx, store.Queries, account, user, time.Now())
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func startOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
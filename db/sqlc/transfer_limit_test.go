@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func CreateRandomAccountLimit(t *testing.T, account Account) TransferLimit {
+	arg := CreateTransferLimitParams{
+		Scope:           LimitScopeAccount,
+		AccountID:       sql.NullInt64{Int64: account.ID, Valid: true},
+		Currency:        sql.NullString{String: account.Currency, Valid: true},
+		MaxSingleAmount: sql.NullInt64{Int64: 100, Valid: true},
+		DailyAmount:     sql.NullInt64{Int64: 150, Valid: true},
+		DailyCount:      sql.NullInt64{Int64: 3, Valid: true},
+	}
+	limit, err := testQueries.CreateTransferLimit(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, limit)
+
+	require.Equal(t, arg.Scope, limit.Scope)
+	require.Equal(t, arg.AccountID, limit.AccountID)
+	require.Equal(t, arg.MaxSingleAmount, limit.MaxSingleAmount)
+	require.Equal(t, arg.DailyAmount, limit.DailyAmount)
+	require.False(t, limit.MonthlyAmount.Valid)
+	require.NotZero(t, limit.ID)
+	require.NotZero(t, limit.CreatedAt)
+
+	return limit
+}
+
+func TestCreateTransferLimit(t *testing.T) {
+	account := CreateRandomAccount(t)
+	CreateRandomAccountLimit(t, account)
+}
+
+func TestListApplicableTransferLimits(t *testing.T) {
+	account := CreateRandomAccount(t)
+	other := CreateRandomAccount(t)
+
+	accountLimit := CreateRandomAccountLimit(t, account)
+	CreateRandomAccountLimit(t, other)
+
+	userLimit, err := testQueries.CreateTransferLimit(context.Background(), CreateTransferLimitParams{
+		Scope:         LimitScopeUser,
+		Username:      sql.NullString{String: account.Owner, Valid: true},
+		Currency:      sql.NullString{String: account.Currency, Valid: true},
+		MonthlyAmount: sql.NullInt64{Int64: 5000, Valid: true},
+	})
+	require.NoError(t, err)
+
+	limits, err := testQueries.ListApplicableTransferLimits(context.Background(), ListApplicableTransferLimitsParams{
+		Currency:    account.Currency,
+		AccountID:   account.ID,
+		Username:    account.Owner,
+		Tier:        "standard",
+		AccountType: account.Type,
+	})
+	require.NoError(t, err)
+
+	ids := make(map[int64]bool)
+	for _, limit := range limits {
+		ids[limit.ID] = true
+	}
+	require.True(t, ids[accountLimit.ID])
+	require.True(t, ids[userLimit.ID])
+	require.Len(t, ids, len(limits))
+	for _, limit := range limits {
+		if limit.Scope == LimitScopeAccount {
+			require.Equal(t, account.ID, limit.AccountID.Int64)
+		}
+	}
+}
+
+func TestCreateTransferLimitAmountWithoutCurrency(t *testing.T) {
+	user := CreateRandomUser(t)
+
+	// amounts are in minor units of a currency, they mean nothing without one
+	_, err := testQueries.CreateTransferLimit(context.Background(), CreateTransferLimitParams{
+		Scope:       LimitScopeUser,
+		Username:    sql.NullString{String: user.Username, Valid: true},
+		DailyAmount: sql.NullInt64{Int64: 5000, Valid: true},
+	})
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, "check_violation", pqErr.Code.Name())
+
+	// counts apply across currencies
+	limit, err := testQueries.CreateTransferLimit(context.Background(), CreateTransferLimitParams{
+		Scope:      LimitScopeUser,
+		Username:   sql.NullString{String: user.Username, Valid: true},
+		DailyCount: sql.NullInt64{Int64: 10, Valid: true},
+	})
+	require.NoError(t, err)
+	require.False(t, limit.Currency.Valid)
+}
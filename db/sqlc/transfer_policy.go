@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+// TransferPolicy holds the rules of the deployment that every transfer a user initiates follows,
+// whichever endpoint or import started it
+type TransferPolicy struct {
+	// ApprovalThresholds hold transfers above the amount of their currency until a second user approves them
+	ApprovalThresholds map[string]utils.Money
+	// PayeeCoolingOff is how long after a payee is saved transfers to its account are capped
+	PayeeCoolingOff time.Duration
+	// PayeeCoolingLimits caps transfers to the account of a new payee, by currency
+	PayeeCoolingLimits map[string]utils.Money
+}
+
+// NewTransferPolicy reads the transfer rules of the config
+func NewTransferPolicy(config utils.Config) (TransferPolicy, error) {
+	approvalThresholds, err := utils.ParseCurrencyAmounts(config.ApprovalThresholds)
+	if err != nil {
+		return TransferPolicy{}, fmt.Errorf("cannot parse approval thresholds: %w", err)
+	}
+
+	coolingLimits, err := utils.ParseCurrencyAmounts(config.PayeeCoolingLimits)
+	if err != nil {
+		return TransferPolicy{}, fmt.Errorf("cannot parse payee cooling-off limits: %w", err)
+	}
+
+	return TransferPolicy{
+		ApprovalThresholds: approvalThresholds,
+		PayeeCoolingOff:    config.PayeeCoolingOff,
+		PayeeCoolingLimits: coolingLimits,
+	}, nil
+}
+
+// requiresApproval reports whether the amount, in minor units of the currency, is above the approval threshold
+func (policy TransferPolicy) requiresApproval(currency string, amount int64) bool {
+	threshold, ok := policy.ApprovalThresholds[currency]
+	return ok && amount > threshold.Amount()
+}
+
+// PayeeCoolingOffError refuses a transfer above the cooling-off limit to the account of a new payee
+type PayeeCoolingOffError struct {
+	PayeeID int64
+	Limit   utils.Money
+	Until   time.Time
+}
+
+func (e *PayeeCoolingOffError) Error() string {
+	return fmt.Sprintf("payee %d is new, transfers above %s are allowed from %s", e.PayeeID, e.Limit, e.Until.Format(time.RFC3339))
+}
+
+// checkPayeeCoolingOff fails with a PayeeCoolingOffError when the amount is above the cooling-off limit and
+// a payee the initiator saved recently pays into the account. Deleted payees count, deleting a new payee
+// and paying its account directly does not get around the cooling-off.
+func (policy TransferPolicy) checkPayeeCoolingOff(ctx context.Context, q Querier, initiator string, account Account, amount int64, now time.Time) error {
+	limit, ok := policy.PayeeCoolingLimits[account.Currency]
+	if !ok || policy.PayeeCoolingOff <= 0 || amount <= limit.Amount() {
+		return nil
+	}
+
+	payees, err := q.ListNewPayees(ctx, ListNewPayeesParams{
+		Owner:        initiator,
+		CreatedAfter: now.Add(-policy.PayeeCoolingOff),
+	})
+	if err != nil {
+		return err
+	}
+	for _, payee := range payees {
+		if payeePays(payee, account) {
+			return &PayeeCoolingOffError{
+				PayeeID: payee.ID,
+				Limit:   limit,
+				Until:   payee.CreatedAt.Add(policy.PayeeCoolingOff),
+			}
+		}
+	}
+	return nil
+}
+
+// payeePays reports whether transfers to the payee can land on the account
+func payeePays(payee Payee, account Account) bool {
+	if payee.AccountID.Valid {
+		return payee.AccountID.Int64 == account.ID
+	}
+	return payee.Username.String == account.Owner && account.Type == AccountTypeChecking && !account.OrgID.Valid
+}
+
+// CheckPayeeCoolingOff runs the cooling-off check of a transfer the initiator makes to the account without
+// making it, so a dry run reports what executing the transfer would
+func (store *SQLStore) CheckPayeeCoolingOff(ctx context.Context, initiator string, account Account, amount int64) error {
+	return store.policy.checkPayeeCoolingOff(ctx, store.Queries, initiator, account, amount, time.Now())
+}
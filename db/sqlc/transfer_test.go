@@ -3,9 +3,11 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"testing"
+	"time"
 
-	"github.com/lordofthemind/backendMaster/utils"
+	"github.com/lordofthemind/backendMasterGo/utils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -13,10 +15,14 @@ func CreateRandomTransfer(t *testing.T) Transfer {
 	fromAccount := CreateRandomAccount(t)
 	toAccount := CreateRandomAccount(t)
 
+	rg := utils.NewRandomGenerator()
 	arg := CreateTransferParams{
 		FromAccountID: fromAccount.ID,
 		ToAccountID:   toAccount.ID,
 		Amount:        10,
+		Description:   rg.RandomString(12),
+		Reference:     rg.RandomString(8),
+		Metadata:      json.RawMessage(`{"source": "test"}`),
 	}
 	transfer, err := testQueries.CreateTransfer(context.Background(), arg)
 	require.NoError(t, err)
@@ -25,6 +31,9 @@ func CreateRandomTransfer(t *testing.T) Transfer {
 	require.Equal(t, arg.FromAccountID, transfer.FromAccountID)
 	require.Equal(t, arg.ToAccountID, transfer.ToAccountID)
 	require.Equal(t, arg.Amount, transfer.Amount)
+	require.Equal(t, arg.Description, transfer.Description)
+	require.Equal(t, arg.Reference, transfer.Reference)
+	require.JSONEq(t, string(arg.Metadata), string(transfer.Metadata))
 	require.NotZero(t, transfer.ID)
 	require.NotZero(t, transfer.CreatedAt)
 
@@ -41,6 +50,7 @@ func CreateRandomTransferWithAccount(t *testing.T, accountID int64) Transfer {
 		FromAccountID: accountID,
 		ToAccountID:   accountID, // Same account for simplicity, adjust as needed
 		Amount:        amount,
+		Metadata:      json.RawMessage(`{}`),
 	})
 	require.NoError(t, err)
 
@@ -51,15 +61,18 @@ func TestCreateTransfer(t *testing.T) {
 	CreateRandomTransfer(t)
 }
 
-func TestDeleteTransfer(t *testing.T) {
+func TestTransfersAreAppendOnly(t *testing.T) {
 	transfer := CreateRandomTransfer(t)
-	err := testQueries.DeleteTransfer(context.Background(), transfer.ID)
-	require.NoError(t, err)
 
-	transfer2, err := testQueries.GetTransfer(context.Background(), transfer.ID)
+	_, err := testDB.ExecContext(context.Background(), "UPDATE transfers SET amount = amount + 1 WHERE id = $1", transfer.ID)
+	require.Error(t, err)
+
+	_, err = testDB.ExecContext(context.Background(), "DELETE FROM transfers WHERE id = $1", transfer.ID)
 	require.Error(t, err)
-	require.EqualError(t, err, sql.ErrNoRows.Error())
-	require.Empty(t, transfer2)
+
+	transfer2, err := testQueries.GetTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, transfer, transfer2)
 }
 
 func TestGetTransfer(t *testing.T) {
@@ -70,6 +83,7 @@ func TestGetTransfer(t *testing.T) {
 		FromAccountID: fromAccount.ID,
 		ToAccountID:   toAccount.ID,
 		Amount:        10,
+		Metadata:      json.RawMessage(`{}`),
 	}
 	transfer1, err := testQueries.CreateTransfer(context.Background(), arg)
 	require.NoError(t, err)
@@ -111,3 +125,86 @@ func TestListTransfers(t *testing.T) {
 		require.NotEmpty(t, transfer)
 	}
 }
+
+func TestListTransfersByReference(t *testing.T) {
+	transfer1 := CreateRandomTransfer(t)
+	CreateRandomTransfer(t)
+
+	fromAccount, err := testQueries.GetAccount(context.Background(), transfer1.FromAccountID)
+	require.NoError(t, err)
+
+	transfers, err := testQueries.ListTransfersByReference(context.Background(), ListTransfersByReferenceParams{
+		Reference: transfer1.Reference,
+		Username:  fromAccount.Owner,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, transfer1.ID, transfers[0].ID)
+	require.Equal(t, fromAccount.Currency, transfers[0].Currency)
+
+	transfers, err = testQueries.ListTransfersByReference(context.Background(), ListTransfersByReferenceParams{
+		Reference: transfer1.Reference,
+		Username:  "someone_else",
+	})
+	require.NoError(t, err)
+	require.Empty(t, transfers)
+}
+
+func TestListAccountTransfers(t *testing.T) {
+	account1 := CreateRandomEmptyAccount(t)
+	account2 := CreateRandomEmptyAccount(t)
+
+	for _, amount := range []int64{10, 20, 30} {
+		_, err := testQueries.CreateTransfer(context.Background(), CreateTransferParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+			Metadata:      json.RawMessage(`{}`),
+		})
+		require.NoError(t, err)
+	}
+	_, err := testQueries.CreateTransfer(context.Background(), CreateTransferParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        40,
+		Metadata:      json.RawMessage(`{}`),
+	})
+	require.NoError(t, err)
+
+	transfers, err := testQueries.ListAccountTransfers(context.Background(), ListAccountTransfersParams{
+		AccountID: account1.ID,
+		PageLimit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 4)
+
+	transfers, err = testQueries.ListAccountTransfers(context.Background(), ListAccountTransfersParams{
+		AccountID: account1.ID,
+		Direction: sql.NullString{String: "out", Valid: true},
+		MinAmount: sql.NullInt64{Int64: 20, Valid: true},
+		PageLimit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 2)
+	for _, transfer := range transfers {
+		require.Equal(t, account1.ID, transfer.FromAccountID)
+		require.GreaterOrEqual(t, transfer.Amount, int64(20))
+	}
+
+	transfers, err = testQueries.ListAccountTransfers(context.Background(), ListAccountTransfersParams{
+		AccountID: account1.ID,
+		Direction: sql.NullString{String: "in", Valid: true},
+		PageLimit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, account1.ID, transfers[0].ToAccountID)
+
+	transfers, err = testQueries.ListAccountTransfers(context.Background(), ListAccountTransfersParams{
+		AccountID: account1.ID,
+		StartTime: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+		PageLimit: 10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, transfers)
+}
@@ -55,6 +55,7 @@ require (
 )
 
 require (
+	github.com/boombuler/barcode v1.1.0
 	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
 	github.com/gin-gonic/gin v1.9.1
 	github.com/lordofthemind/backendMaster v0.0.0-20240412060128-2f6bcb0a25ee
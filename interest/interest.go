@@ -0,0 +1,211 @@
+// Package interest accrues daily interest on interest bearing accounts and
+// capitalizes it once a month.
+//
+// Interest is accrued every day on the end-of-day balance using the rate of the
+// account's product (account type and currency) in effect on that day:
+//
+//	accrual = balance * annual_rate_ppm / days_in_year
+//
+// The result is in millionths of the minor currency unit ("micros"), rounded
+// half to even, and days_in_year is 365 or 366 (actual/actual). Negative
+// balances do not accrue interest. Capitalization pays the whole minor units
+// of the month's accruals and carries the remaining micros to the next month.
+package interest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+)
+
+// DaysInYear returns the number of days in the year of day
+func DaysInYear(day time.Time) int64 {
+	year := day.Year()
+	if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		return 366
+	}
+	return 365
+}
+
+// DailyAccrual returns the interest earned by balance on day, in micros
+func DailyAccrual(balance int64, annualRatePPM int64, day time.Time) (int64, error) {
+	if balance <= 0 || annualRatePPM <= 0 {
+		return 0, nil
+	}
+
+	days := big.NewInt(DaysInYear(day))
+	num := new(big.Int).Mul(big.NewInt(balance), big.NewInt(annualRatePPM))
+
+	quo, rem := new(big.Int).QuoRem(num, days, new(big.Int))
+
+	// round half to even
+	switch new(big.Int).Lsh(rem, 1).Cmp(days) {
+	case 1:
+		quo.Add(quo, big.NewInt(1))
+	case 0:
+		if quo.Bit(0) == 1 {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	if !quo.IsInt64() {
+		return 0, fmt.Errorf("interest on balance %d overflows", balance)
+	}
+	return quo.Int64(), nil
+}
+
+// Date truncates t to midnight UTC
+func Date(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// MonthBounds returns the first and the last day of the month of t
+func MonthBounds(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, -1)
+}
+
+type Engine struct {
+	store db.Store
+	now   func() time.Time
+}
+
+func NewEngine(store db.Store) *Engine {
+	return &Engine{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+type AccrualSummary struct {
+	Days     int `json:"days"`
+	Accounts int `json:"accounts"`
+	// Skipped counts accruals that were left alone because they were already capitalized
+	Skipped     int   `json:"skipped"`
+	TotalMicros int64 `json:"total_micros"`
+}
+
+// AccrueDay records the interest of every interest bearing account for a day that has ended.
+// Running it again for the same day recomputes the same accruals, so it is safe to retry.
+func (engine *Engine) AccrueDay(ctx context.Context, day time.Time) (AccrualSummary, error) {
+	var summary AccrualSummary
+
+	day = Date(day)
+	dayEnd := day.AddDate(0, 0, 1)
+	if dayEnd.After(engine.now()) {
+		return summary, fmt.Errorf("%s has not ended yet", day.Format(time.DateOnly))
+	}
+
+	balances, err := engine.store.ListInterestBearingBalances(ctx, db.ListInterestBearingBalancesParams{
+		DayEnd: dayEnd,
+		Day:    day,
+	})
+	if err != nil {
+		return summary, err
+	}
+
+	summary.Days = 1
+	for _, balance := range balances {
+		amount, err := DailyAccrual(balance.Balance, balance.AnnualRatePpm, day)
+		if err != nil {
+			return summary, fmt.Errorf("account %d: %w", balance.AccountID, err)
+		}
+
+		rows, err := engine.store.UpsertInterestAccrual(ctx, db.UpsertInterestAccrualParams{
+			AccountID:     balance.AccountID,
+			AccrualDate:   day,
+			Balance:       balance.Balance,
+			AnnualRatePpm: balance.AnnualRatePpm,
+			AmountMicros:  amount,
+		})
+		if err != nil {
+			return summary, fmt.Errorf("account %d: %w", balance.AccountID, err)
+		}
+		if rows == 0 {
+			summary.Skipped++
+			continue
+		}
+
+		summary.Accounts++
+		summary.TotalMicros += amount
+	}
+
+	return summary, nil
+}
+
+// Backfill recomputes the accruals of every day from from to to, both included
+func (engine *Engine) Backfill(ctx context.Context, from time.Time, to time.Time) (AccrualSummary, error) {
+	var summary AccrualSummary
+
+	from, to = Date(from), Date(to)
+	if to.Before(from) {
+		return summary, errors.New("backfill range ends before it starts")
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		daily, err := engine.AccrueDay(ctx, day)
+		if err != nil {
+			return summary, fmt.Errorf("%s: %w", day.Format(time.DateOnly), err)
+		}
+
+		summary.Days++
+		summary.Accounts += daily.Accounts
+		summary.Skipped += daily.Skipped
+		summary.TotalMicros += daily.TotalMicros
+	}
+
+	return summary, nil
+}
+
+type CapitalizationSummary struct {
+	Accounts int   `json:"accounts"`
+	Amount   int64 `json:"amount"`
+	// Failed maps the accounts that could not be capitalized to the reason
+	Failed map[int64]string `json:"failed,omitempty"`
+}
+
+// CapitalizeMonth pays the interest accrued during the month of t. Accounts that are
+// already capitalized for the month are skipped, so the job can be run again after a failure.
+func (engine *Engine) CapitalizeMonth(ctx context.Context, month time.Time) (CapitalizationSummary, error) {
+	summary := CapitalizationSummary{Failed: make(map[int64]string)}
+
+	start, end := MonthBounds(month)
+	if !end.AddDate(0, 0, 1).Before(engine.now()) {
+		return summary, fmt.Errorf("%s has not ended yet", start.Format("2006-01"))
+	}
+
+	accountIDs, err := engine.store.ListAccountsWithUncapitalizedInterest(ctx, db.ListAccountsWithUncapitalizedInterestParams{
+		PeriodStart: start,
+		PeriodEnd:   end,
+	})
+	if err != nil {
+		return summary, err
+	}
+
+	for _, accountID := range accountIDs {
+		result, err := engine.store.CapitalizeInterestTx(ctx, db.CapitalizeInterestTxParams{
+			AccountID:   accountID,
+			PeriodStart: start,
+			PeriodEnd:   end,
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrInterestAlreadyCapitalized) {
+				continue
+			}
+			// one blocked account must not stop everyone else from being paid
+			summary.Failed[accountID] = err.Error()
+			continue
+		}
+
+		summary.Accounts++
+		summary.Amount += result.Capitalization.Amount
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,163 @@
+package interest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestDailyAccrual(t *testing.T) {
+	testCases := []struct {
+		name    string
+		balance int64
+		rate    int64
+		day     time.Time
+		want    int64
+	}{
+		{"CommonYear", 10000, 50000, date("2025-03-01"), 1369863},
+		{"LeapYear", 10000, 50000, date("2024-03-01"), 1366120},
+		{"HalfRoundsToEvenDown", 183, 1, date("2024-03-01"), 0},
+		{"HalfRoundsToEvenUp", 549, 1, date("2024-03-01"), 2},
+		{"NegativeBalance", -10000, 50000, date("2025-03-01"), 0},
+		{"ZeroRate", 10000, 0, date("2025-03-01"), 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DailyAccrual(tc.balance, tc.rate, tc.day)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := DailyAccrual(math.MaxInt64, 1_000_000, date("2025-03-01"))
+	require.Error(t, err)
+}
+
+func TestMonthBounds(t *testing.T) {
+	start, end := MonthBounds(date("2024-02-17"))
+	require.Equal(t, date("2024-02-01"), start)
+	require.Equal(t, date("2024-02-29"), end)
+}
+
+func TestAccrueDay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	engine := NewEngine(store)
+	engine.now = func() time.Time { return date("2025-03-02").Add(time.Hour) }
+
+	day := date("2025-03-01")
+	balances := []db.ListInterestBearingBalancesRow{
+		{AccountID: 1, AnnualRatePpm: 50000, Balance: 10000},
+		{AccountID: 2, AnnualRatePpm: 50000, Balance: 20000},
+	}
+
+	store.EXPECT().ListInterestBearingBalances(gomock.Any(), gomock.Eq(db.ListInterestBearingBalancesParams{
+		DayEnd: date("2025-03-02"),
+		Day:    day,
+	})).Times(1).Return(balances, nil)
+
+	store.EXPECT().UpsertInterestAccrual(gomock.Any(), gomock.Eq(db.UpsertInterestAccrualParams{
+		AccountID:     1,
+		AccrualDate:   day,
+		Balance:       10000,
+		AnnualRatePpm: 50000,
+		AmountMicros:  1369863,
+	})).Times(1).Return(int64(1), nil)
+
+	// account 2 was already capitalized for that day
+	store.EXPECT().UpsertInterestAccrual(gomock.Any(), gomock.Eq(db.UpsertInterestAccrualParams{
+		AccountID:     2,
+		AccrualDate:   day,
+		Balance:       20000,
+		AnnualRatePpm: 50000,
+		AmountMicros:  2739726,
+	})).Times(1).Return(int64(0), nil)
+
+	summary, err := engine.AccrueDay(context.Background(), day)
+	require.NoError(t, err)
+	require.Equal(t, AccrualSummary{Days: 1, Accounts: 1, Skipped: 1, TotalMicros: 1369863}, summary)
+
+	// the current day has no end-of-day balance yet
+	_, err = engine.AccrueDay(context.Background(), date("2025-03-02"))
+	require.Error(t, err)
+}
+
+func TestBackfill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	engine := NewEngine(store)
+	engine.now = func() time.Time { return date("2025-04-01") }
+
+	store.EXPECT().ListInterestBearingBalances(gomock.Any(), gomock.Any()).Times(3).
+		Return([]db.ListInterestBearingBalancesRow{{AccountID: 1, AnnualRatePpm: 36500, Balance: 1000}}, nil)
+	store.EXPECT().UpsertInterestAccrual(gomock.Any(), gomock.Any()).Times(3).Return(int64(1), nil)
+
+	summary, err := engine.Backfill(context.Background(), date("2025-03-01"), date("2025-03-03"))
+	require.NoError(t, err)
+	require.Equal(t, 3, summary.Days)
+	require.Equal(t, 3, summary.Accounts)
+	require.Equal(t, int64(3*100000), summary.TotalMicros)
+
+	_, err = engine.Backfill(context.Background(), date("2025-03-03"), date("2025-03-01"))
+	require.Error(t, err)
+}
+
+func TestCapitalizeMonth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	engine := NewEngine(store)
+	engine.now = func() time.Time { return date("2025-03-01").Add(time.Minute) }
+
+	params := func(accountID int64) db.CapitalizeInterestTxParams {
+		return db.CapitalizeInterestTxParams{
+			AccountID:   accountID,
+			PeriodStart: date("2025-02-01"),
+			PeriodEnd:   date("2025-02-28"),
+		}
+	}
+
+	store.EXPECT().ListAccountsWithUncapitalizedInterest(gomock.Any(), gomock.Eq(db.ListAccountsWithUncapitalizedInterestParams{
+		PeriodStart: date("2025-02-01"),
+		PeriodEnd:   date("2025-02-28"),
+	})).Times(1).Return([]int64{1, 2, 3}, nil)
+
+	store.EXPECT().CapitalizeInterestTx(gomock.Any(), gomock.Eq(params(1))).Times(1).
+		Return(db.CapitalizeInterestTxResult{Capitalization: db.InterestCapitalization{Amount: 5}}, nil)
+	store.EXPECT().CapitalizeInterestTx(gomock.Any(), gomock.Eq(params(2))).Times(1).
+		Return(db.CapitalizeInterestTxResult{}, db.ErrInterestAlreadyCapitalized)
+	store.EXPECT().CapitalizeInterestTx(gomock.Any(), gomock.Eq(params(3))).Times(1).
+		Return(db.CapitalizeInterestTxResult{}, errors.New("account 3 is frozen"))
+
+	summary, err := engine.CapitalizeMonth(context.Background(), date("2025-02-10"))
+	require.NoError(t, err)
+	require.Equal(t, 1, summary.Accounts)
+	require.Equal(t, int64(5), summary.Amount)
+	require.Len(t, summary.Failed, 1)
+	require.Contains(t, summary.Failed, int64(3))
+
+	// March is not over yet
+	_, err = engine.CapitalizeMonth(context.Background(), date("2025-03-01"))
+	require.Error(t, err)
+}
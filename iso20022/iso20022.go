@@ -0,0 +1,118 @@
+// Package iso20022 reads and writes the ISO 20022 payment messages exchanged with corporate clients:
+// pain.001 credit transfer initiations come in, pain.002 status reports go out, and
+// camt.053 statements are described by Camt053 for the statement package to render.
+//
+// Accounts of the bank are identified by their number in Othr/Id, IBANs are not issued.
+package iso20022
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+const namespacePrefix = "urn:iso:std:iso:20022:tech:xsd:"
+
+// Transaction and group statuses of a status report
+const (
+	StatusSettled  = "ACSC"
+	StatusPending  = "PDNG"
+	StatusRejected = "RJCT"
+	// StatusPartial is a group where some transactions were rejected and others were not
+	StatusPartial = "PART"
+)
+
+// External status reason codes explaining a rejection
+const (
+	ReasonIncorrectAccount   = "AC01"
+	ReasonClosedAccount      = "AC04"
+	ReasonBlockedAccount     = "AC06"
+	ReasonForbidden          = "AG01"
+	ReasonNotAllowedAmount   = "AM02"
+	ReasonNotAllowedCurrency = "AM03"
+	ReasonDuplicate          = "AM05"
+	ReasonInvalidAmount      = "AM12"
+	ReasonInvalidDate        = "DT01"
+	ReasonNarrative          = "NARR"
+)
+
+// Credit and debit indicators
+const (
+	Credit = "CRDT"
+	Debit  = "DBIT"
+)
+
+var errNotAnAccountNumber = errors.New("only account numbers of this bank are supported, IBANs are not")
+
+// MessageName returns the message of a namespace, "pain.001.001.09" for its namespace
+func MessageName(namespace string) string {
+	return strings.TrimPrefix(namespace, namespacePrefix)
+}
+
+// Namespace returns the namespace of a message name
+func Namespace(message string) string {
+	return namespacePrefix + message
+}
+
+// ParseAmount parses an ActiveOrHistoricCurrencyAndAmount, which has no sign and may carry
+// trailing zeros past the minor units of the currency
+func ParseAmount(value string, currency string) (utils.Money, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		return utils.Money{}, fmt.Errorf("%w %q", utils.ErrInvalidAmount, value)
+	}
+	if whole, fraction, ok := strings.Cut(value, "."); ok {
+		fraction = strings.TrimRight(fraction, "0")
+		if fraction == "" {
+			value = whole
+		} else {
+			value = whole + "." + fraction
+		}
+	}
+	return utils.ParseMoney(value, currency)
+}
+
+// FormatAmount formats the magnitude of an amount, the sign goes in a CdtDbtInd
+func FormatAmount(amount utils.Money) string {
+	return strings.TrimPrefix(amount.Decimal(), "-")
+}
+
+// Indicator returns Debit for negative amounts and Credit otherwise
+func Indicator(amount utils.Money) string {
+	if amount.IsNegative() {
+		return Debit
+	}
+	return Credit
+}
+
+// AccountNumber returns the account identified by Othr/Id
+func (id AccountIdentification) AccountNumber() (int64, error) {
+	if id.Other == nil {
+		return 0, errNotAnAccountNumber
+	}
+	number, err := strconv.ParseInt(strings.TrimSpace(id.Other.ID), 10, 64)
+	if err != nil || number < 1 {
+		return 0, fmt.Errorf("invalid account number %q", id.Other.ID)
+	}
+	return number, nil
+}
+
+// String is the identification as it appears in the message
+func (id AccountIdentification) String() string {
+	if id.Other != nil {
+		return id.Other.ID
+	}
+	return id.IBAN
+}
+
+// Truncate cuts s to at most n characters, the maximum length of a text element
+func Truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
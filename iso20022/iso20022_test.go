@@ -0,0 +1,392 @@
+package iso20022
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+const pain001 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09">
+  <CstmrCdtTrfInitn>
+    <GrpHdr>
+      <MsgId>PAYROLL-2026-10</MsgId>
+      <CreDtTm>2026-10-15T09:30:00+02:00</CreDtTm>
+      <NbOfTxs>3</NbOfTxs>
+      <CtrlSum>1750.75</CtrlSum>
+      <InitgPty><Nm>Acme Corp</Nm></InitgPty>
+    </GrpHdr>
+    <PmtInf>
+      <PmtInfId>SALARIES</PmtInfId>
+      <PmtMtd>TRF</PmtMtd>
+      <NbOfTxs>2</NbOfTxs>
+      <CtrlSum>1500.50</CtrlSum>
+      <ReqdExctnDt><Dt>2026-10-16</Dt></ReqdExctnDt>
+      <Dbtr><Nm>Acme Corp</Nm><PstlAdr><Ctry>DE</Ctry></PstlAdr></Dbtr>
+      <DbtrAcct><Id><Othr><Id>10</Id></Othr></Id><Ccy>EUR</Ccy></DbtrAcct>
+      <DbtrAgt><FinInstnId><BICFI>SIMPLEBKXXX</BICFI></FinInstnId></DbtrAgt>
+      <CdtTrfTxInf>
+        <PmtId><InstrId>I-1</InstrId><EndToEndId>E2E-1</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="EUR">1000.50000</InstdAmt></Amt>
+        <Cdtr><Nm>Jane Doe</Nm></Cdtr>
+        <CdtrAcct><Id><Othr><Id>20</Id></Othr></Id></CdtrAcct>
+        <RmtInf><Ustrd>Salary</Ustrd><Ustrd>October</Ustrd></RmtInf>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>NOTPROVIDED</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="EUR">500</InstdAmt></Amt>
+        <CdtrAcct><Id><IBAN>DE89370400440532013000</IBAN></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+    <PmtInf>
+      <PmtInfId>EXPENSES</PmtInfId>
+      <PmtMtd>TRF</PmtMtd>
+      <ReqdExctnDt><DtTm>2026-10-16T12:00:00Z</DtTm></ReqdExctnDt>
+      <Dbtr><Nm>Acme Corp</Nm></Dbtr>
+      <DbtrAcct><Id><Othr><Id>11</Id></Othr></Id></DbtrAcct>
+      <DbtrAgt><FinInstnId/></DbtrAgt>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-3</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">250.25</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>30</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>
+`
+
+const pain001V03 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03">
+  <CstmrCdtTrfInitn>
+    <GrpHdr>
+      <MsgId>LEGACY-1</MsgId>
+      <CreDtTm>2026-10-15T09:30:00</CreDtTm>
+      <NbOfTxs>1</NbOfTxs>
+      <InitgPty><Nm>Acme Corp</Nm></InitgPty>
+    </GrpHdr>
+    <PmtInf>
+      <PmtInfId>P-1</PmtInfId>
+      <PmtMtd>TRF</PmtMtd>
+      <ReqdExctnDt>2026-10-16</ReqdExctnDt>
+      <Dbtr><Nm>Acme Corp</Nm></Dbtr>
+      <DbtrAcct><Id><Othr><Id>10</Id></Othr></Id></DbtrAcct>
+      <DbtrAgt><FinInstnId><BIC>SIMPLEBKXXX</BIC></FinInstnId></DbtrAgt>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-1</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="EUR">10.00</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>20</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>
+`
+
+func TestParsePain001(t *testing.T) {
+	message, err := ParsePain001(strings.NewReader(pain001))
+	require.NoError(t, err)
+
+	require.Equal(t, Pain001V09, message.Namespace)
+	require.Equal(t, "pain.001.001.09", MessageName(message.Namespace))
+	require.Equal(t, "PAYROLL-2026-10", message.GroupHeader.MessageID)
+	require.Equal(t, "Acme Corp", message.GroupHeader.InitiatingParty.Name)
+	require.True(t, message.GroupHeader.CreatedAt().Equal(time.Date(2026, 10, 15, 7, 30, 0, 0, time.UTC)))
+	require.Len(t, message.PaymentInformation, 2)
+
+	salaries := message.PaymentInformation[0]
+	require.Equal(t, "SALARIES", salaries.ID)
+	day, err := salaries.RequestedExecutionDate.Day()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), day)
+
+	debtor, err := salaries.DebtorAccount.ID.AccountNumber()
+	require.NoError(t, err)
+	require.Equal(t, int64(10), debtor)
+	require.Len(t, salaries.Transactions, 2)
+
+	first := salaries.Transactions[0]
+	require.Equal(t, "I-1", first.PaymentID.InstructionID)
+	require.Equal(t, "E2E-1", first.PaymentID.EndToEndID)
+	require.Equal(t, "Salary October", first.RemittanceInformation.String())
+	require.Equal(t, "Jane Doe", first.Creditor.Name)
+
+	amount, err := ParseAmount(first.Amount.Value().Value, first.Amount.Value().Currency)
+	require.NoError(t, err)
+	require.Equal(t, "1000.50", amount.Decimal())
+
+	// IBANs are valid in the message but do not identify an account of the bank
+	second := salaries.Transactions[1]
+	require.Equal(t, NotProvided, second.PaymentID.EndToEndID)
+	require.Equal(t, "DE89370400440532013000", second.CreditorAccount.ID.String())
+	_, err = second.CreditorAccount.ID.AccountNumber()
+	require.Error(t, err)
+
+	day, err = message.PaymentInformation[1].RequestedExecutionDate.Day()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), day)
+}
+
+func TestParsePain001V03(t *testing.T) {
+	message, err := ParsePain001(strings.NewReader(pain001V03))
+	require.NoError(t, err)
+	require.Equal(t, Pain001V03, message.Namespace)
+
+	day, err := message.PaymentInformation[0].RequestedExecutionDate.Day()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), day)
+	require.True(t, message.GroupHeader.CreatedAt().Equal(time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC)))
+}
+
+func TestParsePain001Invalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		old     string
+		new     string
+		message string
+	}{
+		{
+			name:    "UnknownVersion",
+			old:     "pain.001.001.09",
+			new:     "pain.001.001.12",
+			message: "unsupported message namespace",
+		},
+		{
+			name:    "NotXML",
+			old:     "<CstmrCdtTrfInitn>",
+			new:     "<CstmrCdtTrfInitn",
+			message: "XML syntax error",
+		},
+		{
+			name:    "MissingEndToEndId",
+			old:     "<PmtId><InstrId>I-1</InstrId><EndToEndId>E2E-1</EndToEndId></PmtId>",
+			new:     "<PmtId><InstrId>I-1</InstrId></PmtId>",
+			message: "/CdtTrfTxInf/PmtId: missing element EndToEndId",
+		},
+		{
+			name:    "WrongOrder",
+			old:     "<Cdtr><Nm>Jane Doe</Nm></Cdtr>",
+			new:     "<RmtInf><Ustrd>Early</Ustrd></RmtInf><Cdtr><Nm>Jane Doe</Nm></Cdtr>",
+			message: "unexpected element Cdtr",
+		},
+		{
+			name:    "UnknownElement",
+			old:     "<NbOfTxs>3</NbOfTxs>",
+			new:     "<NbOfTxs>3</NbOfTxs><Priority>HIGH</Priority>",
+			message: "Document/CstmrCdtTrfInitn/GrpHdr: missing element InitgPty",
+		},
+		{
+			name:    "MissingCurrency",
+			old:     `<InstdAmt Ccy="USD">250.25</InstdAmt>`,
+			new:     `<InstdAmt>250.25</InstdAmt>`,
+			message: "missing attribute Ccy",
+		},
+		{
+			name:    "NegativeAmount",
+			old:     `<InstdAmt Ccy="USD">250.25</InstdAmt>`,
+			new:     `<InstdAmt Ccy="USD">-250.25</InstdAmt>`,
+			message: `"-250.25" is not a valid decimal number`,
+		},
+		{
+			name:    "BothAccountIdentifications",
+			old:     "<Id><Othr><Id>30</Id></Othr></Id>",
+			new:     "<Id><IBAN>DE89370400440532013000</IBAN><Othr><Id>30</Id></Othr></Id>",
+			message: "CdtrAcct/Id: expected one of IBAN, Othr",
+		},
+		{
+			name:    "LongMessageID",
+			old:     "<MsgId>PAYROLL-2026-10</MsgId>",
+			new:     "<MsgId>" + strings.Repeat("X", 36) + "</MsgId>",
+			message: "GrpHdr/MsgId: text must be 1 to 35 characters",
+		},
+		{
+			name:    "ForeignNamespace",
+			old:     "<Cdtr><Nm>Jane Doe</Nm></Cdtr>",
+			new:     `<Cdtr xmlns="urn:example"><Nm>Jane Doe</Nm></Cdtr>`,
+			message: "element Cdtr is not in namespace",
+		},
+		{
+			name:    "Cheque",
+			old:     "<PmtInfId>EXPENSES</PmtInfId>\n      <PmtMtd>TRF</PmtMtd>",
+			new:     "<PmtInfId>EXPENSES</PmtInfId>\n      <PmtMtd>CHK</PmtMtd>",
+			message: "payment EXPENSES: unsupported payment method CHK",
+		},
+		{
+			name:    "PaymentNumberOfTransactions",
+			old:     "<NbOfTxs>2</NbOfTxs>",
+			new:     "<NbOfTxs>3</NbOfTxs>",
+			message: "payment SALARIES: number of transactions 3 does not match the 2 transactions",
+		},
+		{
+			name:    "GroupControlSum",
+			old:     "<CtrlSum>1750.75</CtrlSum>",
+			new:     "<CtrlSum>1750.70</CtrlSum>",
+			message: "group header: control sum 1750.70 does not match the sum 1750.75 of the transactions",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Contains(t, pain001, tc.old)
+			_, err := ParsePain001(strings.NewReader(strings.Replace(pain001, tc.old, tc.new, 1)))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.message)
+		})
+	}
+}
+
+func TestSchemaError(t *testing.T) {
+	_, err := Pain001.Validate(strings.NewReader(strings.Replace(pain001, "<Dt>2026-10-16</Dt>", "<Dt>16.10.2026</Dt>", 1)))
+
+	var schemaError *SchemaError
+	require.True(t, errors.As(err, &schemaError))
+	require.Equal(t, "Document/CstmrCdtTrfInitn/PmtInf/ReqdExctnDt/Dt", schemaError.Path)
+
+	_, err = Pain002.Validate(strings.NewReader(pain001))
+	require.ErrorIs(t, err, ErrUnknownNamespace)
+}
+
+func TestWritePain002(t *testing.T) {
+	report := StatusReport{
+		MessageID:           "STS-1",
+		CreatedAt:           time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC),
+		OriginalMessageID:   "PAYROLL-2026-10",
+		OriginalMessageName: "pain.001.001.09",
+		OriginalCreatedAt:   time.Date(2026, 10, 15, 7, 30, 0, 0, time.UTC),
+		Payments: []PaymentStatus{
+			{
+				PaymentInformationID: "SALARIES",
+				Transactions: []TransactionStatus{
+					{InstructionID: "I-1", EndToEndID: "E2E-1", Status: StatusSettled, AccountServicerReference: "41"},
+					{
+						EndToEndID:            NotProvided,
+						Status:                StatusRejected,
+						Reason:                ReasonIncorrectAccount,
+						AdditionalInformation: strings.Repeat("x", 200),
+					},
+				},
+			},
+			{
+				PaymentInformationID: "EXPENSES",
+				Transactions: []TransactionStatus{
+					{EndToEndID: "E2E-3", Status: StatusPending, AccountServicerReference: "7"},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePain002(&buf, report))
+
+	namespace, err := Pain002.Validate(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, Pain002V10, namespace)
+
+	namespace, err = loadXSD(t, "pain.002.001.10.xsd").Validate(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, Pain002V10, namespace)
+
+	document := buf.String()
+	require.Contains(t, document, "<OrgnlMsgNmId>pain.001.001.09</OrgnlMsgNmId>")
+	require.Contains(t, document, "<OrgnlCreDtTm>2026-10-15T07:30:00Z</OrgnlCreDtTm>")
+	require.Contains(t, document, "<OrgnlNbOfTxs>3</OrgnlNbOfTxs>")
+	require.Contains(t, document, "<GrpSts>PART</GrpSts>")
+	require.Contains(t, document, "<DtldNbOfTxs>1</DtldNbOfTxs>\n        <DtldSts>ACSC</DtldSts>")
+	require.Contains(t, document, "<PmtInfSts>PART</PmtInfSts>")
+	require.Contains(t, document, "<PmtInfSts>PDNG</PmtInfSts>")
+	require.Contains(t, document, "<Rsn>\n            <Cd>AC01</Cd>\n          </Rsn>")
+	require.Contains(t, document, "<AddtlInf>"+strings.Repeat("x", 105)+"</AddtlInf>")
+	require.Contains(t, document, "<AcctSvcrRef>41</AcctSvcrRef>")
+}
+
+func TestParseXSD(t *testing.T) {
+	schema := loadXSD(t, "pain.002.001.10.xsd")
+
+	report := func(status string, amount string) string {
+		return `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.002.001.10">
+  <CstmrPmtStsRpt>
+    <GrpHdr><MsgId>STS-1</MsgId><CreDtTm>2026-10-15T10:00:00Z</CreDtTm></GrpHdr>
+    <OrgnlGrpInfAndSts>
+      <OrgnlMsgId>PAYROLL-2026-10</OrgnlMsgId>
+      <OrgnlMsgNmId>pain.001.001.09</OrgnlMsgNmId>
+      <OrgnlCtrlSum>` + amount + `</OrgnlCtrlSum>
+      <GrpSts>` + status + `</GrpSts>
+    </OrgnlGrpInfAndSts>
+  </CstmrPmtStsRpt>
+</Document>`
+	}
+
+	namespace, err := schema.Validate(strings.NewReader(report(StatusSettled, "1750.75")))
+	require.NoError(t, err)
+	require.Equal(t, Pain002V10, namespace)
+
+	for name, document := range map[string]string{
+		"CodeTooLong":     report("SETTLED", "1750.75"),
+		"TooManyDigits":   report(StatusSettled, "1234567890123456789"),
+		"NotADecimal":     report(StatusSettled, "1,750.75"),
+		"UnknownElement":  strings.Replace(report(StatusSettled, "1"), "<GrpSts>", "<Rsn>X</Rsn><GrpSts>", 1),
+		"MissingRequired": strings.Replace(report(StatusSettled, "1"), "<OrgnlMsgNmId>pain.001.001.09</OrgnlMsgNmId>", "", 1),
+	} {
+		_, err := schema.Validate(strings.NewReader(document))
+		var schemaError *SchemaError
+		require.True(t, errors.As(err, &schemaError), name)
+	}
+
+	_, err = ParseXSD(strings.NewReader(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:element name="Document" type="Document"/>
+  <xs:complexType name="Document"><xs:all><xs:element name="A" type="xs:string"/></xs:all></xs:complexType>
+</xs:schema>`))
+	require.Error(t, err)
+}
+
+// loadXSD parses a message definition vendored under testdata
+func loadXSD(t *testing.T, name string) Schema {
+	f, err := os.Open(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	defer f.Close()
+
+	schema, err := ParseXSD(f)
+	require.NoError(t, err)
+	return schema
+}
+
+func TestGroupStatus(t *testing.T) {
+	require.Equal(t, StatusSettled, GroupStatus([]string{StatusSettled, StatusSettled}))
+	require.Equal(t, StatusRejected, GroupStatus([]string{StatusRejected}))
+	require.Equal(t, StatusPartial, GroupStatus([]string{StatusSettled, StatusRejected}))
+	require.Equal(t, StatusPartial, GroupStatus([]string{StatusPending, StatusRejected}))
+	require.Equal(t, StatusPending, GroupStatus([]string{StatusSettled, StatusPending}))
+}
+
+func TestParseAmount(t *testing.T) {
+	amount, err := ParseAmount("12.50000", "EUR")
+	require.NoError(t, err)
+	require.Equal(t, int64(1250), amount.Amount())
+
+	amount, err = ParseAmount("700.", "JPY")
+	require.NoError(t, err)
+	require.Equal(t, int64(700), amount.Amount())
+
+	_, err = ParseAmount("12.505", "EUR")
+	require.ErrorIs(t, err, utils.ErrInvalidAmount)
+
+	_, err = ParseAmount("-1", "EUR")
+	require.ErrorIs(t, err, utils.ErrInvalidAmount)
+
+	_, err = ParseAmount("1", "XXX")
+	require.ErrorIs(t, err, utils.ErrUnknownCurrency)
+
+	negative, err := utils.ParseMoney("-12.5", "EUR")
+	require.NoError(t, err)
+	require.Equal(t, "12.50", FormatAmount(negative))
+	require.Equal(t, Debit, Indicator(negative))
+	require.Equal(t, Credit, Indicator(amount))
+}
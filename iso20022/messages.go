@@ -0,0 +1,372 @@
+package iso20022
+
+// The structures follow the message definitions of the ISO 20022 catalogue. Parts the bank does not
+// read, such as postal addresses and agents, are open: they must be present in the right place but
+// their content is not checked. Elements added by a later version of a message are accepted in the
+// earlier versions too.
+
+const (
+	Pain001V03 = "urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"
+	Pain001V09 = "urn:iso:std:iso:20022:tech:xsd:pain.001.001.09"
+	Pain002V10 = "urn:iso:std:iso:20022:tech:xsd:pain.002.001.10"
+	Camt053V08 = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"
+)
+
+// Pain001 is the customer credit transfer initiation, versions 03 and 09 are accepted
+var Pain001 = Schema{
+	Pain001V03: pain001Document(text(one("ReqdExctnDt"), dateText)),
+	Pain001V09: pain001Document(dateAndDateTime(one("ReqdExctnDt"))),
+}
+
+// Pain002 is the customer payment status report the bank writes
+var Pain002 = Schema{
+	Pain002V10: one("Document",
+		one("CstmrPmtStsRpt",
+			one("GrpHdr",
+				text(one("MsgId"), max35Text),
+				text(one("CreDtTm"), dateTimeText),
+				party(optional("InitgPty")),
+				open(optional("FwdgAgt")),
+				open(optional("DbtrAgt")),
+				open(optional("CdtrAgt")),
+			),
+			one("OrgnlGrpInfAndSts",
+				text(one("OrgnlMsgId"), max35Text),
+				text(one("OrgnlMsgNmId"), max35Text),
+				text(optional("OrgnlCreDtTm"), dateTimeText),
+				text(optional("OrgnlNbOfTxs"), numberText),
+				text(optional("OrgnlCtrlSum"), decimalText),
+				text(optional("GrpSts"), codeText),
+				statusReason(many("StsRsnInf", 0, Unbounded)),
+				numberOfTransactionsPerStatus(many("NbOfTxsPerSts", 0, Unbounded)),
+			),
+			many("OrgnlPmtInfAndSts", 0, Unbounded,
+				text(one("OrgnlPmtInfId"), max35Text),
+				text(optional("OrgnlNbOfTxs"), numberText),
+				text(optional("OrgnlCtrlSum"), decimalText),
+				text(optional("PmtInfSts"), codeText),
+				statusReason(many("StsRsnInf", 0, Unbounded)),
+				numberOfTransactionsPerStatus(many("NbOfTxsPerSts", 0, Unbounded)),
+				many("TxInfAndSts", 0, Unbounded,
+					text(optional("StsId"), max35Text),
+					text(optional("OrgnlInstrId"), max35Text),
+					text(optional("OrgnlEndToEndId"), max35Text),
+					optional("OrgnlUETR"),
+					text(optional("TxSts"), codeText),
+					statusReason(many("StsRsnInf", 0, Unbounded)),
+					open(many("ChrgsInf", 0, Unbounded)),
+					text(optional("AccptncDtTm"), dateTimeText),
+					text(optional("AcctSvcrRef"), max35Text),
+					text(optional("ClrSysRef"), max35Text),
+					open(optional("OrgnlTxRef")),
+					open(many("SplmtryData", 0, Unbounded)),
+				),
+			),
+			open(many("SplmtryData", 0, Unbounded)),
+		),
+	),
+}
+
+// Camt053 is the bank to customer statement
+var Camt053 = Schema{
+	Camt053V08: one("Document",
+		one("BkToCstmrStmt",
+			one("GrpHdr",
+				text(one("MsgId"), max35Text),
+				text(one("CreDtTm"), dateTimeText),
+				party(optional("MsgRcpt")),
+				open(optional("MsgPgntn")),
+				open(optional("OrgnlBizQry")),
+				text(optional("AddtlInf"), maxText(500)),
+			),
+			many("Stmt", 1, Unbounded,
+				text(one("Id"), max35Text),
+				open(optional("StmtPgntn")),
+				text(optional("ElctrncSeqNb"), decimalText),
+				open(optional("RptgSeq")),
+				text(optional("LglSeqNb"), decimalText),
+				text(optional("CreDtTm"), dateTimeText),
+				optional("FrToDt",
+					text(one("FrDtTm"), dateTimeText),
+					text(one("ToDtTm"), dateTimeText),
+				),
+				open(optional("CpyDplctInd")),
+				open(optional("RptgSrc")),
+				cashAccount(one("Acct"),
+					party(optional("Ownr")),
+					open(optional("Svcr")),
+				),
+				open(optional("RltdAcct")),
+				open(many("Intrst", 0, Unbounded)),
+				many("Bal", 0, Unbounded,
+					one("Tp",
+						choice(one("CdOrPrtry"), text(one("Cd"), codeText), text(one("Prtry"), max35Text)),
+						open(optional("SubTp")),
+					),
+					open(many("CdtLine", 0, Unbounded)),
+					amount(one("Amt")),
+					text(one("CdtDbtInd"), indicatorText),
+					dateAndDateTime(one("Dt")),
+					open(many("Avlbty", 0, Unbounded)),
+				),
+				optional("TxsSummry",
+					optional("TtlNtries",
+						text(optional("NbOfNtries"), numberText),
+						text(optional("Sum"), decimalText),
+						optional("TtlNetNtry",
+							text(one("Amt"), decimalText),
+							text(one("CdtDbtInd"), indicatorText),
+						),
+					),
+					numberAndSum(optional("TtlCdtNtries")),
+					numberAndSum(optional("TtlDbtNtries")),
+					open(many("TtlNtriesPerBkTxCd", 0, Unbounded)),
+				),
+				many("Ntry", 0, Unbounded,
+					text(optional("NtryRef"), max35Text),
+					amount(one("Amt")),
+					text(one("CdtDbtInd"), indicatorText),
+					open(optional("RvslInd")),
+					choice(one("Sts"), text(one("Cd"), codeText), text(one("Prtry"), max35Text)),
+					dateAndDateTime(optional("BookgDt")),
+					dateAndDateTime(optional("ValDt")),
+					text(optional("AcctSvcrRef"), max35Text),
+					open(many("Avlbty", 0, Unbounded)),
+					bankTransactionCode(one("BkTxCd")),
+					open(optional("ComssnWvrInd")),
+					open(optional("AddtlInfInd")),
+					open(optional("AmtDtls")),
+					open(optional("Chrgs")),
+					open(optional("TechInptChanl")),
+					open(optional("Intrst")),
+					open(optional("CardTx")),
+					many("NtryDtls", 0, Unbounded,
+						open(optional("Btch")),
+						many("TxDtls", 0, Unbounded,
+							optional("Refs",
+								text(optional("MsgId"), max35Text),
+								text(optional("AcctSvcrRef"), max35Text),
+								text(optional("PmtInfId"), max35Text),
+								text(optional("InstrId"), max35Text),
+								text(optional("EndToEndId"), max35Text),
+								optional("UETR"),
+								text(optional("TxId"), max35Text),
+								text(optional("MndtId"), max35Text),
+								text(optional("ChqNb"), max35Text),
+								text(optional("ClrSysRef"), max35Text),
+								text(optional("AcctOwnrTxId"), max35Text),
+								text(optional("AcctSvcrTxId"), max35Text),
+								text(optional("MktInfrstrctrTxId"), max35Text),
+								text(optional("PrcgId"), max35Text),
+								open(many("Prtry", 0, Unbounded)),
+							),
+							amount(optional("Amt")),
+							text(optional("CdtDbtInd"), indicatorText),
+							open(optional("AmtDtls")),
+							open(many("Avlbty", 0, Unbounded)),
+							bankTransactionCode(optional("BkTxCd")),
+							open(optional("Chrgs")),
+							open(optional("Intrst")),
+							optional("RltdPties",
+								open(optional("InitgPty")),
+								relatedParty(optional("Dbtr")),
+								cashAccount(optional("DbtrAcct")),
+								open(optional("UltmtDbtr")),
+								relatedParty(optional("Cdtr")),
+								cashAccount(optional("CdtrAcct")),
+								open(optional("UltmtCdtr")),
+								open(optional("TradgPty")),
+								open(many("Prtry", 0, Unbounded)),
+							),
+							open(optional("RltdAgts")),
+							open(optional("LclInstrm")),
+							open(optional("Purp")),
+							open(many("RltdRmtInf", 0, 10)),
+							remittanceInformation(optional("RmtInf")),
+							open(optional("RltdDts")),
+							open(optional("RltdPric")),
+							open(many("RltdQties", 0, Unbounded)),
+							open(optional("FinInstrmId")),
+							open(optional("Tax")),
+							open(optional("RtrInf")),
+							open(optional("CorpActn")),
+							open(optional("SfkpgAcct")),
+							open(many("CshDpst", 0, Unbounded)),
+							open(optional("CardTx")),
+							text(optional("AddtlTxInf"), maxText(500)),
+							open(many("SplmtryData", 0, Unbounded)),
+						),
+					),
+					text(optional("AddtlNtryInf"), maxText(500)),
+				),
+				text(optional("AddtlStmtInf"), maxText(500)),
+			),
+			open(many("SplmtryData", 0, Unbounded)),
+		),
+	),
+}
+
+// pain001Document differs between the versions only in the requested execution date
+func pain001Document(requestedExecutionDate Element) Element {
+	return one("Document",
+		one("CstmrCdtTrfInitn",
+			one("GrpHdr",
+				text(one("MsgId"), max35Text),
+				text(one("CreDtTm"), dateTimeText),
+				open(many("Authstn", 0, 2)),
+				text(one("NbOfTxs"), numberText),
+				text(optional("CtrlSum"), decimalText),
+				party(one("InitgPty")),
+				open(optional("FwdgAgt")),
+			),
+			many("PmtInf", 1, Unbounded,
+				text(one("PmtInfId"), max35Text),
+				text(one("PmtMtd"), codeText),
+				open(optional("BtchBookg")),
+				text(optional("NbOfTxs"), numberText),
+				text(optional("CtrlSum"), decimalText),
+				open(optional("PmtTpInf")),
+				requestedExecutionDate,
+				open(optional("PoolgAdjstmntDt")),
+				party(one("Dbtr")),
+				cashAccount(one("DbtrAcct")),
+				open(one("DbtrAgt")),
+				open(optional("DbtrAgtAcct")),
+				open(optional("InstrForDbtrAgt")),
+				open(optional("UltmtDbtr")),
+				open(optional("ChrgBr")),
+				open(optional("ChrgsAcct")),
+				open(optional("ChrgsAcctAgt")),
+				many("CdtTrfTxInf", 1, Unbounded,
+					one("PmtId",
+						text(optional("InstrId"), max35Text),
+						text(one("EndToEndId"), max35Text),
+						optional("UETR"),
+					),
+					open(optional("PmtTpInf")),
+					choice(one("Amt"),
+						amount(one("InstdAmt")),
+						one("EqvtAmt",
+							amount(one("Amt")),
+							text(one("CcyOfTrf"), currencyText),
+						),
+					),
+					open(optional("XchgRateInf")),
+					open(optional("ChrgBr")),
+					open(optional("ChqInstr")),
+					open(optional("UltmtDbtr")),
+					open(optional("IntrmyAgt1")),
+					open(optional("IntrmyAgt1Acct")),
+					open(optional("IntrmyAgt2")),
+					open(optional("IntrmyAgt2Acct")),
+					open(optional("IntrmyAgt3")),
+					open(optional("IntrmyAgt3Acct")),
+					open(optional("CdtrAgt")),
+					open(optional("CdtrAgtAcct")),
+					party(optional("Cdtr")),
+					cashAccount(optional("CdtrAcct")),
+					open(optional("UltmtCdtr")),
+					open(many("InstrForCdtrAgt", 0, Unbounded)),
+					open(optional("InstrForDbtrAgt")),
+					open(optional("Purp")),
+					open(many("RgltryRptg", 0, 10)),
+					open(optional("Tax")),
+					open(many("RltdRmtInf", 0, 10)),
+					remittanceInformation(optional("RmtInf")),
+					open(many("SplmtryData", 0, Unbounded)),
+				),
+			),
+			open(many("SplmtryData", 0, Unbounded)),
+		),
+	)
+}
+
+// party is a PartyIdentification
+func party(el Element) Element {
+	el.Children = []Element{
+		text(optional("Nm"), max140Text),
+		open(optional("PstlAdr")),
+		open(optional("Id")),
+		open(optional("CtryOfRes")),
+		open(optional("CtctDtls")),
+	}
+	return el
+}
+
+// relatedParty is a Party40Choice of a party or an agent
+func relatedParty(el Element) Element {
+	return choice(el, party(one("Pty")), open(one("Agt")))
+}
+
+// cashAccount is a CashAccount identified by an IBAN or another identification, followed by extra
+func cashAccount(el Element, extra ...Element) Element {
+	el.Children = append([]Element{
+		choice(one("Id"),
+			text(one("IBAN"), pattern(ibanPattern, "IBAN")),
+			one("Othr",
+				text(one("Id"), max35Text),
+				open(optional("SchmeNm")),
+				text(optional("Issr"), max35Text),
+			),
+		),
+		open(optional("Tp")),
+		text(optional("Ccy"), currencyText),
+		text(optional("Nm"), max70Text),
+		open(optional("Prxy")),
+	}, extra...)
+	return el
+}
+
+// dateAndDateTime is a DateAndDateTime2Choice
+func dateAndDateTime(el Element) Element {
+	return choice(el, text(one("Dt"), dateText), text(one("DtTm"), dateTimeText))
+}
+
+func remittanceInformation(el Element) Element {
+	el.Children = []Element{
+		text(many("Ustrd", 0, Unbounded), max140Text),
+		open(many("Strd", 0, Unbounded)),
+	}
+	return el
+}
+
+func statusReason(el Element) Element {
+	el.Children = []Element{
+		party(optional("Orgtr")),
+		choice(optional("Rsn"), text(one("Cd"), codeText), text(one("Prtry"), max35Text)),
+		text(many("AddtlInf", 0, Unbounded), max105Text),
+	}
+	return el
+}
+
+func numberOfTransactionsPerStatus(el Element) Element {
+	el.Children = []Element{
+		text(one("DtldNbOfTxs"), numberText),
+		text(one("DtldSts"), codeText),
+		text(optional("DtldCtrlSum"), decimalText),
+	}
+	return el
+}
+
+func numberAndSum(el Element) Element {
+	el.Children = []Element{
+		text(optional("NbOfNtries"), numberText),
+		text(optional("Sum"), decimalText),
+	}
+	return el
+}
+
+// bankTransactionCode is a BankTransactionCodeStructure4 with the domain code
+func bankTransactionCode(el Element) Element {
+	el.Children = []Element{
+		optional("Domn",
+			text(one("Cd"), codeText),
+			one("Fmly",
+				text(one("Cd"), codeText),
+				text(one("SubFmlyCd"), codeText),
+			),
+		),
+		open(optional("Prtry")),
+	}
+	return el
+}
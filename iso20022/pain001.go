@@ -0,0 +1,214 @@
+package iso20022
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PaymentMethodTransfer is the only payment method of a credit transfer initiation the bank executes,
+// cheques are not issued
+const PaymentMethodTransfer = "TRF"
+
+// NotProvided is the end to end identification of a payment the initiating party gave no reference
+const NotProvided = "NOTPROVIDED"
+
+// CreditTransferInitiation is a decoded pain.001, a batch of credit transfers grouped by debtor account
+type CreditTransferInitiation struct {
+	// Namespace is the version of the message
+	Namespace          string               `xml:"-"`
+	GroupHeader        GroupHeader          `xml:"CstmrCdtTrfInitn>GrpHdr"`
+	PaymentInformation []PaymentInformation `xml:"CstmrCdtTrfInitn>PmtInf"`
+}
+
+type GroupHeader struct {
+	MessageID            string `xml:"MsgId"`
+	CreationDateTime     string `xml:"CreDtTm"`
+	NumberOfTransactions string `xml:"NbOfTxs"`
+	ControlSum           string `xml:"CtrlSum"`
+	InitiatingParty      Party  `xml:"InitgPty"`
+}
+
+// CreatedAt is when the initiating party created the message
+func (header GroupHeader) CreatedAt() time.Time {
+	t, _ := parseDateTime(header.CreationDateTime)
+	return t
+}
+
+// PaymentInformation is the group of credit transfers debited from one account
+type PaymentInformation struct {
+	ID                     string                      `xml:"PmtInfId"`
+	Method                 string                      `xml:"PmtMtd"`
+	NumberOfTransactions   string                      `xml:"NbOfTxs"`
+	ControlSum             string                      `xml:"CtrlSum"`
+	RequestedExecutionDate DateAndDateTime             `xml:"ReqdExctnDt"`
+	Debtor                 Party                       `xml:"Dbtr"`
+	DebtorAccount          CashAccount                 `xml:"DbtrAcct"`
+	Transactions           []CreditTransferTransaction `xml:"CdtTrfTxInf"`
+}
+
+type CreditTransferTransaction struct {
+	PaymentID             PaymentIdentification `xml:"PmtId"`
+	Amount                AmountType            `xml:"Amt"`
+	Creditor              Party                 `xml:"Cdtr"`
+	CreditorAccount       *CashAccount          `xml:"CdtrAcct"`
+	RemittanceInformation RemittanceInformation `xml:"RmtInf"`
+}
+
+type PaymentIdentification struct {
+	InstructionID string `xml:"InstrId"`
+	EndToEndID    string `xml:"EndToEndId"`
+}
+
+// AmountType holds either the instructed amount or an amount to convert into the currency of transfer
+type AmountType struct {
+	Instructed *Amount           `xml:"InstdAmt"`
+	Equivalent *EquivalentAmount `xml:"EqvtAmt"`
+}
+
+// Value is the amount as written in the message
+func (amount AmountType) Value() Amount {
+	if amount.Instructed != nil {
+		return *amount.Instructed
+	}
+	if amount.Equivalent != nil {
+		return amount.Equivalent.Amount
+	}
+	return Amount{}
+}
+
+type EquivalentAmount struct {
+	Amount             Amount `xml:"Amt"`
+	CurrencyOfTransfer string `xml:"CcyOfTrf"`
+}
+
+type Amount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type Party struct {
+	Name string `xml:"Nm,omitempty"`
+}
+
+type CashAccount struct {
+	ID       AccountIdentification `xml:"Id"`
+	Currency string                `xml:"Ccy,omitempty"`
+	Name     string                `xml:"Nm,omitempty"`
+}
+
+type AccountIdentification struct {
+	IBAN  string                        `xml:"IBAN,omitempty"`
+	Other *GenericAccountIdentification `xml:"Othr,omitempty"`
+}
+
+type GenericAccountIdentification struct {
+	ID string `xml:"Id"`
+}
+
+// DateAndDateTime is a date, a date time, or the bare date of version 03
+type DateAndDateTime struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+	Value    string `xml:",chardata"`
+}
+
+// Day is the date, in UTC
+func (d DateAndDateTime) Day() (time.Time, error) {
+	switch {
+	case d.Date != "":
+		return time.Parse("2006-01-02", d.Date)
+	case d.DateTime != "":
+		t, err := parseDateTime(d.DateTime)
+		if err != nil {
+			return t, err
+		}
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Parse("2006-01-02", strings.TrimSpace(d.Value))
+}
+
+type RemittanceInformation struct {
+	Unstructured []string `xml:"Ustrd"`
+}
+
+// String joins the unstructured remittance information
+func (info RemittanceInformation) String() string {
+	return strings.Join(info.Unstructured, " ")
+}
+
+// ParsePain001 reads a credit transfer initiation. The document must follow the schema of its version,
+// use the transfer payment method, and its numbers of transactions and control sums must add up.
+func ParsePain001(r io.Reader) (*CreditTransferInitiation, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := Pain001.Validate(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	message := &CreditTransferInitiation{Namespace: namespace}
+	if err := xml.Unmarshal(data, message); err != nil {
+		return nil, err
+	}
+
+	total := new(big.Rat)
+	count := 0
+	for _, payment := range message.PaymentInformation {
+		if payment.Method != PaymentMethodTransfer {
+			return nil, fmt.Errorf("payment %s: unsupported payment method %s", payment.ID, payment.Method)
+		}
+
+		sum := new(big.Rat)
+		for _, transaction := range payment.Transactions {
+			value, ok := new(big.Rat).SetString(transaction.Amount.Value().Value)
+			if !ok {
+				return nil, fmt.Errorf("payment %s: invalid amount %q", payment.ID, transaction.Amount.Value().Value)
+			}
+			sum.Add(sum, value)
+		}
+		if err := checkTotals(payment.NumberOfTransactions, payment.ControlSum, len(payment.Transactions), sum); err != nil {
+			return nil, fmt.Errorf("payment %s: %w", payment.ID, err)
+		}
+
+		total.Add(total, sum)
+		count += len(payment.Transactions)
+	}
+
+	if err := checkTotals(message.GroupHeader.NumberOfTransactions, message.GroupHeader.ControlSum, count, total); err != nil {
+		return nil, fmt.Errorf("group header: %w", err)
+	}
+	return message, nil
+}
+
+// checkTotals compares the declared number of transactions and control sum, when there is one, with the actual ones
+func checkTotals(numberOfTransactions string, controlSum string, count int, sum *big.Rat) error {
+	if numberOfTransactions != "" {
+		declared, err := strconv.Atoi(numberOfTransactions)
+		if err != nil || declared != count {
+			return fmt.Errorf("number of transactions %s does not match the %d transactions", numberOfTransactions, count)
+		}
+	}
+	if controlSum != "" {
+		declared, ok := new(big.Rat).SetString(controlSum)
+		if !ok || declared.Cmp(sum) != 0 {
+			return fmt.Errorf("control sum %s does not match the sum %s of the transactions", controlSum, formatRat(sum))
+		}
+	}
+	return nil
+}
+
+// formatRat formats an exact sum of amounts without trailing zeros
+func formatRat(r *big.Rat) string {
+	s := r.FloatString(5)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
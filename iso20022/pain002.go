@@ -0,0 +1,203 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// StatusReport is the outcome of every credit transfer of a pain.001
+type StatusReport struct {
+	MessageID string
+	CreatedAt time.Time
+	// OriginalMessageID and OriginalMessageName identify the pain.001 being reported on
+	OriginalMessageID   string
+	OriginalMessageName string
+	OriginalCreatedAt   time.Time
+	Payments            []PaymentStatus
+}
+
+// PaymentStatus is the outcome of the credit transfers of one payment information block
+type PaymentStatus struct {
+	PaymentInformationID string
+	Transactions         []TransactionStatus
+}
+
+type TransactionStatus struct {
+	InstructionID string
+	EndToEndID    string
+	Status        string
+	// Reason is the code of a rejection and AdditionalInformation explains it
+	Reason                string
+	AdditionalInformation string
+	// AccountServicerReference identifies the transfer, or the approval it waits for
+	AccountServicerReference string
+}
+
+// GroupStatus summarizes transaction statuses: settled or rejected when they all are,
+// partially accepted when only some were rejected, and pending otherwise
+func GroupStatus(statuses []string) string {
+	counts := make(map[string]int)
+	for _, status := range statuses {
+		counts[status]++
+	}
+
+	switch {
+	case counts[StatusSettled] == len(statuses):
+		return StatusSettled
+	case counts[StatusRejected] == len(statuses):
+		return StatusRejected
+	case counts[StatusRejected] > 0:
+		return StatusPartial
+	}
+	return StatusPending
+}
+
+func (report StatusReport) statuses() []string {
+	var statuses []string
+	for _, payment := range report.Payments {
+		statuses = append(statuses, payment.statuses()...)
+	}
+	return statuses
+}
+
+func (payment PaymentStatus) statuses() []string {
+	statuses := make([]string, len(payment.Transactions))
+	for i, transaction := range payment.Transactions {
+		statuses[i] = transaction.Status
+	}
+	return statuses
+}
+
+// WritePain002 writes the report as a pain.002.001.10 customer payment status report
+func WritePain002(w io.Writer, report StatusReport) error {
+	statuses := report.statuses()
+	document := pain002Document{
+		Namespace: Pain002V10,
+		GroupHeader: pain002GroupHeader{
+			MessageID: report.MessageID,
+			CreatedAt: formatDateTime(report.CreatedAt),
+		},
+		Group: pain002Group{
+			OriginalMessageID:   report.OriginalMessageID,
+			OriginalMessageName: report.OriginalMessageName,
+			OriginalCount:       len(statuses),
+			Status:              GroupStatus(statuses),
+			PerStatus:           countPerStatus(statuses),
+		},
+	}
+	if !report.OriginalCreatedAt.IsZero() {
+		document.Group.OriginalCreatedAt = formatDateTime(report.OriginalCreatedAt)
+	}
+
+	for _, payment := range report.Payments {
+		status := pain002Payment{
+			ID:     payment.PaymentInformationID,
+			Count:  len(payment.Transactions),
+			Status: GroupStatus(payment.statuses()),
+		}
+		for _, transaction := range payment.Transactions {
+			var reasons []pain002StatusReason
+			if transaction.Reason != "" || transaction.AdditionalInformation != "" {
+				reason := pain002StatusReason{}
+				if transaction.Reason != "" {
+					reason.Reason = &pain002Code{Code: transaction.Reason}
+				}
+				if transaction.AdditionalInformation != "" {
+					reason.AdditionalInformation = Truncate(transaction.AdditionalInformation, 105)
+				}
+				reasons = append(reasons, reason)
+			}
+			status.Transactions = append(status.Transactions, pain002Transaction{
+				InstructionID:            transaction.InstructionID,
+				EndToEndID:               transaction.EndToEndID,
+				Status:                   transaction.Status,
+				Reasons:                  reasons,
+				AccountServicerReference: transaction.AccountServicerReference,
+			})
+		}
+		document.Payments = append(document.Payments, status)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// countPerStatus counts the transactions of every status in the order they first occur
+func countPerStatus(statuses []string) []pain002StatusCount {
+	var counts []pain002StatusCount
+	index := make(map[string]int)
+	for _, status := range statuses {
+		i, ok := index[status]
+		if !ok {
+			i = len(counts)
+			index[status] = i
+			counts = append(counts, pain002StatusCount{Status: status})
+		}
+		counts[i].Count++
+	}
+	return counts
+}
+
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+type pain002Document struct {
+	XMLName     xml.Name           `xml:"Document"`
+	Namespace   string             `xml:"xmlns,attr"`
+	GroupHeader pain002GroupHeader `xml:"CstmrPmtStsRpt>GrpHdr"`
+	Group       pain002Group       `xml:"CstmrPmtStsRpt>OrgnlGrpInfAndSts"`
+	Payments    []pain002Payment   `xml:"CstmrPmtStsRpt>OrgnlPmtInfAndSts"`
+}
+
+type pain002GroupHeader struct {
+	MessageID string `xml:"MsgId"`
+	CreatedAt string `xml:"CreDtTm"`
+}
+
+type pain002Group struct {
+	OriginalMessageID   string               `xml:"OrgnlMsgId"`
+	OriginalMessageName string               `xml:"OrgnlMsgNmId"`
+	OriginalCreatedAt   string               `xml:"OrgnlCreDtTm,omitempty"`
+	OriginalCount       int                  `xml:"OrgnlNbOfTxs"`
+	Status              string               `xml:"GrpSts"`
+	PerStatus           []pain002StatusCount `xml:"NbOfTxsPerSts"`
+}
+
+type pain002StatusCount struct {
+	Count  int    `xml:"DtldNbOfTxs"`
+	Status string `xml:"DtldSts"`
+}
+
+type pain002Payment struct {
+	ID           string               `xml:"OrgnlPmtInfId"`
+	Count        int                  `xml:"OrgnlNbOfTxs"`
+	Status       string               `xml:"PmtInfSts"`
+	Transactions []pain002Transaction `xml:"TxInfAndSts"`
+}
+
+type pain002Transaction struct {
+	InstructionID            string                `xml:"OrgnlInstrId,omitempty"`
+	EndToEndID               string                `xml:"OrgnlEndToEndId"`
+	Status                   string                `xml:"TxSts"`
+	Reasons                  []pain002StatusReason `xml:"StsRsnInf"`
+	AccountServicerReference string                `xml:"AcctSvcrRef,omitempty"`
+}
+
+type pain002StatusReason struct {
+	Reason                *pain002Code `xml:"Rsn"`
+	AdditionalInformation string       `xml:"AddtlInf,omitempty"`
+}
+
+type pain002Code struct {
+	Code string `xml:"Cd"`
+}
@@ -0,0 +1,318 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Unbounded is the Max of an element that may repeat without limit
+const Unbounded = -1
+
+// Element describes an element of a message the way its XSD does: how often it occurs and either its
+// children in sequence, the alternatives of a choice, or the text it holds
+type Element struct {
+	Name string
+	Min  int
+	Max  int
+	// Children are the elements of the content in order, the alternatives when Choice is set
+	Children []Element
+	// Choice holds exactly one of the Children
+	Choice bool
+	// Open content is not checked, it stands for parts of a message the bank does not read
+	Open bool
+	// Attrs are the required attributes
+	Attrs []string
+	// AttrText checks the value of attributes by name, when they are present
+	AttrText map[string]func(string) error
+	// Text checks the content of an element without children, any text is accepted when it is nil
+	Text func(string) error
+}
+
+// SchemaError locates where a document does not follow its schema
+type SchemaError struct {
+	Path string
+	Err  error
+}
+
+func (e *SchemaError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// ErrUnknownNamespace is returned for a document of a message or version the schema does not describe
+var ErrUnknownNamespace = errors.New("unsupported message namespace")
+
+// Schema is the structure of the versions of a message, keyed by their namespace
+type Schema map[string]Element
+
+// Validate checks the document read from r against the version of its namespace and returns the namespace
+func (schema Schema) Validate(r io.Reader) (string, error) {
+	root, err := parseNode(r)
+	if err != nil {
+		return "", err
+	}
+
+	document, ok := schema[root.name.Space]
+	if !ok || root.name.Local != document.Name {
+		return "", fmt.Errorf("%w %q", ErrUnknownNamespace, root.name.Space)
+	}
+	return root.name.Space, validateNode(root, document, root.name.Space, document.Name)
+}
+
+// node is an element of the document being validated
+type node struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*node
+	text     strings.Builder
+}
+
+func parseNode(r io.Reader) (*node, error) {
+	decoder := xml.NewDecoder(r)
+	var stack []*node
+	var root *node
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			n := &node{name: token.Name, attrs: token.Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root != nil {
+				return nil, errors.New("more than one root element")
+			} else {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(token)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("empty document")
+	}
+	return root, nil
+}
+
+func validateNode(n *node, el Element, namespace string, path string) error {
+	for _, attr := range el.Attrs {
+		if _, ok := attrValue(n, attr); !ok {
+			return &SchemaError{path, fmt.Errorf("missing attribute %s", attr)}
+		}
+	}
+	for attr, check := range el.AttrText {
+		if value, ok := attrValue(n, attr); ok {
+			if err := check(value); err != nil {
+				return &SchemaError{path + "/@" + attr, err}
+			}
+		}
+	}
+	if el.Open {
+		return nil
+	}
+
+	text := strings.TrimSpace(n.text.String())
+	if len(el.Children) == 0 {
+		if len(n.children) > 0 {
+			return &SchemaError{path, fmt.Errorf("unexpected element %s", n.children[0].name.Local)}
+		}
+		if el.Text != nil {
+			if err := el.Text(n.text.String()); err != nil {
+				return &SchemaError{path, err}
+			}
+		}
+		return nil
+	}
+	if text != "" {
+		return &SchemaError{path, errors.New("unexpected text")}
+	}
+
+	for _, child := range n.children {
+		if child.name.Space != namespace {
+			return &SchemaError{path, fmt.Errorf("element %s is not in namespace %s", child.name.Local, namespace)}
+		}
+	}
+
+	if el.Choice {
+		if len(n.children) != 1 {
+			return &SchemaError{path, fmt.Errorf("expected one of %s", names(el.Children))}
+		}
+		child := n.children[0]
+		for _, alternative := range el.Children {
+			if alternative.Name == child.name.Local {
+				return validateNode(child, alternative, namespace, path+"/"+child.name.Local)
+			}
+		}
+		return &SchemaError{path, fmt.Errorf("unexpected element %s, expected one of %s", child.name.Local, names(el.Children))}
+	}
+
+	i := 0
+	for _, expected := range el.Children {
+		count := 0
+		for i < len(n.children) && n.children[i].name.Local == expected.Name && (expected.Max == Unbounded || count < expected.Max) {
+			if err := validateNode(n.children[i], expected, namespace, path+"/"+expected.Name); err != nil {
+				return err
+			}
+			i++
+			count++
+		}
+		if count < expected.Min {
+			return &SchemaError{path, fmt.Errorf("missing element %s", expected.Name)}
+		}
+	}
+	if i < len(n.children) {
+		return &SchemaError{path, fmt.Errorf("unexpected element %s", n.children[i].name.Local)}
+	}
+	return nil
+}
+
+func attrValue(n *node, name string) (string, bool) {
+	for _, attr := range n.attrs {
+		if attr.Name.Space == "" && attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+func names(elements []Element) string {
+	list := make([]string, len(elements))
+	for i, el := range elements {
+		list[i] = el.Name
+	}
+	return strings.Join(list, ", ")
+}
+
+// one, optional and many build the elements of a sequence
+
+func one(name string, children ...Element) Element {
+	return Element{Name: name, Min: 1, Max: 1, Children: children}
+}
+
+func optional(name string, children ...Element) Element {
+	return Element{Name: name, Min: 0, Max: 1, Children: children}
+}
+
+func many(name string, min int, max int, children ...Element) Element {
+	return Element{Name: name, Min: min, Max: max, Children: children}
+}
+
+// choice turns el into a choice between alternatives
+func choice(el Element, alternatives ...Element) Element {
+	for i := range alternatives {
+		alternatives[i].Min, alternatives[i].Max = 1, 1
+	}
+	el.Children = alternatives
+	el.Choice = true
+	return el
+}
+
+// open stops the validation at el
+func open(el Element) Element {
+	el.Open = true
+	return el
+}
+
+// text gives el a simple type
+func text(el Element, check func(string) error) Element {
+	el.Text = check
+	return el
+}
+
+// amount is an ActiveOrHistoricCurrencyAndAmount
+func amount(el Element) Element {
+	el.Attrs = []string{"Ccy"}
+	el.Text = decimalText
+	return el
+}
+
+// simple types
+
+func maxText(n int) func(string) error {
+	return func(s string) error {
+		if s == "" || utf8.RuneCountInString(s) > n {
+			return fmt.Errorf("text must be 1 to %d characters", n)
+		}
+		return nil
+	}
+}
+
+var (
+	max35Text  = maxText(35)
+	max70Text  = maxText(70)
+	max105Text = maxText(105)
+	max140Text = maxText(140)
+)
+
+var (
+	decimalPattern   = regexp.MustCompile(`^[0-9]{1,18}(\.[0-9]{1,17})?$`)
+	numberPattern    = regexp.MustCompile(`^[0-9]{1,15}$`)
+	codePattern      = regexp.MustCompile(`^[A-Z0-9]{1,4}$`)
+	currencyPattern  = regexp.MustCompile(`^[A-Z]{3}$`)
+	indicatorPattern = regexp.MustCompile(`^(CRDT|DBIT)$`)
+)
+
+func pattern(re *regexp.Regexp, name string) func(string) error {
+	return func(s string) error {
+		if !re.MatchString(s) {
+			return fmt.Errorf("%q is not a valid %s", s, name)
+		}
+		return nil
+	}
+}
+
+var (
+	decimalText   = pattern(decimalPattern, "decimal number")
+	numberText    = pattern(numberPattern, "number of transactions")
+	codeText      = pattern(codePattern, "code")
+	currencyText  = pattern(currencyPattern, "currency code")
+	indicatorText = pattern(indicatorPattern, "credit debit indicator")
+)
+
+func dateText(s string) error {
+	if _, err := time.Parse("2006-01-02", s); err != nil {
+		return fmt.Errorf("%q is not a valid date", s)
+	}
+	return nil
+}
+
+func dateTimeText(s string) error {
+	if _, err := parseDateTime(s); err != nil {
+		return fmt.Errorf("%q is not a valid date time", s)
+	}
+	return nil
+}
+
+// parseDateTime parses an ISODateTime, its offset is optional
+func parseDateTime(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		t, err = time.Parse("2006-01-02T15:04:05.999999999", s)
+	}
+	return t, err
+}
+
+var ibanPattern = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[a-zA-Z0-9]{1,30}$`)
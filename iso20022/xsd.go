@@ -0,0 +1,345 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseXSD builds the schema of the document an ISO 20022 message definition describes. It reads the
+// subset of XML Schema the catalogue uses: named complex types holding a sequence or a choice of
+// elements or simple content with attributes, and named simple types restricting a built-in type
+// with length, pattern, enumeration, bound and digit facets. Nothing of the schema is left open.
+func ParseXSD(r io.Reader) (Schema, error) {
+	var doc xsdSchema
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Elements) != 1 {
+		return nil, fmt.Errorf("expected one document element, found %d", len(doc.Elements))
+	}
+
+	b := xsdBuilder{
+		complexTypes: make(map[string]xsdComplexType),
+		simpleTypes:  make(map[string]xsdSimpleType),
+		building:     make(map[string]bool),
+	}
+	for _, t := range doc.ComplexTypes {
+		b.complexTypes[t.Name] = t
+	}
+	for _, t := range doc.SimpleTypes {
+		b.simpleTypes[t.Name] = t
+	}
+
+	document, err := b.element(doc.Elements[0])
+	if err != nil {
+		return nil, err
+	}
+	return Schema{doc.TargetNamespace: document}, nil
+}
+
+type xsdSchema struct {
+	TargetNamespace string           `xml:"targetNamespace,attr"`
+	Elements        []xsdElement     `xml:"element"`
+	ComplexTypes    []xsdComplexType `xml:"complexType"`
+	SimpleTypes     []xsdSimpleType  `xml:"simpleType"`
+}
+
+type xsdElement struct {
+	Name      string `xml:"name,attr"`
+	Type      string `xml:"type,attr"`
+	MinOccurs string `xml:"minOccurs,attr"`
+	MaxOccurs string `xml:"maxOccurs,attr"`
+}
+
+type xsdComplexType struct {
+	Name          string            `xml:"name,attr"`
+	Sequence      *xsdGroup         `xml:"sequence"`
+	Choice        *xsdGroup         `xml:"choice"`
+	SimpleContent *xsdSimpleContent `xml:"simpleContent"`
+}
+
+type xsdGroup struct {
+	Elements []xsdElement `xml:"element"`
+}
+
+type xsdSimpleContent struct {
+	Extension struct {
+		Base       string         `xml:"base,attr"`
+		Attributes []xsdAttribute `xml:"attribute"`
+	} `xml:"extension"`
+}
+
+type xsdAttribute struct {
+	Name string `xml:"name,attr"`
+	Type string `xml:"type,attr"`
+	Use  string `xml:"use,attr"`
+}
+
+type xsdSimpleType struct {
+	Name        string `xml:"name,attr"`
+	Restriction struct {
+		Base           string     `xml:"base,attr"`
+		MinLength      *xsdFacet  `xml:"minLength"`
+		MaxLength      *xsdFacet  `xml:"maxLength"`
+		Patterns       []xsdFacet `xml:"pattern"`
+		Enumerations   []xsdFacet `xml:"enumeration"`
+		MinInclusive   *xsdFacet  `xml:"minInclusive"`
+		TotalDigits    *xsdFacet  `xml:"totalDigits"`
+		FractionDigits *xsdFacet  `xml:"fractionDigits"`
+	} `xml:"restriction"`
+}
+
+type xsdFacet struct {
+	Value string `xml:"value,attr"`
+}
+
+// xsdBuilder turns the declarations of a schema into elements, building guards against recursive types
+type xsdBuilder struct {
+	complexTypes map[string]xsdComplexType
+	simpleTypes  map[string]xsdSimpleType
+	building     map[string]bool
+}
+
+func (b *xsdBuilder) element(decl xsdElement) (Element, error) {
+	el := Element{Name: decl.Name, Min: 1, Max: 1}
+
+	var err error
+	if decl.MinOccurs != "" {
+		if el.Min, err = strconv.Atoi(decl.MinOccurs); err != nil {
+			return el, fmt.Errorf("element %s: invalid minOccurs %q", decl.Name, decl.MinOccurs)
+		}
+	}
+	if decl.MaxOccurs == "unbounded" {
+		el.Max = Unbounded
+	} else if decl.MaxOccurs != "" {
+		if el.Max, err = strconv.Atoi(decl.MaxOccurs); err != nil {
+			return el, fmt.Errorf("element %s: invalid maxOccurs %q", decl.Name, decl.MaxOccurs)
+		}
+	}
+
+	t, ok := b.complexTypes[decl.Type]
+	if !ok {
+		el.Text, err = b.simpleType(decl.Type)
+		if err != nil {
+			return el, fmt.Errorf("element %s: %w", decl.Name, err)
+		}
+		return el, nil
+	}
+
+	if b.building[t.Name] {
+		return el, fmt.Errorf("type %s is recursive", t.Name)
+	}
+	b.building[t.Name] = true
+	defer delete(b.building, t.Name)
+
+	switch {
+	case t.Sequence != nil && t.Choice == nil && t.SimpleContent == nil:
+		el.Children, err = b.elements(t.Sequence.Elements)
+	case t.Choice != nil && t.Sequence == nil && t.SimpleContent == nil:
+		el.Children, err = b.elements(t.Choice.Elements)
+		el.Choice = true
+	case t.SimpleContent != nil && t.Sequence == nil && t.Choice == nil:
+		err = b.simpleContent(&el, *t.SimpleContent)
+	default:
+		err = fmt.Errorf("type %s must hold exactly one sequence, choice or simple content", t.Name)
+	}
+	return el, err
+}
+
+func (b *xsdBuilder) elements(decls []xsdElement) ([]Element, error) {
+	if len(decls) == 0 {
+		return nil, errors.New("empty sequence or choice")
+	}
+	children := make([]Element, len(decls))
+	for i, decl := range decls {
+		child, err := b.element(decl)
+		if err != nil {
+			return nil, err
+		}
+		children[i] = child
+	}
+	return children, nil
+}
+
+func (b *xsdBuilder) simpleContent(el *Element, content xsdSimpleContent) error {
+	var err error
+	if el.Text, err = b.simpleType(content.Extension.Base); err != nil {
+		return err
+	}
+	for _, attr := range content.Extension.Attributes {
+		check, err := b.simpleType(attr.Type)
+		if err != nil {
+			return fmt.Errorf("attribute %s: %w", attr.Name, err)
+		}
+		if attr.Use == "required" {
+			el.Attrs = append(el.Attrs, attr.Name)
+		}
+		if check != nil {
+			if el.AttrText == nil {
+				el.AttrText = make(map[string]func(string) error)
+			}
+			el.AttrText[attr.Name] = check
+		}
+	}
+	return nil
+}
+
+// simpleType returns the check of a named simple type or of a built-in one
+func (b *xsdBuilder) simpleType(name string) (func(string) error, error) {
+	if builtin, ok := strings.CutPrefix(name, "xs:"); ok {
+		return xsdBuiltin(builtin)
+	}
+	t, ok := b.simpleTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q", name)
+	}
+
+	restriction := t.Restriction
+	base, err := b.simpleType(restriction.Base)
+	if err != nil {
+		return nil, fmt.Errorf("type %s: %w", name, err)
+	}
+	checks := []func(string) error{}
+	if base != nil {
+		checks = append(checks, base)
+	}
+
+	if facet := restriction.MinLength; facet != nil {
+		n, err := strconv.Atoi(facet.Value)
+		if err != nil {
+			return nil, fmt.Errorf("type %s: invalid minLength %q", name, facet.Value)
+		}
+		checks = append(checks, func(s string) error {
+			if utf8.RuneCountInString(s) < n {
+				return fmt.Errorf("text must be at least %d characters", n)
+			}
+			return nil
+		})
+	}
+	if facet := restriction.MaxLength; facet != nil {
+		n, err := strconv.Atoi(facet.Value)
+		if err != nil {
+			return nil, fmt.Errorf("type %s: invalid maxLength %q", name, facet.Value)
+		}
+		checks = append(checks, func(s string) error {
+			if utf8.RuneCountInString(s) > n {
+				return fmt.Errorf("text must be at most %d characters", n)
+			}
+			return nil
+		})
+	}
+	if len(restriction.Patterns) > 0 {
+		// the patterns of one restriction are alternatives, each matches the whole value
+		alternatives := make([]string, len(restriction.Patterns))
+		for i, facet := range restriction.Patterns {
+			alternatives[i] = "(?:" + facet.Value + ")"
+		}
+		re, err := regexp.Compile("^(?:" + strings.Join(alternatives, "|") + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("type %s: %w", name, err)
+		}
+		checks = append(checks, pattern(re, name))
+	}
+	if len(restriction.Enumerations) > 0 {
+		values := make(map[string]bool)
+		for _, facet := range restriction.Enumerations {
+			values[facet.Value] = true
+		}
+		checks = append(checks, func(s string) error {
+			if !values[strings.TrimSpace(s)] {
+				return fmt.Errorf("%q is not a valid %s", s, name)
+			}
+			return nil
+		})
+	}
+	if facet := restriction.MinInclusive; facet != nil {
+		bound, ok := new(big.Rat).SetString(facet.Value)
+		if !ok {
+			return nil, fmt.Errorf("type %s: invalid minInclusive %q", name, facet.Value)
+		}
+		checks = append(checks, func(s string) error {
+			value, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+			if !ok || value.Cmp(bound) < 0 {
+				return fmt.Errorf("%q is less than %s", s, facet.Value)
+			}
+			return nil
+		})
+	}
+	if facet := restriction.TotalDigits; facet != nil {
+		n, err := strconv.Atoi(facet.Value)
+		if err != nil {
+			return nil, fmt.Errorf("type %s: invalid totalDigits %q", name, facet.Value)
+		}
+		checks = append(checks, func(s string) error {
+			if whole, fraction := decimalDigits(s); len(whole)+len(fraction) > n {
+				return fmt.Errorf("%q has more than %d digits", s, n)
+			}
+			return nil
+		})
+	}
+	if facet := restriction.FractionDigits; facet != nil {
+		n, err := strconv.Atoi(facet.Value)
+		if err != nil {
+			return nil, fmt.Errorf("type %s: invalid fractionDigits %q", name, facet.Value)
+		}
+		checks = append(checks, func(s string) error {
+			if _, fraction := decimalDigits(s); len(fraction) > n {
+				return fmt.Errorf("%q has more than %d fraction digits", s, n)
+			}
+			return nil
+		})
+	}
+
+	return func(s string) error {
+		for _, check := range checks {
+			if err := check(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+var xsdDecimalPattern = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+
+// xsdBuiltin returns the check of a built-in type, strings take any text
+func xsdBuiltin(name string) (func(string) error, error) {
+	switch name {
+	case "string":
+		return nil, nil
+	case "decimal":
+		return func(s string) error {
+			if !xsdDecimalPattern.MatchString(strings.TrimSpace(s)) {
+				return fmt.Errorf("%q is not a valid decimal number", s)
+			}
+			return nil
+		}, nil
+	case "date":
+		return func(s string) error { return dateText(strings.TrimSpace(s)) }, nil
+	case "dateTime":
+		return func(s string) error { return dateTimeText(strings.TrimSpace(s)) }, nil
+	case "boolean":
+		return func(s string) error {
+			switch strings.TrimSpace(s) {
+			case "true", "false", "1", "0":
+				return nil
+			}
+			return fmt.Errorf("%q is not a valid boolean", s)
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported built-in type xs:%s", name)
+}
+
+// decimalDigits returns the significant digits of a decimal before and after its point
+func decimalDigits(s string) (string, string) {
+	s = strings.TrimLeft(strings.TrimSpace(s), "+-")
+	whole, fraction, _ := strings.Cut(s, ".")
+	return strings.TrimLeft(whole, "0"), strings.TrimRight(fraction, "0")
+}
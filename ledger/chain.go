@@ -0,0 +1,88 @@
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+)
+
+const (
+	BreakUnhashed     = "entry is not hashed"
+	BreakHashMismatch = "hash does not match the entry"
+	BreakPrevMismatch = "prev_hash does not match the previous entry"
+)
+
+// ChainBreak is an entry where the hash chain of its account does not verify
+type ChainBreak struct {
+	EntryID int64  `json:"entry_id"`
+	Reason  string `json:"reason"`
+	// PrevEntryID is the entry the chain expected to follow, 0 at the start of the chain
+	PrevEntryID int64 `json:"prev_entry_id"`
+}
+
+// ChainVerification is the result of walking the hash chain of an account
+type ChainVerification struct {
+	AccountID int64 `json:"account_id"`
+	Entries   int   `json:"entries"`
+	// Hashed counts the entries in the chain, older entries written before the chain are not
+	Hashed int          `json:"hashed"`
+	Head   string       `json:"head"`
+	OK     bool         `json:"ok"`
+	Breaks []ChainBreak `json:"breaks"`
+}
+
+// VerifyChain walks the entries of an account in id order. The chain starts at the first hashed entry,
+// from there every entry must be hashed, hash its own contents and link to the entry before it.
+func VerifyChain(accountID int64, entries []db.Entry) ChainVerification {
+	verification := ChainVerification{AccountID: accountID, Entries: len(entries), Breaks: []ChainBreak{}}
+
+	var prev *db.Entry
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Hash == nil {
+			if prev != nil {
+				verification.Breaks = append(verification.Breaks, ChainBreak{
+					EntryID: entry.ID, Reason: BreakUnhashed, PrevEntryID: prev.ID,
+				})
+			}
+			continue
+		}
+
+		var prevHash []byte
+		var prevID int64
+		if prev != nil {
+			prevHash, prevID = prev.Hash, prev.ID
+		}
+		if !bytes.Equal(entry.PrevHash, prevHash) {
+			verification.Breaks = append(verification.Breaks, ChainBreak{
+				EntryID: entry.ID, Reason: BreakPrevMismatch, PrevEntryID: prevID,
+			})
+		}
+		if !bytes.Equal(entry.Hash, db.EntryHash(*entry, entry.PrevHash)) {
+			verification.Breaks = append(verification.Breaks, ChainBreak{
+				EntryID: entry.ID, Reason: BreakHashMismatch, PrevEntryID: prevID,
+			})
+		}
+
+		verification.Hashed++
+		prev = entry
+	}
+
+	if prev != nil {
+		verification.Head = hex.EncodeToString(prev.Hash)
+	}
+	verification.OK = len(verification.Breaks) == 0
+	return verification
+}
+
+// VerifyAccountChain loads the entries of the account and verifies its hash chain
+func VerifyAccountChain(ctx context.Context, q db.Querier, accountID int64) (ChainVerification, error) {
+	entries, err := q.ListAccountChainEntries(ctx, accountID)
+	if err != nil {
+		return ChainVerification{}, fmt.Errorf("cannot list the entries of account %d: %w", accountID, err)
+	}
+	return VerifyChain(accountID, entries), nil
+}
@@ -0,0 +1,123 @@
+package ledger
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+// chainEntries builds a valid chain of n entries for the account, after the legacy unhashed entries
+func chainEntries(accountID int64, legacy int, n int) []db.Entry {
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var entries []db.Entry
+	var prevHash []byte
+	for i := 0; i < legacy+n; i++ {
+		entry := db.Entry{
+			ID:          int64(i + 1),
+			AccountID:   accountID,
+			Amount:      int64(100 * (i + 1)),
+			CreatedAt:   createdAt.Add(time.Duration(i) * time.Minute),
+			Description: "transfer",
+			Metadata:    []byte("{}"),
+		}
+		if i >= legacy {
+			entry.PrevHash = prevHash
+			entry.Hash = db.EntryHash(entry, prevHash)
+			prevHash = entry.Hash
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestVerifyChain(t *testing.T) {
+	entries := chainEntries(7, 2, 3)
+
+	verification := VerifyChain(7, entries)
+	require.True(t, verification.OK)
+	require.Empty(t, verification.Breaks)
+	require.Equal(t, 5, verification.Entries)
+	require.Equal(t, 3, verification.Hashed)
+	require.Equal(t, hex.EncodeToString(entries[4].Hash), verification.Head)
+}
+
+func TestVerifyChainEmpty(t *testing.T) {
+	verification := VerifyChain(7, nil)
+	require.True(t, verification.OK)
+	require.Zero(t, verification.Hashed)
+	require.Empty(t, verification.Head)
+}
+
+func TestVerifyChainBreaks(t *testing.T) {
+	testCases := []struct {
+		name   string
+		tamper func(entries []db.Entry) []db.Entry
+		breaks []ChainBreak
+	}{
+		{
+			name: "AmountChanged",
+			tamper: func(entries []db.Entry) []db.Entry {
+				entries[1].Amount = 1
+				return entries
+			},
+			breaks: []ChainBreak{{EntryID: 2, Reason: BreakHashMismatch, PrevEntryID: 1}},
+		},
+		{
+			name: "EntryDeleted",
+			tamper: func(entries []db.Entry) []db.Entry {
+				return append(entries[:1], entries[2:]...)
+			},
+			breaks: []ChainBreak{{EntryID: 3, Reason: BreakPrevMismatch, PrevEntryID: 1}},
+		},
+		{
+			name: "HashRemoved",
+			tamper: func(entries []db.Entry) []db.Entry {
+				entries[1].Hash = nil
+				return entries
+			},
+			breaks: []ChainBreak{
+				{EntryID: 2, Reason: BreakUnhashed, PrevEntryID: 1},
+				{EntryID: 3, Reason: BreakPrevMismatch, PrevEntryID: 1},
+			},
+		},
+		{
+			name: "ChainRewritten",
+			tamper: func(entries []db.Entry) []db.Entry {
+				// rehashing the changed entry still breaks the link of the next one
+				entries[1].Amount = 1
+				entries[1].Hash = db.EntryHash(entries[1], entries[1].PrevHash)
+				return entries
+			},
+			breaks: []ChainBreak{{EntryID: 3, Reason: BreakPrevMismatch, PrevEntryID: 2}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			verification := VerifyChain(7, tc.tamper(chainEntries(7, 0, 3)))
+			require.False(t, verification.OK)
+			require.Equal(t, tc.breaks, verification.Breaks)
+		})
+	}
+}
+
+func TestVerifyAccountChain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListAccountChainEntries(gomock.Any(), gomock.Eq(int64(7))).Times(1).Return(chainEntries(7, 0, 2), nil)
+
+	verification, err := VerifyAccountChain(context.Background(), store, 7)
+	require.NoError(t, err)
+	require.True(t, verification.OK)
+	require.Equal(t, int64(7), verification.AccountID)
+	require.Equal(t, 2, verification.Hashed)
+}
@@ -0,0 +1,194 @@
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+)
+
+// checkpointVersion prefixes the signed message of a checkpoint
+const checkpointVersion = "ledger-checkpoint:v1"
+
+// ParseSigningKey reads the hex encoded 32 byte Ed25519 seed checkpoints are signed with
+func ParseSigningKey(seed string) (ed25519.PrivateKey, error) {
+	b, err := hex.DecodeString(seed)
+	if err != nil || len(b) != ed25519.SeedSize {
+		return nil, fmt.Errorf("signing key must be %d hex encoded bytes", ed25519.SeedSize)
+	}
+	return ed25519.NewKeyFromSeed(b), nil
+}
+
+// leafHash commits to the chain head of an account, the 0x00 prefix keeps leaves apart from nodes (RFC 6962)
+func leafHash(accountID int64, head []byte) []byte {
+	var id [8]byte
+	binary.BigEndian.PutUint64(id[:], uint64(accountID))
+
+	h := sha256.New()
+	h.Write([]byte{0x00})
+	h.Write(id[:])
+	h.Write(head)
+	return h.Sum(nil)
+}
+
+func nodeHash(left []byte, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0x01})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// MerkleRoot is the RFC 6962 Merkle tree hash of the leaf hashes
+func MerkleRoot(leaves [][]byte) []byte {
+	switch len(leaves) {
+	case 0:
+		empty := sha256.Sum256(nil)
+		return empty[:]
+	case 1:
+		return leaves[0]
+	}
+
+	// split at the largest power of two smaller than the number of leaves
+	k := 1
+	for k*2 < len(leaves) {
+		k *= 2
+	}
+	return nodeHash(MerkleRoot(leaves[:k]), MerkleRoot(leaves[k:]))
+}
+
+// chainRoot is the Merkle root of the chain heads of every account as of the entry lastEntryID
+func chainRoot(ctx context.Context, q db.Querier, lastEntryID int64) (root []byte, accounts int64, lastID int64, err error) {
+	heads, err := q.ListAccountChainHeads(ctx, lastEntryID)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	leaves := make([][]byte, len(heads))
+	for i, head := range heads {
+		leaves[i] = leafHash(head.AccountID, head.Hash)
+		if head.ID > lastID {
+			lastID = head.ID
+		}
+	}
+	return MerkleRoot(leaves), int64(len(heads)), lastID, nil
+}
+
+// checkpointMessage is what the signature of a checkpoint covers
+func checkpointMessage(root []byte, accounts int64, lastEntryID int64, createdAt time.Time) []byte {
+	var b bytes.Buffer
+	b.WriteString(checkpointVersion)
+	b.Write(root)
+	binary.Write(&b, binary.BigEndian, accounts)
+	binary.Write(&b, binary.BigEndian, lastEntryID)
+	binary.Write(&b, binary.BigEndian, createdAt.UnixMicro())
+	return b.Bytes()
+}
+
+// CreateCheckpoint signs the Merkle root of the current chain heads of every account and stores it
+func CreateCheckpoint(ctx context.Context, store db.Store, key ed25519.PrivateKey, now time.Time) (db.LedgerCheckpoint, error) {
+	root, accounts, lastEntryID, err := chainRoot(ctx, store, math.MaxInt64)
+	if err != nil {
+		return db.LedgerCheckpoint{}, err
+	}
+
+	// postgres keeps microseconds, the signed time must survive the round trip
+	createdAt := now.UTC().Truncate(time.Microsecond)
+
+	return store.CreateLedgerCheckpoint(ctx, db.CreateLedgerCheckpointParams{
+		Root:        root,
+		Accounts:    accounts,
+		LastEntryID: lastEntryID,
+		PublicKey:   key.Public().(ed25519.PublicKey),
+		Signature:   ed25519.Sign(key, checkpointMessage(root, accounts, lastEntryID, createdAt)),
+		CreatedAt:   createdAt,
+	})
+}
+
+// VerifySignature checks the checkpoint was signed by the key, callers must trust the key itself
+func VerifySignature(checkpoint db.LedgerCheckpoint, key ed25519.PublicKey) bool {
+	message := checkpointMessage(checkpoint.Root, checkpoint.Accounts, checkpoint.LastEntryID, checkpoint.CreatedAt)
+	return ed25519.Verify(key, message, checkpoint.Signature)
+}
+
+// VerifyCheckpoint recomputes the root of the checkpoint from the entries it covers, a different root
+// means covered entries were changed or deleted even if the chains were rewritten consistently.
+// An entry committed after the checkpoint with a lower id than its last entry also changes the root,
+// so verify checkpoints some time after taking them rather than right away.
+func VerifyCheckpoint(ctx context.Context, q db.Querier, checkpoint db.LedgerCheckpoint) (bool, error) {
+	root, accounts, _, err := chainRoot(ctx, q, checkpoint.LastEntryID)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(root, checkpoint.Root) && accounts == checkpoint.Accounts, nil
+}
+
+// ErrNoTrustedKey is returned when checkpoints are verified without the key they must be signed with
+var ErrNoTrustedKey = errors.New("checkpoints cannot be verified without the public key they must be signed with")
+
+// CheckpointVerification is the outcome of verifying a checkpoint
+type CheckpointVerification struct {
+	Checkpoint db.LedgerCheckpoint
+	// Signed is false unless the checkpoint was signed by the trusted key
+	Signed bool
+	// Matches is false when the entries the checkpoint covers no longer have its root
+	Matches bool
+}
+
+// VerifyLatestCheckpoint verifies the latest checkpoint, it is nil when none was taken yet. The signature
+// is checked against the trusted key only, never against the key the checkpoint carries: whoever can
+// write a checkpoint could sign it with a key of their own.
+func VerifyLatestCheckpoint(ctx context.Context, q db.Querier, key ed25519.PublicKey) (*CheckpointVerification, error) {
+	if len(key) != ed25519.PublicKeySize {
+		return nil, ErrNoTrustedKey
+	}
+
+	checkpoints, err := q.ListLedgerCheckpoints(ctx, 1)
+	if err != nil || len(checkpoints) == 0 {
+		return nil, err
+	}
+
+	verification := CheckpointVerification{
+		Checkpoint: checkpoints[0],
+		Signed:     VerifySignature(checkpoints[0], key),
+	}
+	verification.Matches, err = VerifyCheckpoint(ctx, q, checkpoints[0])
+	if err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// Checkpoint is the export format of a checkpoint for external anchoring, binary fields are hex encoded
+type Checkpoint struct {
+	ID          int64     `json:"id"`
+	Root        string    `json:"root"`
+	Accounts    int64     `json:"accounts"`
+	LastEntryID int64     `json:"last_entry_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	PublicKey   string    `json:"public_key"`
+	Signature   string    `json:"signature"`
+	// Message is the exact signed bytes
+	Message string `json:"message"`
+}
+
+func NewCheckpoint(checkpoint db.LedgerCheckpoint) Checkpoint {
+	return Checkpoint{
+		ID:          checkpoint.ID,
+		Root:        hex.EncodeToString(checkpoint.Root),
+		Accounts:    checkpoint.Accounts,
+		LastEntryID: checkpoint.LastEntryID,
+		CreatedAt:   checkpoint.CreatedAt,
+		PublicKey:   hex.EncodeToString(checkpoint.PublicKey),
+		Signature:   hex.EncodeToString(checkpoint.Signature),
+		Message:     hex.EncodeToString(checkpointMessage(checkpoint.Root, checkpoint.Accounts, checkpoint.LastEntryID, checkpoint.CreatedAt)),
+	}
+}
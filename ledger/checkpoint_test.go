@@ -0,0 +1,163 @@
+package ledger
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+const testSigningKey = "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"
+
+func TestParseSigningKey(t *testing.T) {
+	key, err := ParseSigningKey(testSigningKey)
+	require.NoError(t, err)
+	// RFC 8032 test vector 1
+	require.Equal(t, "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+		hex.EncodeToString(key.Public().(ed25519.PublicKey)))
+
+	_, err = ParseSigningKey("abcd")
+	require.Error(t, err)
+	_, err = ParseSigningKey("not hex")
+	require.Error(t, err)
+}
+
+func TestMerkleRoot(t *testing.T) {
+	empty := sha256.Sum256(nil)
+	require.Equal(t, empty[:], MerkleRoot(nil))
+
+	a, b, c := leafHash(1, []byte("a")), leafHash(2, []byte("b")), leafHash(3, []byte("c"))
+	require.Equal(t, a, MerkleRoot([][]byte{a}))
+	require.Equal(t, nodeHash(a, b), MerkleRoot([][]byte{a, b}))
+	require.Equal(t, nodeHash(nodeHash(a, b), c), MerkleRoot([][]byte{a, b, c}))
+	require.NotEqual(t, MerkleRoot([][]byte{a, b}), MerkleRoot([][]byte{b, a}))
+}
+
+func TestCreateCheckpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key, err := ParseSigningKey(testSigningKey)
+	require.NoError(t, err)
+
+	heads := []db.ListAccountChainHeadsRow{
+		{AccountID: 1, ID: 12, Hash: []byte("head-1")},
+		{AccountID: 2, ID: 11, Hash: []byte("head-2")},
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 123456789, time.Local)
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListAccountChainHeads(gomock.Any(), gomock.Eq(int64(math.MaxInt64))).Times(1).Return(heads, nil)
+	store.EXPECT().CreateLedgerCheckpoint(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateLedgerCheckpointParams) (db.LedgerCheckpoint, error) {
+			return db.LedgerCheckpoint{
+				ID:          1,
+				Root:        arg.Root,
+				Accounts:    arg.Accounts,
+				LastEntryID: arg.LastEntryID,
+				PublicKey:   arg.PublicKey,
+				Signature:   arg.Signature,
+				CreatedAt:   arg.CreatedAt,
+			}, nil
+		})
+
+	checkpoint, err := CreateCheckpoint(context.Background(), store, key, now)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), checkpoint.Accounts)
+	require.Equal(t, int64(12), checkpoint.LastEntryID)
+	require.Equal(t, MerkleRoot([][]byte{leafHash(1, []byte("head-1")), leafHash(2, []byte("head-2"))}), checkpoint.Root)
+	require.Equal(t, now.UTC().Truncate(time.Microsecond), checkpoint.CreatedAt)
+
+	require.True(t, VerifySignature(checkpoint, key.Public().(ed25519.PublicKey)))
+
+	other, err := ParseSigningKey(testSigningKey[2:] + "00")
+	require.NoError(t, err)
+	require.False(t, VerifySignature(checkpoint, other.Public().(ed25519.PublicKey)))
+
+	tampered := checkpoint
+	tampered.LastEntryID++
+	require.False(t, VerifySignature(tampered, key.Public().(ed25519.PublicKey)))
+
+	export := NewCheckpoint(checkpoint)
+	require.Equal(t, hex.EncodeToString(checkpoint.Root), export.Root)
+	message, err := hex.DecodeString(export.Message)
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(key.Public().(ed25519.PublicKey), message, checkpoint.Signature))
+
+	// the entries the checkpoint covers still match
+	store.EXPECT().ListAccountChainHeads(gomock.Any(), gomock.Eq(int64(12))).Times(1).Return(heads, nil)
+	matches, err := VerifyCheckpoint(context.Background(), store, checkpoint)
+	require.NoError(t, err)
+	require.True(t, matches)
+
+	// a rewritten chain head no longer does
+	rewritten := []db.ListAccountChainHeadsRow{heads[0], {AccountID: 2, ID: 11, Hash: []byte("forged")}}
+	store.EXPECT().ListAccountChainHeads(gomock.Any(), gomock.Eq(int64(12))).Times(1).Return(rewritten, nil)
+	matches, err = VerifyCheckpoint(context.Background(), store, checkpoint)
+	require.NoError(t, err)
+	require.False(t, matches)
+}
+
+func TestVerifyLatestCheckpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key, err := ParseSigningKey(testSigningKey)
+	require.NoError(t, err)
+	foreign, err := ParseSigningKey(testSigningKey[2:] + "00")
+	require.NoError(t, err)
+	trusted := key.Public().(ed25519.PublicKey)
+
+	heads := []db.ListAccountChainHeadsRow{{AccountID: 1, ID: 12, Hash: []byte("head-1")}}
+	root := MerkleRoot([][]byte{leafHash(1, []byte("head-1"))})
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// signed returns a checkpoint of the heads signed by the key, carrying the public key of the signer
+	signed := func(signer ed25519.PrivateKey) db.LedgerCheckpoint {
+		return db.LedgerCheckpoint{
+			ID:          1,
+			Root:        root,
+			Accounts:    1,
+			LastEntryID: 12,
+			PublicKey:   signer.Public().(ed25519.PublicKey),
+			Signature:   ed25519.Sign(signer, checkpointMessage(root, 1, 12, createdAt)),
+			CreatedAt:   createdAt,
+		}
+	}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListLedgerCheckpoints(gomock.Any(), gomock.Eq(int32(1))).Times(1).Return([]db.LedgerCheckpoint{signed(key)}, nil)
+	store.EXPECT().ListAccountChainHeads(gomock.Any(), gomock.Eq(int64(12))).Times(2).Return(heads, nil)
+
+	verification, err := VerifyLatestCheckpoint(context.Background(), store, trusted)
+	require.NoError(t, err)
+	require.True(t, verification.Signed)
+	require.True(t, verification.Matches)
+
+	// a checkpoint signed by another key fails even though it carries that key and its root matches
+	forged := signed(foreign)
+	require.True(t, VerifySignature(forged, forged.PublicKey))
+	store.EXPECT().ListLedgerCheckpoints(gomock.Any(), gomock.Eq(int32(1))).Times(1).Return([]db.LedgerCheckpoint{forged}, nil)
+
+	verification, err = VerifyLatestCheckpoint(context.Background(), store, trusted)
+	require.NoError(t, err)
+	require.False(t, verification.Signed)
+	require.True(t, verification.Matches)
+
+	// there is nothing to verify without a trusted key, the embedded one is never used instead
+	_, err = VerifyLatestCheckpoint(context.Background(), store, nil)
+	require.ErrorIs(t, err, ErrNoTrustedKey)
+
+	store.EXPECT().ListLedgerCheckpoints(gomock.Any(), gomock.Eq(int32(1))).Times(1).Return([]db.LedgerCheckpoint{}, nil)
+	verification, err = VerifyLatestCheckpoint(context.Background(), store, trusted)
+	require.NoError(t, err)
+	require.Nil(t, verification)
+}
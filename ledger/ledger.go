@@ -0,0 +1,188 @@
+// Package ledger checks the double-entry invariants of the accounts, entries and transfers:
+//
+//   - the balance of every account equals the sum of its entries
+//   - every transfer has exactly two entries, the amount leaving one account and entering the other
+//   - every entry belongs to a transfer, so the entries of a currency sum to zero
+//
+// An entry references the transfer it is a leg of and must have the account and the signed
+// amount of that leg. Entries written before the reference existed were linked once by the
+// migration adding it, an entry it could not pair with a leg has none and is an orphan.
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+// BalanceMismatch is an account whose balance differs from the sum of its entries
+type BalanceMismatch struct {
+	AccountID    int64       `json:"account_id"`
+	Balance      utils.Money `json:"balance"`
+	EntriesTotal utils.Money `json:"entries_total"`
+}
+
+// OrphanEntry is an entry no transfer accounts for
+type OrphanEntry struct {
+	ID        int64       `json:"id"`
+	AccountID int64       `json:"account_id"`
+	Amount    utils.Money `json:"amount"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// UnbalancedTransfer is a transfer missing one or both of its entries, or with a non-positive amount
+type UnbalancedTransfer struct {
+	ID            int64       `json:"id"`
+	FromAccountID int64       `json:"from_account_id"`
+	ToAccountID   int64       `json:"to_account_id"`
+	Amount        utils.Money `json:"amount"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+// CurrencyTotal sums the accounts and entries of a currency, the entries must sum to zero
+type CurrencyTotal struct {
+	Currency     string      `json:"currency"`
+	Accounts     int64       `json:"accounts"`
+	Entries      int64       `json:"entries"`
+	Balance      utils.Money `json:"balance"`
+	EntriesTotal utils.Money `json:"entries_total"`
+}
+
+// Balanced reports whether the entries of the currency sum to zero
+func (total CurrencyTotal) Balanced() bool {
+	return total.EntriesTotal.IsZero()
+}
+
+type Report struct {
+	CheckedAt           time.Time            `json:"checked_at"`
+	OK                  bool                 `json:"ok"`
+	BalanceMismatches   []BalanceMismatch    `json:"balance_mismatches"`
+	OrphanEntries       []OrphanEntry        `json:"orphan_entries"`
+	UnbalancedTransfers []UnbalancedTransfer `json:"unbalanced_transfers"`
+	Totals              []CurrencyTotal      `json:"totals"`
+}
+
+// Check scans the whole ledger in one snapshot and reports every broken invariant
+func Check(ctx context.Context, store db.Store) (Report, error) {
+	report := Report{CheckedAt: time.Now()}
+
+	err := store.ReadTx(ctx, func(q db.Querier) error {
+		mismatches, err := q.ListAccountBalanceMismatches(ctx)
+		if err != nil {
+			return err
+		}
+		report.BalanceMismatches = make([]BalanceMismatch, len(mismatches))
+		for i, row := range mismatches {
+			mismatch := BalanceMismatch{AccountID: row.ID}
+			if mismatch.Balance, err = utils.NewMoney(row.Balance, row.Currency); err != nil {
+				return err
+			}
+			if mismatch.EntriesTotal, err = utils.NewMoney(row.EntriesTotal, row.Currency); err != nil {
+				return err
+			}
+			report.BalanceMismatches[i] = mismatch
+		}
+
+		orphans, err := q.ListOrphanEntries(ctx)
+		if err != nil {
+			return err
+		}
+		report.OrphanEntries = make([]OrphanEntry, len(orphans))
+		for i, row := range orphans {
+			entry := OrphanEntry{ID: row.ID, AccountID: row.AccountID, CreatedAt: row.CreatedAt}
+			if entry.Amount, err = utils.NewMoney(row.Amount, row.Currency); err != nil {
+				return err
+			}
+			report.OrphanEntries[i] = entry
+		}
+
+		transfers, err := q.ListUnbalancedTransfers(ctx)
+		if err != nil {
+			return err
+		}
+		report.UnbalancedTransfers = make([]UnbalancedTransfer, len(transfers))
+		for i, row := range transfers {
+			transfer := UnbalancedTransfer{
+				ID:            row.ID,
+				FromAccountID: row.FromAccountID,
+				ToAccountID:   row.ToAccountID,
+				CreatedAt:     row.CreatedAt,
+			}
+			if transfer.Amount, err = utils.NewMoney(row.Amount, row.Currency); err != nil {
+				return err
+			}
+			report.UnbalancedTransfers[i] = transfer
+		}
+
+		totals, err := q.ListCurrencyLedgerTotals(ctx)
+		if err != nil {
+			return err
+		}
+		report.Totals = make([]CurrencyTotal, len(totals))
+		for i, row := range totals {
+			total := CurrencyTotal{Currency: row.Currency, Accounts: row.Accounts, Entries: row.Entries}
+			if total.Balance, err = utils.NewMoney(row.Balance, row.Currency); err != nil {
+				return err
+			}
+			if total.EntriesTotal, err = utils.NewMoney(row.EntriesTotal, row.Currency); err != nil {
+				return err
+			}
+			report.Totals[i] = total
+		}
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	report.OK = len(report.BalanceMismatches) == 0 && len(report.OrphanEntries) == 0 && len(report.UnbalancedTransfers) == 0
+	for _, total := range report.Totals {
+		report.OK = report.OK && total.Balanced()
+	}
+	return report, nil
+}
+
+// WriteText writes the report for people, one line per finding
+func (report Report) WriteText(w io.Writer) error {
+	status := "OK"
+	if !report.OK {
+		status = "FAILED"
+	}
+
+	lines := []string{fmt.Sprintf("ledger check %s at %s", status, report.CheckedAt.UTC().Format(time.RFC3339))}
+	lines = append(lines, fmt.Sprintf("balance mismatches: %d", len(report.BalanceMismatches)))
+	for _, mismatch := range report.BalanceMismatches {
+		lines = append(lines, fmt.Sprintf("  account %d: balance %s, entries %s",
+			mismatch.AccountID, mismatch.Balance, mismatch.EntriesTotal))
+	}
+	lines = append(lines, fmt.Sprintf("orphan entries: %d", len(report.OrphanEntries)))
+	for _, entry := range report.OrphanEntries {
+		lines = append(lines, fmt.Sprintf("  entry %d: account %d, %s at %s",
+			entry.ID, entry.AccountID, entry.Amount, entry.CreatedAt.UTC().Format(time.RFC3339Nano)))
+	}
+	lines = append(lines, fmt.Sprintf("unbalanced transfers: %d", len(report.UnbalancedTransfers)))
+	for _, transfer := range report.UnbalancedTransfers {
+		lines = append(lines, fmt.Sprintf("  transfer %d: account %d to %d, %s at %s",
+			transfer.ID, transfer.FromAccountID, transfer.ToAccountID, transfer.Amount, transfer.CreatedAt.UTC().Format(time.RFC3339Nano)))
+	}
+	lines = append(lines, "totals:")
+	for _, total := range report.Totals {
+		balanced := ""
+		if !total.Balanced() {
+			balanced = ", UNBALANCED"
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %d accounts, %d entries, balances %s, entries %s%s",
+			total.Currency, total.Accounts, total.Entries, total.Balance, total.EntriesTotal, balanced))
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
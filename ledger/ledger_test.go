@@ -0,0 +1,105 @@
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func stubReadTx(store *mockdb.MockStore) {
+	store.EXPECT().ReadTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, fn func(db.Querier) error) error {
+			return fn(store)
+		})
+}
+
+func TestCheckBalancedLedger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	stubReadTx(store)
+	store.EXPECT().ListAccountBalanceMismatches(gomock.Any()).Times(1).Return(nil, nil)
+	store.EXPECT().ListOrphanEntries(gomock.Any()).Times(1).Return(nil, nil)
+	store.EXPECT().ListUnbalancedTransfers(gomock.Any()).Times(1).Return(nil, nil)
+	store.EXPECT().ListCurrencyLedgerTotals(gomock.Any()).Times(1).Return([]db.ListCurrencyLedgerTotalsRow{
+		{Currency: utils.EUR, Accounts: 3, Balance: 5000, Entries: 4, EntriesTotal: 0},
+		{Currency: utils.USD, Accounts: 2, Balance: 0, Entries: 0, EntriesTotal: 0},
+	}, nil)
+
+	report, err := Check(context.Background(), store)
+	require.NoError(t, err)
+	require.True(t, report.OK)
+	require.Len(t, report.Totals, 2)
+	require.Equal(t, "50.00", report.Totals[0].Balance.Decimal())
+
+	var out bytes.Buffer
+	require.NoError(t, report.WriteText(&out))
+	require.Contains(t, out.String(), "ledger check OK")
+	require.Contains(t, out.String(), "EUR: 3 accounts, 4 entries, balances 50.00 EUR, entries 0.00 EUR\n")
+}
+
+func TestCheckBrokenLedger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	store := mockdb.NewMockStore(ctrl)
+	stubReadTx(store)
+	store.EXPECT().ListAccountBalanceMismatches(gomock.Any()).Times(1).Return([]db.ListAccountBalanceMismatchesRow{
+		{ID: 1, Currency: utils.USD, Balance: 1000, EntriesTotal: 750},
+	}, nil)
+	store.EXPECT().ListOrphanEntries(gomock.Any()).Times(1).Return([]db.ListOrphanEntriesRow{
+		{ID: 10, AccountID: 1, Amount: -250, CreatedAt: createdAt, Currency: utils.USD},
+	}, nil)
+	store.EXPECT().ListUnbalancedTransfers(gomock.Any()).Times(1).Return([]db.ListUnbalancedTransfersRow{
+		{ID: 4, FromAccountID: 1, ToAccountID: 2, Amount: 250, CreatedAt: createdAt, Currency: utils.USD},
+	}, nil)
+	store.EXPECT().ListCurrencyLedgerTotals(gomock.Any()).Times(1).Return([]db.ListCurrencyLedgerTotalsRow{
+		{Currency: utils.USD, Accounts: 2, Balance: 1000, Entries: 1, EntriesTotal: -250},
+	}, nil)
+
+	report, err := Check(context.Background(), store)
+	require.NoError(t, err)
+	require.False(t, report.OK)
+	require.False(t, report.Totals[0].Balanced())
+
+	var out bytes.Buffer
+	require.NoError(t, report.WriteText(&out))
+	require.Equal(t, `ledger check FAILED at `+report.CheckedAt.UTC().Format(time.RFC3339)+`
+balance mismatches: 1
+  account 1: balance 10.00 USD, entries 7.50 USD
+orphan entries: 1
+  entry 10: account 1, -2.50 USD at 2026-03-01T12:00:00Z
+unbalanced transfers: 1
+  transfer 4: account 1 to 2, 2.50 USD at 2026-03-01T12:00:00Z
+totals:
+  USD: 2 accounts, 1 entries, balances 10.00 USD, entries -2.50 USD, UNBALANCED
+`, out.String())
+}
+
+func TestCheckUnbalancedCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	stubReadTx(store)
+	store.EXPECT().ListAccountBalanceMismatches(gomock.Any()).Times(1).Return(nil, nil)
+	store.EXPECT().ListOrphanEntries(gomock.Any()).Times(1).Return(nil, nil)
+	store.EXPECT().ListUnbalancedTransfers(gomock.Any()).Times(1).Return(nil, nil)
+	store.EXPECT().ListCurrencyLedgerTotals(gomock.Any()).Times(1).Return([]db.ListCurrencyLedgerTotalsRow{
+		{Currency: utils.USD, Accounts: 1, Balance: 10, Entries: 1, EntriesTotal: 10},
+	}, nil)
+
+	report, err := Check(context.Background(), store)
+	require.NoError(t, err)
+	require.False(t, report.OK)
+}
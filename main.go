@@ -23,7 +23,12 @@ func main() {
 		log.Fatal("cannot connect to db: ", err)
 	}
 
-	store := db.NewStore(conn)
+	policy, err := db.NewTransferPolicy(*config)
+	if err != nil {
+		log.Fatal("cannot create transfer policy: ", err)
+	}
+
+	store := db.NewStore(conn, policy)
 	server, err := api.NewServer(*config, store)
 	if err != nil {
 		log.Fatal("cannot create server: ", err)
@@ -0,0 +1,186 @@
+package statement
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/lordofthemind/backendMasterGo/blob"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+// ArchiveFormat is the format of the monthly statements
+const ArchiveFormat = FormatPDF
+
+// accountPageSize is the number of accounts listed at a time by the monthly job
+const accountPageSize = 100
+
+// Archiver generates the monthly statements of every active account and keeps them in the blob storage
+type Archiver struct {
+	store db.Store
+	blobs blob.Storage
+	now   func() time.Time
+}
+
+func NewArchiver(store db.Store, blobs blob.Storage) *Archiver {
+	return &Archiver{
+		store: store,
+		blobs: blobs,
+		now:   time.Now,
+	}
+}
+
+// monthPeriod returns the first day of the month of t and the first day of the next month
+func monthPeriod(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// BlobKey is where the statement of the account for the month starting at periodStart is stored
+func BlobKey(accountID int64, periodStart time.Time, format string) string {
+	return fmt.Sprintf("statements/%d/%s.%s", accountID, periodStart.Format("2006-01"), format)
+}
+
+type ArchiveSummary struct {
+	Month      string `json:"month"`
+	Statements int    `json:"statements"`
+	// Failed maps the accounts whose statement could not be archived to the reason
+	Failed map[int64]string `json:"failed,omitempty"`
+}
+
+// ArchiveMonth archives the statement of the month of t for every account active and open before the
+// month ended. Accounts that already have the statement are skipped, so the job can be run again after a failure.
+func (archiver *Archiver) ArchiveMonth(ctx context.Context, month time.Time) (ArchiveSummary, error) {
+	start, end := monthPeriod(month)
+	summary := ArchiveSummary{Month: start.Format("2006-01"), Failed: make(map[int64]string)}
+
+	if end.After(archiver.now()) {
+		return summary, fmt.Errorf("%s has not ended yet", summary.Month)
+	}
+
+	var afterID int64
+	for {
+		accounts, err := archiver.store.ListStatementAccounts(ctx, db.ListStatementAccountsParams{
+			PeriodEndTime: end,
+			AfterID:       afterID,
+			PeriodStart:   start,
+			Format:        ArchiveFormat,
+			PageLimit:     accountPageSize,
+		})
+		if err != nil {
+			return summary, err
+		}
+
+		for _, account := range accounts {
+			afterID = account.ID
+			// one broken account must not hold back everyone else's statement
+			if _, err := archiver.Archive(ctx, account, start); err != nil {
+				summary.Failed[account.ID] = err.Error()
+				continue
+			}
+			summary.Statements++
+		}
+
+		if len(accounts) < accountPageSize {
+			return summary, nil
+		}
+	}
+}
+
+// Archive stores the statement of the account for the month starting at periodStart,
+// indexes it and notifies the users who can view the account
+func (archiver *Archiver) Archive(ctx context.Context, account db.Account, periodStart time.Time) (db.Statement, error) {
+	start, end := monthPeriod(periodStart)
+
+	holder, err := archiver.store.GetUser(ctx, account.Owner)
+	if err != nil {
+		return db.Statement{}, err
+	}
+
+	// a month of entries is small enough to render in memory before it is stored
+	var buf bytes.Buffer
+	w, err := NewWriter(ArchiveFormat, &buf)
+	if err != nil {
+		return db.Statement{}, err
+	}
+	balances := &balanceRecorder{Writer: w}
+
+	err = Generate(ctx, archiver.store, Request{
+		Account: account,
+		Holder:  holder.FullName,
+		From:    start,
+		To:      end,
+	}, balances)
+	if err != nil {
+		return db.Statement{}, err
+	}
+
+	key := BlobKey(account.ID, start, ArchiveFormat)
+	sum := sha256.Sum256(buf.Bytes())
+	size := int64(buf.Len())
+
+	err = archiver.blobs.Put(ctx, key, bytes.NewReader(buf.Bytes()))
+	if errors.Is(err, blob.ErrExists) {
+		// an earlier run stored the statement but failed before indexing it,
+		// the stored document stands since it is never replaced
+		size, sum, err = archiver.digest(ctx, key)
+	}
+	if err != nil {
+		return db.Statement{}, err
+	}
+
+	result, err := archiver.store.ArchiveStatementTx(ctx, db.CreateStatementParams{
+		AccountID:      account.ID,
+		PeriodStart:    start,
+		PeriodEnd:      end.AddDate(0, 0, -1),
+		Format:         ArchiveFormat,
+		BlobKey:        key,
+		Size:           size,
+		Sha256:         sum[:],
+		OpeningBalance: balances.opening.Amount(),
+		ClosingBalance: balances.closing.Amount(),
+	})
+	return result.Statement, err
+}
+
+// digest reads back a stored object to index it
+func (archiver *Archiver) digest(ctx context.Context, key string) (int64, [sha256.Size]byte, error) {
+	var sum [sha256.Size]byte
+
+	r, err := archiver.blobs.Open(ctx, key)
+	if err != nil {
+		return 0, sum, err
+	}
+	defer r.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, r)
+	if err != nil {
+		return 0, sum, err
+	}
+	copy(sum[:], h.Sum(nil))
+	return size, sum, nil
+}
+
+// balanceRecorder keeps the opening and closing balances of the statement it passes on
+type balanceRecorder struct {
+	Writer
+	opening utils.Money
+	closing utils.Money
+}
+
+func (w *balanceRecorder) Begin(header Header) error {
+	w.opening = header.Opening
+	return w.Writer.Begin(header)
+}
+
+func (w *balanceRecorder) End(footer Footer) error {
+	w.closing = footer.Closing
+	return w.Writer.End(footer)
+}
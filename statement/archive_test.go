@@ -0,0 +1,149 @@
+package statement
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lordofthemind/backendMasterGo/blob"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestArchiver(t *testing.T, store db.Store) (*Archiver, blob.Storage) {
+	blobs := blob.NewLocalStorage(t.TempDir())
+	archiver := NewArchiver(store, blobs)
+	archiver.now = func() time.Time { return time.Date(2026, 2, 1, 6, 0, 0, 0, time.UTC) }
+	return archiver, blobs
+}
+
+// stubArchive makes every account have an opening balance of 50.00 and the entries
+func stubArchive(store *mockdb.MockStore, accounts []db.Account, entries []db.ListStatementEntriesRow) {
+	byID := make(map[int64]db.Account)
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+
+	store.EXPECT().ReadTx(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, fn func(db.Querier) error) error {
+			return fn(store)
+		})
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, id int64) (db.Account, error) {
+			return byID[id], nil
+		})
+	store.EXPECT().GetLatestBalanceSnapshot(gomock.Any(), gomock.Any()).AnyTimes().
+		Return(db.BalanceSnapshot{Balance: 5000}, nil)
+	store.EXPECT().SumAccountEntriesBetween(gomock.Any(), gomock.Any()).AnyTimes().Return(int64(0), nil)
+	store.EXPECT().SumStatementEntries(gomock.Any(), gomock.Any()).AnyTimes().Return(sumEntries(entries), nil)
+	store.EXPECT().ListStatementEntries(gomock.Any(), gomock.Any()).AnyTimes().Return(entries, nil)
+}
+
+func readBlob(t *testing.T, blobs blob.Storage, key string) []byte {
+	r, err := blobs.Open(context.Background(), key)
+	require.NoError(t, err)
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return data
+}
+
+func TestArchiveMonth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	account1 := testAccount()
+	account2 := testAccount()
+	account2.ID = 43
+	account2.Owner = "broken"
+
+	store := mockdb.NewMockStore(ctrl)
+	archiver, blobs := newTestArchiver(t, store)
+	stubArchive(store, []db.Account{account1, account2}, statementEntries(2))
+
+	store.EXPECT().ListStatementAccounts(gomock.Any(), gomock.Eq(db.ListStatementAccountsParams{
+		PeriodEndTime: testTo,
+		PeriodStart:   testFrom,
+		Format:        FormatPDF,
+		PageLimit:     accountPageSize,
+	})).Times(1).Return([]db.Account{account1, account2}, nil)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq("alice")).Times(1).Return(db.User{Username: "alice", FullName: "Alice"}, nil)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq("broken")).Times(1).Return(db.User{}, errors.New("boom"))
+
+	var archived db.CreateStatementParams
+	store.EXPECT().ArchiveStatementTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateStatementParams) (db.ArchiveStatementTxResult, error) {
+			archived = arg
+			return db.ArchiveStatementTxResult{Statement: db.Statement{ID: 1}}, nil
+		})
+
+	summary, err := archiver.ArchiveMonth(context.Background(), time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, "2026-01", summary.Month)
+	require.Equal(t, 1, summary.Statements)
+	require.Equal(t, map[int64]string{43: "boom"}, summary.Failed)
+
+	require.Equal(t, account1.ID, archived.AccountID)
+	require.Equal(t, testFrom, archived.PeriodStart)
+	require.Equal(t, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), archived.PeriodEnd)
+	require.Equal(t, FormatPDF, archived.Format)
+	require.Equal(t, "statements/42/2026-01.pdf", archived.BlobKey)
+	require.Equal(t, int64(5000), archived.OpeningBalance)
+	require.Equal(t, int64(5750), archived.ClosingBalance)
+
+	data := readBlob(t, blobs, archived.BlobKey)
+	sum := sha256.Sum256(data)
+	require.Equal(t, sum[:], archived.Sha256)
+	require.Equal(t, int64(len(data)), archived.Size)
+	require.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+}
+
+func TestArchiveMonthNotEnded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	archiver, _ := newTestArchiver(t, store)
+	store.EXPECT().ListStatementAccounts(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := archiver.ArchiveMonth(context.Background(), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.Error(t, err)
+}
+
+func TestArchiveKeepsStoredStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	account := testAccount()
+	store := mockdb.NewMockStore(ctrl)
+	archiver, blobs := newTestArchiver(t, store)
+	stubArchive(store, []db.Account{account}, statementEntries(1))
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(account.Owner)).Times(1).Return(db.User{Username: account.Owner}, nil)
+
+	// a previous run stored the statement and failed before indexing it
+	key := BlobKey(account.ID, testFrom, FormatPDF)
+	stored := "%PDF-1.4 stored earlier"
+	require.NoError(t, blobs.Put(context.Background(), key, strings.NewReader(stored)))
+
+	sum := sha256.Sum256([]byte(stored))
+	store.EXPECT().ArchiveStatementTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateStatementParams) (db.ArchiveStatementTxResult, error) {
+			require.Equal(t, key, arg.BlobKey)
+			require.Equal(t, sum[:], arg.Sha256)
+			require.Equal(t, int64(len(stored)), arg.Size)
+			return db.ArchiveStatementTxResult{Statement: db.Statement{ID: 1, BlobKey: arg.BlobKey}}, nil
+		})
+
+	statement, err := archiver.Archive(context.Background(), account, testFrom)
+	require.NoError(t, err)
+	require.Equal(t, key, statement.BlobKey)
+	require.Equal(t, stored, string(readBlob(t, blobs, key)))
+}
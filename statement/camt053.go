@@ -0,0 +1,322 @@
+package statement
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/lordofthemind/backendMasterGo/iso20022"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+const (
+	// camtBooked is the status of every entry, the ledger has no pending entries
+	camtBooked = "BOOK"
+	// camtOpeningBalance and camtClosingBalance are the booked balances at the ends of the period
+	camtOpeningBalance = "OPBD"
+	camtClosingBalance = "CLBD"
+
+	// camtPayments, camtIssuedTransfer and camtReceivedTransfer are the bank transaction codes of
+	// the entries: the outgoing or incoming leg of a book transfer between two accounts of the bank
+	camtPayments         = "PMNT"
+	camtIssuedTransfer   = "ICDT"
+	camtReceivedTransfer = "RCDT"
+	camtBookTransfer     = "BOOK"
+)
+
+// camt053Writer writes an ISO 20022 camt.053.001.08 bank to customer statement. The balances
+// and the transactions summary come before the entries, they are taken from the header.
+type camt053Writer struct {
+	w       *bufio.Writer
+	encoder *xml.Encoder
+	header  Header
+}
+
+func newCAMT053Writer(w io.Writer) *camt053Writer {
+	buffered := bufio.NewWriter(w)
+	encoder := xml.NewEncoder(buffered)
+	encoder.Indent("", "  ")
+	return &camt053Writer{w: buffered, encoder: encoder}
+}
+
+func (c *camt053Writer) start(name string, attrs ...xml.Attr) error {
+	return c.encoder.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}, Attr: attrs})
+}
+
+func (c *camt053Writer) end(name string) error {
+	return c.encoder.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}})
+}
+
+func (c *camt053Writer) element(name string, v interface{}) error {
+	return c.encoder.EncodeElement(v, xml.StartElement{Name: xml.Name{Local: name}})
+}
+
+func (c *camt053Writer) Begin(header Header) error {
+	c.header = header
+
+	if _, err := io.WriteString(c.w, xml.Header); err != nil {
+		return err
+	}
+	if err := c.start("Document", xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: iso20022.Camt053V08}); err != nil {
+		return err
+	}
+	if err := c.start("BkToCstmrStmt"); err != nil {
+		return err
+	}
+
+	err := c.element("GrpHdr", camtGroupHeader{
+		MessageID: fmt.Sprintf("%d-%s", header.AccountID, header.GeneratedAt.UTC().Format("20060102150405.000000")),
+		CreatedAt: camtDateTime(header.GeneratedAt),
+	})
+	if err != nil {
+		return err
+	}
+	if err := c.start("Stmt"); err != nil {
+		return err
+	}
+
+	account := camtAccount{
+		ID:       iso20022.AccountIdentification{Other: &iso20022.GenericAccountIdentification{ID: strconv.FormatInt(header.AccountID, 10)}},
+		Currency: header.Currency,
+	}
+	if header.Holder != "" {
+		account.Owner = &iso20022.Party{Name: iso20022.Truncate(header.Holder, 140)}
+	}
+
+	net, err := header.Credits.Add(header.Debits)
+	if err != nil {
+		return err
+	}
+	total, err := header.Credits.Sub(header.Debits)
+	if err != nil {
+		return err
+	}
+
+	elements := []struct {
+		name  string
+		value interface{}
+	}{
+		{"Id", fmt.Sprintf("%d-%s-%s", header.AccountID, header.From.UTC().Format("20060102"), lastDay(header.To).Format("20060102"))},
+		{"CreDtTm", camtDateTime(header.GeneratedAt)},
+		{"FrToDt", camtPeriod{From: camtDateTime(header.From), To: camtDateTime(header.To)}},
+		{"Acct", account},
+		{"Bal", newCAMTBalance(camtOpeningBalance, header.Opening, header.From)},
+		{"Bal", newCAMTBalance(camtClosingBalance, header.Closing, lastDay(header.To))},
+		{"TxsSummry", camtSummary{
+			Total: camtTotal{
+				Count: header.CreditEntries + header.DebitEntries,
+				Sum:   iso20022.FormatAmount(total),
+				Net: camtNet{
+					Amount:    iso20022.FormatAmount(net),
+					Indicator: iso20022.Indicator(net),
+				},
+			},
+			Credits: camtNumberAndSum{Count: header.CreditEntries, Sum: iso20022.FormatAmount(header.Credits)},
+			Debits:  camtNumberAndSum{Count: header.DebitEntries, Sum: iso20022.FormatAmount(header.Debits)},
+		}},
+	}
+	for _, el := range elements {
+		if err := c.element(el.name, el.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *camt053Writer) Line(line Line) error {
+	indicator := iso20022.Indicator(line.Amount)
+	amount := newCAMTAmount(line.Amount)
+	entryID := strconv.FormatInt(line.EntryID, 10)
+
+	code := camtBankTransactionCode{Domain: camtDomain{Code: camtPayments}}
+	code.Domain.Family.Code = camtReceivedTransfer
+	if line.Amount.IsNegative() {
+		code.Domain.Family.Code = camtIssuedTransfer
+	}
+	code.Domain.Family.SubFamilyCode = camtBookTransfer
+
+	details := camtTransactionDetails{
+		References: camtReferences{
+			AccountServicerReference: entryID,
+			EndToEndID:               iso20022.Truncate(line.Reference, 35),
+		},
+		Amount:    amount,
+		Indicator: indicator,
+	}
+	if line.TransferID != 0 {
+		details.References.TransactionID = strconv.FormatInt(line.TransferID, 10)
+	}
+	if line.Counterparty != "" {
+		// the counterparty paid a credit and was paid by a debit
+		party := &camtRelatedParty{Party: iso20022.Party{Name: iso20022.Truncate(line.Counterparty, 140)}}
+		details.Parties = &camtRelatedParties{Debtor: party}
+		if line.Amount.IsNegative() {
+			details.Parties = &camtRelatedParties{Creditor: party}
+		}
+	}
+	if line.Memo != "" {
+		details.Remittance = &iso20022.RemittanceInformation{Unstructured: []string{iso20022.Truncate(line.Memo, 140)}}
+	}
+
+	return c.element("Ntry", camtEntry{
+		Reference:                entryID,
+		Amount:                   amount,
+		Indicator:                indicator,
+		Status:                   camtCode{Code: camtBooked},
+		BookingDate:              camtDate{DateTime: camtDateTime(line.Time)},
+		ValueDate:                camtDate{Date: line.Time.UTC().Format("2006-01-02")},
+		AccountServicerReference: entryID,
+		BankTransactionCode:      code,
+		Details:                  camtEntryDetails{Transactions: []camtTransactionDetails{details}},
+	})
+}
+
+func (c *camt053Writer) End(footer Footer) error {
+	for _, name := range []string{"Stmt", "BkToCstmrStmt", "Document"} {
+		if err := c.end(name); err != nil {
+			return err
+		}
+	}
+	if err := c.encoder.Flush(); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(c.w, "\n"); err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
+
+func camtDateTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// lastDay is the day of the end of a period that excludes to
+func lastDay(to time.Time) time.Time {
+	return to.UTC().Add(-time.Nanosecond)
+}
+
+func newCAMTAmount(amount utils.Money) iso20022.Amount {
+	return iso20022.Amount{Currency: amount.Currency(), Value: iso20022.FormatAmount(amount)}
+}
+
+func newCAMTBalance(code string, balance utils.Money, day time.Time) camtBalance {
+	return camtBalance{
+		Type:      camtBalanceType{Code: camtCode{Code: code}},
+		Amount:    newCAMTAmount(balance),
+		Indicator: iso20022.Indicator(balance),
+		Date:      camtDate{Date: day.UTC().Format("2006-01-02")},
+	}
+}
+
+type camtGroupHeader struct {
+	MessageID string `xml:"MsgId"`
+	CreatedAt string `xml:"CreDtTm"`
+}
+
+type camtPeriod struct {
+	From string `xml:"FrDtTm"`
+	To   string `xml:"ToDtTm"`
+}
+
+type camtAccount struct {
+	ID       iso20022.AccountIdentification `xml:"Id"`
+	Currency string                         `xml:"Ccy"`
+	Owner    *iso20022.Party                `xml:"Ownr"`
+}
+
+type camtBalance struct {
+	Type      camtBalanceType `xml:"Tp"`
+	Amount    iso20022.Amount `xml:"Amt"`
+	Indicator string          `xml:"CdtDbtInd"`
+	Date      camtDate        `xml:"Dt"`
+}
+
+type camtBalanceType struct {
+	Code camtCode `xml:"CdOrPrtry"`
+}
+
+type camtCode struct {
+	Code string `xml:"Cd"`
+}
+
+// camtDate holds either a date or a date time
+type camtDate struct {
+	Date     string `xml:"Dt,omitempty"`
+	DateTime string `xml:"DtTm,omitempty"`
+}
+
+type camtSummary struct {
+	Total   camtTotal        `xml:"TtlNtries"`
+	Credits camtNumberAndSum `xml:"TtlCdtNtries"`
+	Debits  camtNumberAndSum `xml:"TtlDbtNtries"`
+}
+
+type camtTotal struct {
+	Count int     `xml:"NbOfNtries"`
+	Sum   string  `xml:"Sum"`
+	Net   camtNet `xml:"TtlNetNtry"`
+}
+
+type camtNet struct {
+	Amount    string `xml:"Amt"`
+	Indicator string `xml:"CdtDbtInd"`
+}
+
+type camtNumberAndSum struct {
+	Count int    `xml:"NbOfNtries"`
+	Sum   string `xml:"Sum"`
+}
+
+type camtEntry struct {
+	Reference                string                  `xml:"NtryRef"`
+	Amount                   iso20022.Amount         `xml:"Amt"`
+	Indicator                string                  `xml:"CdtDbtInd"`
+	Status                   camtCode                `xml:"Sts"`
+	BookingDate              camtDate                `xml:"BookgDt"`
+	ValueDate                camtDate                `xml:"ValDt"`
+	AccountServicerReference string                  `xml:"AcctSvcrRef"`
+	BankTransactionCode      camtBankTransactionCode `xml:"BkTxCd"`
+	Details                  camtEntryDetails        `xml:"NtryDtls"`
+}
+
+type camtBankTransactionCode struct {
+	Domain camtDomain `xml:"Domn"`
+}
+
+type camtDomain struct {
+	Code   string `xml:"Cd"`
+	Family struct {
+		Code          string `xml:"Cd"`
+		SubFamilyCode string `xml:"SubFmlyCd"`
+	} `xml:"Fmly"`
+}
+
+type camtEntryDetails struct {
+	Transactions []camtTransactionDetails `xml:"TxDtls"`
+}
+
+type camtTransactionDetails struct {
+	References camtReferences                  `xml:"Refs"`
+	Amount     iso20022.Amount                 `xml:"Amt"`
+	Indicator  string                          `xml:"CdtDbtInd"`
+	Parties    *camtRelatedParties             `xml:"RltdPties"`
+	Remittance *iso20022.RemittanceInformation `xml:"RmtInf"`
+}
+
+type camtReferences struct {
+	AccountServicerReference string `xml:"AcctSvcrRef"`
+	EndToEndID               string `xml:"EndToEndId,omitempty"`
+	TransactionID            string `xml:"TxId,omitempty"`
+}
+
+type camtRelatedParties struct {
+	Debtor   *camtRelatedParty `xml:"Dbtr"`
+	Creditor *camtRelatedParty `xml:"Cdtr"`
+}
+
+type camtRelatedParty struct {
+	Party iso20022.Party `xml:"Pty"`
+}
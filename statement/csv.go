@@ -0,0 +1,79 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	csvOpening = "opening"
+	csvEntry   = "entry"
+	csvClosing = "closing"
+)
+
+var csvColumns = []string{
+	"type", "time", "entry_id", "transfer_id", "counterparty", "memo", "reference", "amount", "balance", "currency",
+}
+
+// csvWriter writes one row per entry between an opening and a closing balance row
+type csvWriter struct {
+	w  *csv.Writer
+	to time.Time
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Begin(header Header) error {
+	c.to = header.To
+	if err := c.w.Write(csvColumns); err != nil {
+		return err
+	}
+	return c.w.Write([]string{
+		csvOpening, header.From.UTC().Format(time.RFC3339), "", "", "", "", "",
+		"", header.Opening.Decimal(), header.Currency,
+	})
+}
+
+func (c *csvWriter) Line(line Line) error {
+	transferID := ""
+	if line.TransferID != 0 {
+		transferID = strconv.FormatInt(line.TransferID, 10)
+	}
+	return c.w.Write([]string{
+		csvEntry,
+		line.Time.UTC().Format(time.RFC3339),
+		strconv.FormatInt(line.EntryID, 10),
+		transferID,
+		csvText(line.Counterparty),
+		csvText(line.Memo),
+		csvText(line.Reference),
+		line.Amount.Decimal(),
+		line.Balance.Decimal(),
+		line.Amount.Currency(),
+	})
+}
+
+func (c *csvWriter) End(footer Footer) error {
+	err := c.w.Write([]string{
+		csvClosing, c.to.UTC().Format(time.RFC3339), "", "", "", "", "",
+		"", footer.Closing.Decimal(), footer.Closing.Currency(),
+	})
+	if err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// csvText keeps spreadsheets from evaluating user written text as a formula
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,107 @@
+package statement
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+)
+
+const (
+	// ofxBankID identifies the bank, OFX requires one and we have no routing number
+	ofxBankID = "0"
+
+	ofxNameSize = 32
+	ofxMemoSize = 255
+)
+
+// ofxWriter writes an OFX 2.2 bank statement. OFX only knows the closing balance,
+// the opening balance goes into the balance list.
+type ofxWriter struct {
+	w      *bufio.Writer
+	header Header
+}
+
+func newOFXWriter(w io.Writer) *ofxWriter {
+	return &ofxWriter{w: bufio.NewWriter(w)}
+}
+
+func ofxTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.000") + "[0:UTC]"
+}
+
+// ofxText escapes the text and cuts it to size runes
+func ofxText(s string, size int) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(truncateRunes(s, size)))
+	return b.String()
+}
+
+func truncateRunes(s string, size int) string {
+	runes := []rune(s)
+	if len(runes) <= size {
+		return s
+	}
+	return string(runes[:size])
+}
+
+func (o *ofxWriter) Begin(header Header) error {
+	o.header = header
+
+	accountType := "CHECKING"
+	if header.AccountType == db.AccountTypeSavings {
+		accountType = "SAVINGS"
+	}
+
+	fmt.Fprint(o.w, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>`+"\n")
+	fmt.Fprint(o.w, `<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>`+"\n")
+	fmt.Fprint(o.w, "<OFX>\n")
+	fmt.Fprintf(o.w, "<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>"+
+		"<DTSERVER>%s</DTSERVER><LANGUAGE>ENG</LANGUAGE></SONRS></SIGNONMSGSRSV1>\n", ofxTime(header.GeneratedAt))
+	fmt.Fprint(o.w, "<BANKMSGSRSV1><STMTTRNRS><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n")
+	fmt.Fprintf(o.w, "<STMTRS><CURDEF>%s</CURDEF>\n", header.Currency)
+	fmt.Fprintf(o.w, "<BANKACCTFROM><BANKID>%s</BANKID><ACCTID>%d</ACCTID><ACCTTYPE>%s</ACCTTYPE></BANKACCTFROM>\n",
+		ofxBankID, header.AccountID, accountType)
+	_, err := fmt.Fprintf(o.w, "<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>\n", ofxTime(header.From), ofxTime(header.To))
+	return err
+}
+
+func (o *ofxWriter) Line(line Line) error {
+	transactionType := "CREDIT"
+	if line.Amount.IsNegative() {
+		transactionType = "DEBIT"
+	}
+
+	memo := line.Memo
+	if line.Reference != "" {
+		memo += " (" + line.Reference + ")"
+	}
+
+	fmt.Fprintf(o.w, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%s</FITID>",
+		transactionType, ofxTime(line.Time), line.Amount.Decimal(), strconv.FormatInt(line.EntryID, 10))
+	if line.Counterparty != "" {
+		fmt.Fprintf(o.w, "<NAME>%s</NAME>", ofxText(line.Counterparty, ofxNameSize))
+	}
+	if memo != "" {
+		fmt.Fprintf(o.w, "<MEMO>%s</MEMO>", ofxText(memo, ofxMemoSize))
+	}
+	_, err := fmt.Fprint(o.w, "</STMTTRN>\n")
+	return err
+}
+
+func (o *ofxWriter) End(footer Footer) error {
+	fmt.Fprint(o.w, "</BANKTRANLIST>\n")
+	fmt.Fprintf(o.w, "<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>\n",
+		footer.Closing.Decimal(), ofxTime(o.header.To))
+	fmt.Fprintf(o.w, "<BALLIST><BAL><NAME>Opening balance</NAME><DESC>Balance at the start of the statement</DESC>"+
+		"<BALTYPE>DOLLAR</BALTYPE><VALUE>%s</VALUE><DTASOF>%s</DTASOF></BAL></BALLIST>\n",
+		o.header.Opening.Decimal(), ofxTime(o.header.From))
+	fmt.Fprint(o.w, "</STMTRS></STMTTRNRS></BANKMSGSRSV1>\n")
+	fmt.Fprint(o.w, "</OFX>\n")
+	return o.w.Flush()
+}
@@ -0,0 +1,276 @@
+package statement
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// A4 in points, the PDF unit
+const (
+	pdfWidth   = 595
+	pdfHeight  = 842
+	pdfMargin  = 40
+	pdfBottom  = 60
+	pdfRowSize = 13
+	pdfTextPt  = 9
+
+	pdfDateX    = pdfMargin
+	pdfDetailsX = 105
+	pdfAmountX  = 470
+	pdfBalanceX = pdfWidth - pdfMargin
+)
+
+// objects written up front, the page tree is written last once every page is known
+const (
+	pdfCatalog = 1
+	pdfPages   = 2
+	pdfFont    = 3
+	pdfBold    = 4
+)
+
+// helveticaWidths are the widths of the printable ASCII characters in Helvetica, in 1/1000 of the font size
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space to /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556, // 0 to ?
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778, // @ to O
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556, // P to _
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556, // ` to o
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584, // p to ~
+}
+
+// textWidth approximates the width of s in points, Helvetica-Bold is a little wider
+// but only the digits of amounts are right aligned and those match
+func textWidth(s string, size float64) float64 {
+	width := 0
+	for _, r := range s {
+		if r >= ' ' && r <= '~' {
+			width += helveticaWidths[r-' ']
+		} else {
+			width += 556
+		}
+	}
+	return float64(width) * size / 1000
+}
+
+// fitText cuts s so it is at most width points wide
+func fitText(s string, size float64, width float64) string {
+	if textWidth(s, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && textWidth(string(runes)+"...", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// pdfString encodes s as a PDF literal string in WinAnsiEncoding, which matches Latin-1
+// from 160 up, anything it cannot show becomes a question mark
+func pdfString(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= ' ' && r <= '~':
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+type countingWriter struct {
+	w *bufio.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// pdfWriter writes a PDF with the standard Helvetica fonts, so nothing is embedded.
+// Each page is written out as soon as it is full, only the current page is kept in memory.
+type pdfWriter struct {
+	w       *countingWriter
+	offsets []int64
+	pages   []int
+	page    bytes.Buffer
+	y       float64
+	header  Header
+}
+
+func newPDFWriter(w io.Writer) *pdfWriter {
+	return &pdfWriter{w: &countingWriter{w: bufio.NewWriter(w)}}
+}
+
+// newObject reserves the next object number
+func (p *pdfWriter) newObject() int {
+	p.offsets = append(p.offsets, 0)
+	return len(p.offsets)
+}
+
+func (p *pdfWriter) writeObject(n int, body string) {
+	p.offsets[n-1] = p.w.n
+	fmt.Fprintf(p.w, "%d 0 obj\n%s\nendobj\n", n, body)
+}
+
+func (p *pdfWriter) text(font int, size float64, x float64, s string) {
+	fmt.Fprintf(&p.page, "BT /F%d %.1f Tf %.2f %.2f Td %s Tj ET\n", font, size, x, p.y, pdfString(s))
+}
+
+func (p *pdfWriter) textRight(font int, size float64, right float64, s string) {
+	p.text(font, size, right-textWidth(s, size), s)
+}
+
+func (p *pdfWriter) rule() {
+	fmt.Fprintf(&p.page, "0.5 w %d %.2f m %d %.2f l S\n", pdfMargin, p.y, pdfWidth-pdfMargin, p.y)
+}
+
+func pdfTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04 UTC")
+}
+
+func (p *pdfWriter) Begin(header Header) error {
+	p.header = header
+	for n := pdfCatalog; n <= pdfBold; n++ {
+		p.newObject()
+	}
+
+	fmt.Fprint(p.w, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	p.writeObject(pdfCatalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPages))
+	p.writeObject(pdfFont, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	p.writeObject(pdfBold, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	p.y = pdfHeight - pdfMargin - 2
+	p.text(pdfBold, 16, pdfMargin, "Account statement")
+	p.y -= 22
+	p.text(pdfFont, 10, pdfMargin, fmt.Sprintf("Account %d, %s, %s", header.AccountID, header.AccountType, header.Currency))
+	p.y -= 14
+	p.text(pdfFont, 10, pdfMargin, "Holder: "+header.Holder)
+	p.y -= 14
+	p.text(pdfFont, 10, pdfMargin, fmt.Sprintf("Period: %s to %s", pdfTime(header.From), pdfTime(header.To)))
+	p.y -= 14
+	p.text(pdfFont, 10, pdfMargin, "Generated: "+pdfTime(header.GeneratedAt))
+	p.y -= 22
+	p.text(pdfBold, 10, pdfMargin, "Opening balance")
+	p.textRight(pdfBold, 10, pdfBalanceX, header.Opening.String())
+	p.y -= 22
+	p.columns()
+	return p.w.w.Flush()
+}
+
+// columns writes the table heading of the entries
+func (p *pdfWriter) columns() {
+	p.text(pdfBold, pdfTextPt, pdfDateX, "Date")
+	p.text(pdfBold, pdfTextPt, pdfDetailsX, "Details")
+	p.textRight(pdfBold, pdfTextPt, pdfAmountX, "Amount")
+	p.textRight(pdfBold, pdfTextPt, pdfBalanceX, "Balance")
+	p.y -= 4
+	p.rule()
+	p.y -= pdfRowSize
+}
+
+// ensure starts a new page unless height points are left on this one
+func (p *pdfWriter) ensure(height float64) error {
+	if p.y-height >= pdfBottom {
+		return nil
+	}
+	if err := p.flushPage(); err != nil {
+		return err
+	}
+	p.y = pdfHeight - pdfMargin - 2
+	p.text(pdfFont, pdfTextPt, pdfMargin, fmt.Sprintf("Account %d statement, %s to %s",
+		p.header.AccountID, pdfTime(p.header.From), pdfTime(p.header.To)))
+	p.y -= 24
+	p.columns()
+	return nil
+}
+
+// flushPage writes the current page and its content stream, and sends them on to the client
+func (p *pdfWriter) flushPage() error {
+	y := p.y
+	p.y = pdfMargin - 10
+	p.textRight(pdfFont, 8, pdfBalanceX, fmt.Sprintf("Page %d", len(p.pages)+1))
+	p.y = y
+
+	content := p.newObject()
+	p.writeObject(content, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", p.page.Len(), p.page.String()))
+	page := p.newObject()
+	p.writeObject(page, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] "+
+		"/Resources << /Font << /F%d %d 0 R /F%d %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPages, pdfWidth, pdfHeight, pdfFont, pdfFont, pdfBold, pdfBold, content))
+	p.pages = append(p.pages, page)
+	p.page.Reset()
+	return p.w.w.Flush()
+}
+
+func (p *pdfWriter) Line(line Line) error {
+	if err := p.ensure(pdfRowSize); err != nil {
+		return err
+	}
+
+	details := line.Memo
+	if line.Counterparty != "" {
+		details = line.Counterparty + ": " + details
+	}
+	if line.Reference != "" {
+		details += " (" + line.Reference + ")"
+	}
+
+	p.text(pdfFont, pdfTextPt, pdfDateX, line.Time.UTC().Format(time.DateOnly))
+	p.text(pdfFont, pdfTextPt, pdfDetailsX, fitText(details, pdfTextPt, pdfAmountX-pdfDetailsX-80))
+	p.textRight(pdfFont, pdfTextPt, pdfAmountX, line.Amount.Decimal())
+	p.textRight(pdfFont, pdfTextPt, pdfBalanceX, line.Balance.Decimal())
+	p.y -= pdfRowSize
+	return nil
+}
+
+func (p *pdfWriter) End(footer Footer) error {
+	if err := p.ensure(5 * 16); err != nil {
+		return err
+	}
+
+	p.y += pdfRowSize - 4
+	p.rule()
+	p.y -= 16
+	p.text(pdfFont, 10, pdfMargin, fmt.Sprintf("%d entries", footer.Entries))
+	p.y -= 16
+	p.text(pdfFont, 10, pdfMargin, "Credits")
+	p.textRight(pdfFont, 10, pdfBalanceX, footer.Credits.String())
+	p.y -= 16
+	p.text(pdfFont, 10, pdfMargin, "Debits")
+	p.textRight(pdfFont, 10, pdfBalanceX, footer.Debits.String())
+	p.y -= 16
+	p.text(pdfBold, 10, pdfMargin, "Closing balance")
+	p.textRight(pdfBold, 10, pdfBalanceX, footer.Closing.String())
+	if err := p.flushPage(); err != nil {
+		return err
+	}
+
+	kids := make([]string, len(p.pages))
+	for i, page := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", page)
+	}
+	p.writeObject(pdfPages, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+
+	xref := p.w.n
+	fmt.Fprintf(p.w, "xref\n0 %d\n0000000000 65535 f \n", len(p.offsets)+1)
+	for _, offset := range p.offsets {
+		fmt.Fprintf(p.w, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(p.w, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(p.offsets)+1, pdfCatalog, xref)
+	return p.w.w.Flush()
+}
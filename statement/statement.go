@@ -0,0 +1,260 @@
+// Package statement renders account statements: the opening balance, every entry of the period
+// with its counterparty and memo, and the closing balance.
+//
+// Entries are read in pages and handed to the Writer one at a time, so a statement of any
+// length is rendered in constant memory. The counterparty of an entry is the other account of the
+// transfer the entry references.
+//
+// The Archiver renders the statement of every active account once a month and keeps it in the
+// blob storage, indexed by the statements table.
+package statement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/utils"
+)
+
+const (
+	FormatCSV = "csv"
+	FormatPDF = "pdf"
+	FormatOFX = "ofx"
+	// FormatCAMT053 is the ISO 20022 bank to customer statement
+	FormatCAMT053 = "camt053"
+)
+
+// pageSize is the number of entries read at a time
+const pageSize = 500
+
+// Header is known before the first entry is written
+type Header struct {
+	AccountID   int64
+	AccountType string
+	Currency    string
+	// Holder is the full name of the account owner
+	Holder      string
+	From        time.Time
+	To          time.Time
+	Opening     utils.Money
+	GeneratedAt time.Time
+	// Closing and the totals are summed up before the entries are read,
+	// for the formats that put them ahead of the entries
+	Closing       utils.Money
+	Credits       utils.Money
+	Debits        utils.Money
+	CreditEntries int
+	DebitEntries  int
+}
+
+// Line is one entry of the statement
+type Line struct {
+	EntryID    int64
+	TransferID int64
+	Time       time.Time
+	// Amount is negative for money leaving the account
+	Amount utils.Money
+	// Balance is the running balance after the entry
+	Balance      utils.Money
+	Counterparty string
+	Memo         string
+	Reference    string
+}
+
+// Footer closes the statement
+type Footer struct {
+	Closing utils.Money
+	Credits utils.Money
+	Debits  utils.Money
+	Entries int
+}
+
+// Writer renders a statement, Begin is called once, then Line for every entry in order, then End
+type Writer interface {
+	Begin(header Header) error
+	Line(line Line) error
+	End(footer Footer) error
+}
+
+// NewWriter returns the Writer of the format
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatPDF:
+		return newPDFWriter(w), nil
+	case FormatOFX:
+		return newOFXWriter(w), nil
+	case FormatCAMT053:
+		return newCAMT053Writer(w), nil
+	}
+	return nil, fmt.Errorf("unknown statement format %q", format)
+}
+
+// ContentType is the media type of the format
+func ContentType(format string) string {
+	switch format {
+	case FormatPDF:
+		return "application/pdf"
+	case FormatOFX:
+		return "application/x-ofx"
+	case FormatCAMT053:
+		return "application/xml"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Extension is the file name extension of the format
+func Extension(format string) string {
+	if format == FormatCAMT053 {
+		return "xml"
+	}
+	return format
+}
+
+// Request is the period of the statement, From inclusive and To exclusive
+type Request struct {
+	Account db.Account
+	Holder  string
+	From    time.Time
+	To      time.Time
+}
+
+// ErrInvalidPeriod is returned for a period that ends before it starts or before the account was opened
+var ErrInvalidPeriod = errors.New("invalid statement period")
+
+// Start returns the start of the statement, a period starting before the account was opened starts at
+// its opening. The period must end after it starts.
+func (req Request) Start() (time.Time, error) {
+	if !req.To.After(req.From) {
+		return time.Time{}, fmt.Errorf("%w: it must end after it starts", ErrInvalidPeriod)
+	}
+	if !req.To.After(req.Account.CreatedAt) {
+		return time.Time{}, fmt.Errorf("%w: account %d was opened on %s", ErrInvalidPeriod,
+			req.Account.ID, req.Account.CreatedAt.UTC().Format(time.DateOnly))
+	}
+	if req.From.Before(req.Account.CreatedAt) {
+		return req.Account.CreatedAt, nil
+	}
+	return req.From, nil
+}
+
+// Generate writes the statement of the period. Everything is read in one repeatable read
+// transaction, so the balances, the totals and the entries agree even while transfers keep coming in.
+// A period starting before the account was opened starts at its opening, one ending before fails
+// with ErrInvalidPeriod.
+func Generate(ctx context.Context, store db.Store, req Request, w Writer) error {
+	from, err := req.Start()
+	if err != nil {
+		return err
+	}
+
+	return store.ReadTx(ctx, func(q db.Querier) error {
+		opening, err := db.BalanceAt(ctx, q, req.Account.ID, from)
+		if err != nil {
+			return err
+		}
+
+		balance, err := utils.NewMoney(opening.Balance, req.Account.Currency)
+		if err != nil {
+			return err
+		}
+		credits, _ := utils.NewMoney(0, req.Account.Currency)
+		debits := credits
+
+		totals, err := q.SumStatementEntries(ctx, db.SumStatementEntriesParams{
+			AccountID: req.Account.ID,
+			StartTime: from,
+			EndTime:   req.To,
+		})
+		if err != nil {
+			return err
+		}
+
+		header := Header{
+			AccountID:     req.Account.ID,
+			AccountType:   req.Account.Type,
+			Currency:      req.Account.Currency,
+			Holder:        req.Holder,
+			From:          from,
+			To:            req.To,
+			Opening:       balance,
+			GeneratedAt:   time.Now().UTC(),
+			CreditEntries: int(totals.CreditEntries),
+			DebitEntries:  int(totals.DebitEntries),
+		}
+		header.Credits, _ = utils.NewMoney(totals.Credits, req.Account.Currency)
+		header.Debits, _ = utils.NewMoney(totals.Debits, req.Account.Currency)
+		if header.Closing, err = balance.Add(header.Credits); err != nil {
+			return err
+		}
+		if header.Closing, err = header.Closing.Add(header.Debits); err != nil {
+			return err
+		}
+
+		if err := w.Begin(header); err != nil {
+			return err
+		}
+
+		count := 0
+		var afterID int64
+		for {
+			entries, err := q.ListStatementEntries(ctx, db.ListStatementEntriesParams{
+				AccountID: req.Account.ID,
+				StartTime: from,
+				EndTime:   req.To,
+				AfterID:   afterID,
+				PageLimit: pageSize,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, entry := range entries {
+				amount, _ := utils.NewMoney(entry.Amount, req.Account.Currency)
+				if balance, err = balance.Add(amount); err != nil {
+					return err
+				}
+				if amount.IsNegative() {
+					debits, err = debits.Add(amount)
+				} else {
+					credits, err = credits.Add(amount)
+				}
+				if err != nil {
+					return err
+				}
+
+				err = w.Line(Line{
+					EntryID:      entry.ID,
+					TransferID:   entry.TransferID,
+					Time:         entry.CreatedAt,
+					Amount:       amount,
+					Balance:      balance,
+					Counterparty: entry.Counterparty,
+					Memo:         entry.Description,
+					Reference:    entry.Reference,
+				})
+				if err != nil {
+					return err
+				}
+				count++
+				afterID = entry.ID
+			}
+
+			if len(entries) < pageSize {
+				break
+			}
+		}
+
+		return w.End(Footer{
+			Closing: balance,
+			Credits: credits,
+			Debits:  debits,
+			Entries: count,
+		})
+	})
+}
@@ -0,0 +1,345 @@
+package statement
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/lordofthemind/backendMasterGo/db/mock"
+	db "github.com/lordofthemind/backendMasterGo/db/sqlc"
+	"github.com/lordofthemind/backendMasterGo/iso20022"
+	"github.com/lordofthemind/backendMasterGo/utils"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testFrom = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	testTo   = time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func testAccount() db.Account {
+	return db.Account{
+		ID:        42,
+		Owner:     "alice",
+		Balance:   100000,
+		Currency:  utils.EUR,
+		Type:      db.AccountTypeChecking,
+		CreatedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// statementEntries returns n entries alternating between credits of 10.00 and debits of 2.50
+func statementEntries(n int) []db.ListStatementEntriesRow {
+	entries := make([]db.ListStatementEntriesRow, n)
+	for i := range entries {
+		entries[i] = db.ListStatementEntriesRow{
+			ID:           int64(i + 1),
+			Amount:       1000,
+			CreatedAt:    testFrom.Add(time.Duration(i) * time.Minute),
+			Description:  "salary",
+			TransferID:   int64(100 + i),
+			Counterparty: "Bob Builder",
+		}
+		if i%2 == 1 {
+			entries[i].Amount = -250
+			entries[i].Description = "=coffee"
+			entries[i].Reference = "café (ref)"
+		}
+	}
+	return entries
+}
+
+// sumEntries adds up the entries the way SumStatementEntries does
+func sumEntries(entries []db.ListStatementEntriesRow) db.SumStatementEntriesRow {
+	var totals db.SumStatementEntriesRow
+	for _, entry := range entries {
+		if entry.Amount < 0 {
+			totals.Debits += entry.Amount
+			totals.DebitEntries++
+		} else {
+			totals.Credits += entry.Amount
+			totals.CreditEntries++
+		}
+	}
+	return totals
+}
+
+// stubStatement makes the store return an opening balance of 50.00 and the entries in pages
+func stubStatement(store *mockdb.MockStore, account db.Account, entries []db.ListStatementEntriesRow) {
+	store.EXPECT().ReadTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, fn func(db.Querier) error) error {
+			return fn(store)
+		})
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	store.EXPECT().GetLatestBalanceSnapshot(gomock.Any(), gomock.Any()).Times(1).
+		Return(db.BalanceSnapshot{AccountID: account.ID, Day: testFrom.AddDate(0, 0, -1), Balance: 5000}, nil)
+	store.EXPECT().SumAccountEntriesBetween(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+	store.EXPECT().SumStatementEntries(gomock.Any(), gomock.Eq(db.SumStatementEntriesParams{
+		AccountID: account.ID,
+		StartTime: testFrom,
+		EndTime:   testTo,
+	})).Times(1).Return(sumEntries(entries), nil)
+	store.EXPECT().ListStatementEntries(gomock.Any(), gomock.Any()).MinTimes(1).
+		DoAndReturn(func(_ context.Context, arg db.ListStatementEntriesParams) ([]db.ListStatementEntriesRow, error) {
+			page := []db.ListStatementEntriesRow{}
+			for _, entry := range entries {
+				if entry.ID > arg.AfterID && len(page) < int(arg.PageLimit) {
+					page = append(page, entry)
+				}
+			}
+			return page, nil
+		})
+}
+
+func generate(t *testing.T, format string, entries []db.ListStatementEntriesRow) []byte {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	account := testAccount()
+	store := mockdb.NewMockStore(ctrl)
+	stubStatement(store, account, entries)
+
+	var out bytes.Buffer
+	w, err := NewWriter(format, &out)
+	require.NoError(t, err)
+
+	err = Generate(context.Background(), store, Request{Account: account, Holder: "Alice", From: testFrom, To: testTo}, w)
+	require.NoError(t, err)
+	return out.Bytes()
+}
+
+func TestGenerateCSV(t *testing.T) {
+	n := pageSize + 3
+	out := generate(t, FormatCSV, statementEntries(n))
+
+	rows, err := csv.NewReader(bytes.NewReader(out)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, n+3)
+	require.Equal(t, csvColumns, rows[0])
+	require.Equal(t, []string{csvOpening, "2026-01-01T00:00:00Z", "", "", "", "", "", "", "50.00", utils.EUR}, rows[1])
+	require.Equal(t, []string{csvEntry, "2026-01-01T00:00:00Z", "1", "100", "Bob Builder", "salary", "", "10.00", "60.00", utils.EUR}, rows[2])
+	require.Equal(t, []string{csvEntry, "2026-01-01T00:01:00Z", "2", "101", "Bob Builder", "'=coffee", "café (ref)", "-2.50", "57.50", utils.EUR}, rows[3])
+
+	// 252 credits and 251 debits
+	require.Equal(t, []string{csvClosing, "2026-02-01T00:00:00Z", "", "", "", "", "", "", "1942.50", utils.EUR}, rows[n+2])
+}
+
+func TestGenerateOFX(t *testing.T) {
+	out := generate(t, FormatOFX, statementEntries(2))
+
+	var doc struct {
+		Statement struct {
+			Currency     string `xml:"CURDEF"`
+			AccountID    string `xml:"BANKACCTFROM>ACCTID"`
+			Transactions []struct {
+				Type   string `xml:"TRNTYPE"`
+				Amount string `xml:"TRNAMT"`
+				ID     string `xml:"FITID"`
+				Name   string `xml:"NAME"`
+				Memo   string `xml:"MEMO"`
+			} `xml:"BANKTRANLIST>STMTTRN"`
+			Closing string `xml:"LEDGERBAL>BALAMT"`
+			Opening string `xml:"BALLIST>BAL>VALUE"`
+		} `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS"`
+	}
+	require.NoError(t, xml.Unmarshal(out, &doc))
+	require.True(t, strings.HasPrefix(string(out), `<?xml version="1.0"`))
+
+	statement := doc.Statement
+	require.Equal(t, utils.EUR, statement.Currency)
+	require.Equal(t, "42", statement.AccountID)
+	require.Equal(t, "50.00", statement.Opening)
+	require.Equal(t, "57.50", statement.Closing)
+	require.Len(t, statement.Transactions, 2)
+	require.Equal(t, "CREDIT", statement.Transactions[0].Type)
+	require.Equal(t, "10.00", statement.Transactions[0].Amount)
+	require.Equal(t, "DEBIT", statement.Transactions[1].Type)
+	require.Equal(t, "-2.50", statement.Transactions[1].Amount)
+	require.Equal(t, "2", statement.Transactions[1].ID)
+	require.Equal(t, "Bob Builder", statement.Transactions[1].Name)
+	require.Equal(t, "=coffee (café (ref))", statement.Transactions[1].Memo)
+}
+
+func TestGeneratePDF(t *testing.T) {
+	out := generate(t, FormatPDF, statementEntries(150))
+
+	require.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+
+	// every object in the cross-reference table starts at its offset
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, m)
+	xref, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n0 ")))
+
+	lines := strings.Split(string(out[xref:]), "\n")
+	size, err := strconv.Atoi(strings.Fields(lines[1])[1])
+	require.NoError(t, err)
+	for n := 1; n < size; n++ {
+		offset, err := strconv.Atoi(lines[2+n][:10])
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj\n", n))), "object %d", n)
+	}
+
+	// 150 rows do not fit on one page
+	pages := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(out)
+	require.NotNil(t, pages)
+	count, err := strconv.Atoi(string(pages[1]))
+	require.NoError(t, err)
+	require.Greater(t, count, 1)
+
+	require.Contains(t, string(out), "(Opening balance)")
+	require.Contains(t, string(out), "(Bob Builder: =coffee \\(caf\\351 \\(ref\\)\\))")
+	require.Contains(t, string(out), "(Closing balance)")
+}
+
+func TestGenerateCAMT053(t *testing.T) {
+	entries := statementEntries(3)
+	entries[2].Reference = strings.Repeat("r", 40)
+	out := generate(t, FormatCAMT053, entries)
+
+	namespace, err := iso20022.Camt053.Validate(bytes.NewReader(out))
+	require.NoError(t, err)
+	require.Equal(t, iso20022.Camt053V08, namespace)
+
+	xsd, err := os.Open(filepath.Join("..", "iso20022", "testdata", "camt.053.001.08.xsd"))
+	require.NoError(t, err)
+	defer xsd.Close()
+	schema, err := iso20022.ParseXSD(xsd)
+	require.NoError(t, err)
+	namespace, err = schema.Validate(bytes.NewReader(out))
+	require.NoError(t, err)
+	require.Equal(t, iso20022.Camt053V08, namespace)
+
+	type amount struct {
+		Currency string `xml:"Ccy,attr"`
+		Value    string `xml:",chardata"`
+	}
+	var doc struct {
+		Statement struct {
+			ID       string `xml:"Id"`
+			Account  string `xml:"Acct>Id>Othr>Id"`
+			Owner    string `xml:"Acct>Ownr>Nm"`
+			Balances []struct {
+				Code      string `xml:"Tp>CdOrPrtry>Cd"`
+				Amount    amount `xml:"Amt"`
+				Indicator string `xml:"CdtDbtInd"`
+				Date      string `xml:"Dt>Dt"`
+			} `xml:"Bal"`
+			Entries    int    `xml:"TxsSummry>TtlNtries>NbOfNtries"`
+			Net        string `xml:"TxsSummry>TtlNtries>TtlNetNtry>Amt"`
+			Credits    string `xml:"TxsSummry>TtlCdtNtries>Sum"`
+			Debits     string `xml:"TxsSummry>TtlDbtNtries>Sum"`
+			DebitCount int    `xml:"TxsSummry>TtlDbtNtries>NbOfNtries"`
+			Ntry       []struct {
+				Reference  string `xml:"NtryRef"`
+				Amount     amount `xml:"Amt"`
+				Indicator  string `xml:"CdtDbtInd"`
+				Family     string `xml:"BkTxCd>Domn>Fmly>Cd"`
+				Booked     string `xml:"BookgDt>DtTm"`
+				TxID       string `xml:"NtryDtls>TxDtls>Refs>TxId"`
+				EndToEndID string `xml:"NtryDtls>TxDtls>Refs>EndToEndId"`
+				Debtor     string `xml:"NtryDtls>TxDtls>RltdPties>Dbtr>Pty>Nm"`
+				Creditor   string `xml:"NtryDtls>TxDtls>RltdPties>Cdtr>Pty>Nm"`
+				Memo       string `xml:"NtryDtls>TxDtls>RmtInf>Ustrd"`
+			} `xml:"Ntry"`
+		} `xml:"BkToCstmrStmt>Stmt"`
+	}
+	require.NoError(t, xml.Unmarshal(out, &doc))
+
+	statement := doc.Statement
+	require.Equal(t, "42-20260101-20260131", statement.ID)
+	require.Equal(t, "42", statement.Account)
+	require.Equal(t, "Alice", statement.Owner)
+
+	require.Len(t, statement.Balances, 2)
+	require.Equal(t, "OPBD", statement.Balances[0].Code)
+	require.Equal(t, amount{utils.EUR, "50.00"}, statement.Balances[0].Amount)
+	require.Equal(t, "2026-01-01", statement.Balances[0].Date)
+	require.Equal(t, "CLBD", statement.Balances[1].Code)
+	require.Equal(t, amount{utils.EUR, "67.50"}, statement.Balances[1].Amount)
+	require.Equal(t, iso20022.Credit, statement.Balances[1].Indicator)
+	require.Equal(t, "2026-01-31", statement.Balances[1].Date)
+
+	require.Equal(t, 3, statement.Entries)
+	require.Equal(t, "17.50", statement.Net)
+	require.Equal(t, "20.00", statement.Credits)
+	require.Equal(t, "2.50", statement.Debits)
+	require.Equal(t, 1, statement.DebitCount)
+
+	require.Len(t, statement.Ntry, 3)
+	credit, debit := statement.Ntry[0], statement.Ntry[1]
+	require.Equal(t, "1", credit.Reference)
+	require.Equal(t, amount{utils.EUR, "10.00"}, credit.Amount)
+	require.Equal(t, iso20022.Credit, credit.Indicator)
+	require.Equal(t, "RCDT", credit.Family)
+	require.Equal(t, "2026-01-01T00:00:00Z", credit.Booked)
+	require.Equal(t, "100", credit.TxID)
+	require.Equal(t, "Bob Builder", credit.Debtor)
+	require.Empty(t, credit.Creditor)
+	require.Equal(t, "salary", credit.Memo)
+
+	require.Equal(t, amount{utils.EUR, "2.50"}, debit.Amount)
+	require.Equal(t, iso20022.Debit, debit.Indicator)
+	require.Equal(t, "ICDT", debit.Family)
+	require.Equal(t, "café (ref)", debit.EndToEndID)
+	require.Equal(t, "Bob Builder", debit.Creditor)
+	require.Empty(t, debit.Debtor)
+
+	// references longer than the schema allows are cut
+	require.Equal(t, strings.Repeat("r", 35), statement.Ntry[2].EndToEndID)
+}
+
+func TestGenerateInvalidPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	account := testAccount()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ReadTx(gomock.Any(), gomock.Any()).Times(0)
+
+	w, err := NewWriter(FormatCSV, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	for _, req := range []Request{
+		{Account: account, From: testFrom, To: testFrom},
+		{Account: account, From: testTo, To: testFrom},
+		{Account: account, From: account.CreatedAt.AddDate(0, -1, 0), To: account.CreatedAt},
+	} {
+		err := Generate(context.Background(), store, req, w)
+		require.ErrorIs(t, err, ErrInvalidPeriod)
+	}
+
+	// a period covering the opening starts at it
+	start, err := Request{Account: account, From: account.CreatedAt.AddDate(0, -1, 0), To: testTo}.Start()
+	require.NoError(t, err)
+	require.Equal(t, account.CreatedAt, start)
+}
+
+func TestPDFString(t *testing.T) {
+	require.Equal(t, `(a\(b\)c\\)`, pdfString(`a(b)c\`))
+	require.Equal(t, `(\351t\351 ?)`, pdfString("été €"))
+}
+
+func TestFitText(t *testing.T) {
+	require.Equal(t, "short", fitText("short", 9, 100))
+	fitted := fitText(strings.Repeat("w", 100), 9, 100)
+	require.True(t, strings.HasSuffix(fitted, "..."))
+	require.LessOrEqual(t, textWidth(fitted, 9), 100.0)
+}
+
+func TestNewWriterUnknownFormat(t *testing.T) {
+	_, err := NewWriter("xls", &bytes.Buffer{})
+	require.Error(t, err)
+}
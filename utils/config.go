@@ -7,11 +7,26 @@ import (
 )
 
 type Config struct {
-	DBDriver            string        `mapstructure:"DB_DRIVER"`
-	DBSource            string        `mapstructure:"DB_SOURCE"`
-	ServerAddress       string        `mapstructure:"SERVER_ADDRESS"`
-	TokenSymmetricKey   string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	DBDriver             string        `mapstructure:"DB_DRIVER"`
+	DBSource             string        `mapstructure:"DB_SOURCE"`
+	ServerAddress        string        `mapstructure:"SERVER_ADDRESS"`
+	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	PageSizeMin          int32         `mapstructure:"PAGE_SIZE_MIN"`
+	PageSizeMax          int32         `mapstructure:"PAGE_SIZE_MAX"`
+	PageSizeDefault      int32         `mapstructure:"PAGE_SIZE_DEFAULT"`
+	EnabledCurrencies    []string      `mapstructure:"ENABLED_CURRENCIES"`
+	ApprovalThresholds   []string      `mapstructure:"APPROVAL_THRESHOLDS"`
+	ApprovalExpiry       time.Duration `mapstructure:"APPROVAL_EXPIRY"`
+	PayeeCoolingOff      time.Duration `mapstructure:"PAYEE_COOLING_OFF"`
+	PayeeCoolingLimits   []string      `mapstructure:"PAYEE_COOLING_OFF_LIMITS"`
+	PaymentRequestExpiry time.Duration `mapstructure:"PAYMENT_REQUEST_EXPIRY"`
+	PaymentLinkKey       string        `mapstructure:"PAYMENT_LINK_KEY"`
+	PaymentLinkBaseURL   string        `mapstructure:"PAYMENT_LINK_BASE_URL"`
+	PaymentQRCountry     string        `mapstructure:"PAYMENT_QR_COUNTRY"`
+	PaymentQRCity        string        `mapstructure:"PAYMENT_QR_CITY"`
+	LedgerSigningKey     string        `mapstructure:"LEDGER_SIGNING_KEY"`
+	BlobStorageDir       string        `mapstructure:"BLOB_STORAGE_DIR"`
 }
 
 func LoadConfig(path string) (config *Config, err error) {
@@ -1,15 +1,119 @@
 package utils
 
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
 const (
 	USD = "USD"
 	EUR = "EUR"
 	CAD = "CAD"
 )
 
-func IsSupportedCurrency(currency string) bool {
-	switch currency {
-	case USD, EUR, CAD:
-		return true
+// DefaultCurrencies are enabled when the deployment does not configure ENABLED_CURRENCIES
+var DefaultCurrencies = []string{USD, EUR, CAD}
+
+// iso4217.csv holds the active ISO 4217 currencies, funds and precious metals are left out
+//
+//go:embed iso4217.csv
+var iso4217 string
+
+// Currency is an ISO 4217 currency
+type Currency struct {
+	Code   string `json:"code"`
+	Number string `json:"number"`
+	Name   string `json:"name"`
+	// MinorUnits is the number of decimals of the currency, 2 for EUR, 0 for JPY and 3 for KWD
+	MinorUnits int `json:"minor_units"`
+}
+
+// CurrencyRegistry knows every ISO 4217 currency and which of them are enabled in this deployment
+type CurrencyRegistry struct {
+	currencies map[string]Currency
+	enabled    []Currency
+}
+
+var isoCurrencies = mustLoadISO4217()
+
+func mustLoadISO4217() map[string]Currency {
+	records, err := csv.NewReader(strings.NewReader(iso4217)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("cannot read iso4217.csv: %v", err))
+	}
+
+	currencies := make(map[string]Currency, len(records))
+	for _, record := range records[1:] {
+		minorUnits, err := strconv.Atoi(record[2])
+		if err != nil {
+			panic(fmt.Sprintf("invalid minor units for %s: %v", record[0], err))
+		}
+		currencies[record[0]] = Currency{
+			Code:       record[0],
+			Number:     record[1],
+			Name:       record[3],
+			MinorUnits: minorUnits,
+		}
+	}
+	return currencies
+}
+
+// NewCurrencyRegistry returns a registry with the given ISO 4217 codes enabled,
+// or DefaultCurrencies when codes is empty
+func NewCurrencyRegistry(codes []string) (*CurrencyRegistry, error) {
+	if len(codes) == 0 {
+		codes = DefaultCurrencies
+	}
+
+	registry := &CurrencyRegistry{currencies: isoCurrencies}
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		currency, ok := isoCurrencies[code]
+		if !ok {
+			return nil, fmt.Errorf("unknown currency %q", code)
+		}
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		registry.enabled = append(registry.enabled, currency)
+	}
+
+	sort.Slice(registry.enabled, func(i, j int) bool {
+		return registry.enabled[i].Code < registry.enabled[j].Code
+	})
+	return registry, nil
+}
+
+var defaultCurrencyRegistry, _ = NewCurrencyRegistry(DefaultCurrencies)
+
+// DefaultCurrencyRegistry returns the registry with DefaultCurrencies enabled
+func DefaultCurrencyRegistry() *CurrencyRegistry {
+	return defaultCurrencyRegistry
+}
+
+// Lookup finds any ISO 4217 currency, enabled or not
+func (registry *CurrencyRegistry) Lookup(code string) (Currency, bool) {
+	currency, ok := registry.currencies[code]
+	return currency, ok
+}
+
+// IsEnabled reports whether accounts and transfers may use the currency
+func (registry *CurrencyRegistry) IsEnabled(code string) bool {
+	for _, currency := range registry.enabled {
+		if currency.Code == code {
+			return true
+		}
 	}
 	return false
 }
+
+// Enabled returns the enabled currencies sorted by code
+func (registry *CurrencyRegistry) Enabled() []Currency {
+	return append([]Currency(nil), registry.enabled...)
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrencyRegistry(t *testing.T) {
+	registry, err := NewCurrencyRegistry([]string{"jpy", " KWD", EUR, "JPY"})
+	require.NoError(t, err)
+
+	enabled := registry.Enabled()
+	require.Len(t, enabled, 3)
+	require.Equal(t, EUR, enabled[0].Code)
+	require.Equal(t, "JPY", enabled[1].Code)
+	require.Equal(t, 0, enabled[1].MinorUnits)
+	require.Equal(t, "KWD", enabled[2].Code)
+	require.Equal(t, 3, enabled[2].MinorUnits)
+
+	require.True(t, registry.IsEnabled("KWD"))
+	require.False(t, registry.IsEnabled(USD))
+
+	usd, ok := registry.Lookup(USD)
+	require.True(t, ok)
+	require.Equal(t, "840", usd.Number)
+	require.Equal(t, 2, usd.MinorUnits)
+
+	_, ok = registry.Lookup("XYZ")
+	require.False(t, ok)
+}
+
+func TestCurrencyRegistryDefaults(t *testing.T) {
+	registry, err := NewCurrencyRegistry(nil)
+	require.NoError(t, err)
+	require.Len(t, registry.Enabled(), len(DefaultCurrencies))
+	for _, code := range DefaultCurrencies {
+		require.True(t, registry.IsEnabled(code))
+	}
+
+	rg := NewRandomGenerator()
+	require.True(t, registry.IsEnabled(rg.RandomCurrency()))
+}
+
+func TestCurrencyRegistryUnknownCurrency(t *testing.T) {
+	registry, err := NewCurrencyRegistry([]string{USD, "XYZ"})
+	require.Error(t, err)
+	require.Nil(t, registry)
+}
+
+func TestCurrencyRegistryExcludesFunds(t *testing.T) {
+	registry := DefaultCurrencyRegistry()
+	for _, code := range []string{"BOV", "CHE", "CHW", "CLF", "COU", "MXV", "USN", "UYI", "UYW"} {
+		_, ok := registry.Lookup(code)
+		require.False(t, ok, code)
+	}
+
+	_, err := NewCurrencyRegistry([]string{"CLF"})
+	require.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	emvcoMerchantCategory = "0000"
+	emvcoMaxMerchantName  = 25
+	emvcoMaxMerchantCity  = 15
+)
+
+// MerchantQR is the merchant-presented payload of the EMVCo QR code specification for payment systems
+type MerchantQR struct {
+	// Reusable is false for a code meant to be paid once
+	Reusable bool
+	// GUI names the payment scheme in the merchant account template, URL is where the payment is made
+	GUI      string
+	URL      string
+	Currency string
+	// Amount is nil when the payer chooses it
+	Amount       *Money
+	CountryCode  string
+	MerchantName string
+	MerchantCity string
+}
+
+// Payload encodes the fields as EMVCo tag-length-value objects ending with the CRC16 checksum
+func (qr MerchantQR) Payload() (string, error) {
+	currency, ok := isoCurrencies[qr.Currency]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownCurrency, qr.Currency)
+	}
+
+	initiation := "12"
+	if qr.Reusable {
+		initiation = "11"
+	}
+
+	account, err := emvcoFields("00", qr.GUI, "01", qr.URL)
+	if err != nil {
+		return "", err
+	}
+
+	fields := []string{
+		"00", "01",
+		"01", initiation,
+		"26", account,
+		"52", emvcoMerchantCategory,
+		"53", currency.Number,
+	}
+	if qr.Amount != nil {
+		fields = append(fields, "54", qr.Amount.Decimal())
+	}
+	fields = append(fields,
+		"58", strings.ToUpper(qr.CountryCode),
+		"59", truncate(qr.MerchantName, emvcoMaxMerchantName),
+		"60", truncate(qr.MerchantCity, emvcoMaxMerchantCity),
+	)
+
+	payload, err := emvcoFields(fields...)
+	if err != nil {
+		return "", err
+	}
+	// the checksum covers its own tag and length
+	payload += "6304"
+	return payload + fmt.Sprintf("%04X", crc16CCITT([]byte(payload))), nil
+}
+
+// emvcoFields encodes id, value pairs, values hold at most 99 characters
+func emvcoFields(pairs ...string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(pairs); i += 2 {
+		id, value := pairs[i], pairs[i+1]
+		if len(value) == 0 || len(value) > 99 {
+			return "", fmt.Errorf("emvco field %s must hold 1 to 99 characters, got %d", id, len(value))
+		}
+		fmt.Fprintf(&b, "%s%02d%s", id, len(value), value)
+	}
+	return b.String(), nil
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
+
+// crc16CCITT is the CRC-16/CCITT-FALSE checksum EMVCo requires: polynomial 0x1021, initial value 0xFFFF
+func crc16CCITT(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
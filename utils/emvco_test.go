@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRC16CCITT(t *testing.T) {
+	require.Equal(t, uint16(0x29B1), crc16CCITT([]byte("123456789")))
+}
+
+func TestMerchantQRPayload(t *testing.T) {
+	amount, err := ParseMoney("12.50", EUR)
+	require.NoError(t, err)
+
+	qr := MerchantQR{
+		GUI:          "com.example",
+		URL:          "https://example.com/pay/abc",
+		Currency:     EUR,
+		Amount:       &amount,
+		CountryCode:  "de",
+		MerchantName: "Corner Bakery and Coffee Roasters",
+		MerchantCity: "Berlin",
+	}
+
+	payload, err := qr.Payload()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(payload, "000201"+"010212"+"2646"+"0011com.example"+"0127https://example.com/pay/abc"))
+	require.Contains(t, payload, "5303978")
+	require.Contains(t, payload, "540512.50")
+	require.Contains(t, payload, "5802DE")
+	require.Contains(t, payload, "5925Corner Bakery and Coffee ")
+	require.Contains(t, payload, "6006Berlin")
+
+	// the checksum covers everything up to and including its own tag and length
+	body, checksum := payload[:len(payload)-4], payload[len(payload)-4:]
+	require.True(t, strings.HasSuffix(body, "6304"))
+	require.Equal(t, fmt.Sprintf("%04X", crc16CCITT([]byte(body))), checksum)
+
+	qr.Reusable = true
+	qr.Amount = nil
+	payload, err = qr.Payload()
+	require.NoError(t, err)
+	require.Contains(t, payload, "010211")
+	require.NotContains(t, payload, "5405")
+
+	qr.URL = "https://example.com/" + strings.Repeat("x", 100)
+	_, err = qr.Payload()
+	require.Error(t, err)
+
+	qr.Currency = "XYZ"
+	_, err = qr.Payload()
+	require.ErrorIs(t, err, ErrUnknownCurrency)
+}
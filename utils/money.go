@@ -0,0 +1,222 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrMoneyOverflow    = errors.New("money amount overflows")
+	ErrInvalidAmount    = errors.New("invalid money amount")
+	ErrUnknownCurrency  = errors.New("unknown currency")
+)
+
+// Money is an amount in the minor units of an ISO 4217 currency, 1234 EUR is 12.34 EUR
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+// NewMoney returns amount minor units of the currency
+func NewMoney(amount int64, currency string) (Money, error) {
+	c, ok := isoCurrencies[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("%w %q", ErrUnknownCurrency, currency)
+	}
+	return Money{amount: amount, currency: c}, nil
+}
+
+// ParseMoney parses a decimal string such as "12.34" or "-5" in the given currency,
+// it fails when the string has more decimals than the currency's minor units
+func ParseMoney(value string, currency string) (Money, error) {
+	money, err := NewMoney(0, currency)
+	if err != nil {
+		return money, err
+	}
+
+	s := value
+	negative := strings.HasPrefix(s, "-")
+	if negative {
+		s = s[1:]
+	}
+
+	whole, fraction, hasPoint := strings.Cut(s, ".")
+	if whole == "" || (hasPoint && fraction == "") || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("%w %q", ErrInvalidAmount, value)
+	}
+	if len(fraction) > money.currency.MinorUnits {
+		return Money{}, fmt.Errorf("%w %q: %s has %d decimals", ErrInvalidAmount, value, currency, money.currency.MinorUnits)
+	}
+	fraction += strings.Repeat("0", money.currency.MinorUnits-len(fraction))
+
+	var amount int64
+	for _, digit := range whole + fraction {
+		if amount > (math.MaxInt64-int64(digit-'0'))/10 {
+			return Money{}, fmt.Errorf("%w: %q", ErrMoneyOverflow, value)
+		}
+		amount = amount*10 + int64(digit-'0')
+	}
+	if negative {
+		amount = -amount
+	}
+
+	money.amount = amount
+	return money, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Amount returns the amount in minor units
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency returns the ISO 4217 code of the currency
+func (m Money) Currency() string {
+	return m.currency.Code
+}
+
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// Add returns m + other, both must use the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	if (other.amount > 0 && m.amount > math.MaxInt64-other.amount) ||
+		(other.amount < 0 && m.amount < math.MinInt64-other.amount) {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrMoneyOverflow, m, other)
+	}
+	return Money{amount: m.amount + other.amount, currency: m.currency}, nil
+}
+
+// Sub returns m - other, both must use the same currency
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	if (other.amount < 0 && m.amount > math.MaxInt64+other.amount) ||
+		(other.amount > 0 && m.amount < math.MinInt64+other.amount) {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrMoneyOverflow, m, other)
+	}
+	return Money{amount: m.amount - other.amount, currency: m.currency}, nil
+}
+
+// Neg returns -m
+func (m Money) Neg() (Money, error) {
+	if m.amount == math.MinInt64 {
+		return Money{}, fmt.Errorf("%w: -(%s)", ErrMoneyOverflow, m)
+	}
+	return Money{amount: -m.amount, currency: m.currency}, nil
+}
+
+// Cmp returns -1, 0 or 1 when m is less than, equal to or greater than other
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (m Money) sameCurrency(other Money) error {
+	if m.currency.Code != other.currency.Code {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency.Code, other.currency.Code)
+	}
+	return nil
+}
+
+// Decimal formats the amount with the currency's minor units, 1234 EUR is "12.34" and 1234 JPY is "1234"
+func (m Money) Decimal() string {
+	// formatting the magnitude as uint64 keeps math.MinInt64 representable
+	magnitude := uint64(m.amount)
+	sign := ""
+	if m.amount < 0 {
+		magnitude = -magnitude
+		sign = "-"
+	}
+
+	digits := fmt.Sprintf("%d", magnitude)
+	minorUnits := m.currency.MinorUnits
+	if minorUnits == 0 {
+		return sign + digits
+	}
+	if len(digits) <= minorUnits {
+		digits = strings.Repeat("0", minorUnits-len(digits)+1) + digits
+	}
+	point := len(digits) - minorUnits
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.currency.Code
+}
+
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON encodes the amount as a decimal string so clients never have to know the minor units
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.Decimal(), Currency: m.currency.Code})
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var value moneyJSON
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	money, err := ParseMoney(value.Amount, value.Currency)
+	if err != nil {
+		return err
+	}
+	*m = money
+	return nil
+}
+
+// ParseCurrencyAmounts reads CURRENCY:AMOUNT pairs such as USD:10000.00
+func ParseCurrencyAmounts(values []string) (map[string]Money, error) {
+	amounts := make(map[string]Money, len(values))
+	for _, value := range values {
+		currency, decimal, ok := strings.Cut(strings.TrimSpace(value), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid amount %q, expected CURRENCY:AMOUNT", value)
+		}
+		amount, err := ParseMoney(decimal, strings.ToUpper(currency))
+		if err != nil {
+			return nil, err
+		}
+		if !amount.IsPositive() {
+			return nil, fmt.Errorf("amount %s must be positive", amount)
+		}
+		amounts[amount.Currency()] = amount
+	}
+	return amounts, nil
+}
@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	testCases := []struct {
+		value    string
+		currency string
+		amount   int64
+		decimal  string
+	}{
+		{"12.34", EUR, 1234, "12.34"},
+		{"12.3", EUR, 1230, "12.30"},
+		{"12", EUR, 1200, "12.00"},
+		{"0.05", USD, 5, "0.05"},
+		{"-1.50", CAD, -150, "-1.50"},
+		{"1234", "JPY", 1234, "1234"},
+		{"1.234", "KWD", 1234, "1.234"},
+		{"0.001", "KWD", 1, "0.001"},
+	}
+
+	for _, tc := range testCases {
+		money, err := ParseMoney(tc.value, tc.currency)
+		require.NoError(t, err, tc.value)
+		require.Equal(t, tc.amount, money.Amount())
+		require.Equal(t, tc.currency, money.Currency())
+		require.Equal(t, tc.decimal, money.Decimal())
+	}
+}
+
+func TestParseMoneyInvalid(t *testing.T) {
+	testCases := []struct {
+		value    string
+		currency string
+		err      error
+	}{
+		{"", EUR, ErrInvalidAmount},
+		{"abc", EUR, ErrInvalidAmount},
+		{".5", EUR, ErrInvalidAmount},
+		{"5.", EUR, ErrInvalidAmount},
+		{"1,00", EUR, ErrInvalidAmount},
+		{"+1", EUR, ErrInvalidAmount},
+		{"12.345", EUR, ErrInvalidAmount},
+		{"12.5", "JPY", ErrInvalidAmount},
+		{"92233720368547758.08", EUR, ErrMoneyOverflow},
+		{"1.00", "XYZ", ErrUnknownCurrency},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseMoney(tc.value, tc.currency)
+		require.ErrorIs(t, err, tc.err, tc.value)
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a, err := NewMoney(1050, EUR)
+	require.NoError(t, err)
+	b, err := NewMoney(250, EUR)
+	require.NoError(t, err)
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	require.Equal(t, "13.00 EUR", sum.String())
+
+	diff, err := b.Sub(a)
+	require.NoError(t, err)
+	require.Equal(t, "-8.00 EUR", diff.String())
+	require.True(t, diff.IsNegative())
+
+	cmp, err := a.Cmp(b)
+	require.NoError(t, err)
+	require.Equal(t, 1, cmp)
+
+	usd, err := NewMoney(250, USD)
+	require.NoError(t, err)
+	_, err = a.Add(usd)
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+	_, err = a.Sub(usd)
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+	_, err = a.Cmp(usd)
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	largest, err := NewMoney(math.MaxInt64, EUR)
+	require.NoError(t, err)
+	_, err = largest.Add(b)
+	require.ErrorIs(t, err, ErrMoneyOverflow)
+
+	smallest, err := NewMoney(math.MinInt64, EUR)
+	require.NoError(t, err)
+	_, err = smallest.Sub(b)
+	require.ErrorIs(t, err, ErrMoneyOverflow)
+	_, err = smallest.Neg()
+	require.ErrorIs(t, err, ErrMoneyOverflow)
+	require.Equal(t, "-92233720368547758.08", smallest.Decimal())
+}
+
+func TestMoneyJSON(t *testing.T) {
+	money, err := NewMoney(1234, EUR)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(money)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amount":"12.34","currency":"EUR"}`, string(data))
+
+	var got Money
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+	require.Equal(t, money, got)
+
+	err = json.Unmarshal([]byte(`{"amount":"12.345","currency":"EUR"}`), &got)
+	require.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestParseCurrencyAmounts(t *testing.T) {
+	amounts, err := ParseCurrencyAmounts([]string{"usd:1000.00", " JPY:50000"})
+	require.NoError(t, err)
+	require.Len(t, amounts, 2)
+	require.Equal(t, int64(100000), amounts[USD].Amount())
+	require.Equal(t, int64(50000), amounts["JPY"].Amount())
+
+	for _, value := range []string{"USD", "USD:-5", "USD:0", "XYZ:10", "JPY:1.5"} {
+		_, err := ParseCurrencyAmounts([]string{value})
+		require.Error(t, err, value)
+	}
+}
@@ -45,14 +45,14 @@ func (rg *randomGenerator) RandomMoney() int64 {
 	return rg.RandomInt(0, 1000)
 }
 
-// RandomCurrency generates a random currency from a list of provided currencies
+// RandomCurrency picks a random currency enabled in the default currency registry
 func (rg *randomGenerator) RandomCurrency() string {
-	currencies := []string{USD, EUR, CAD}
+	currencies := DefaultCurrencyRegistry().Enabled()
 	n := len(currencies)
 	if n == 0 {
-		return "" // Return empty string if no currencies provided
+		return "" // Return empty string if no currencies are enabled
 	}
-	return currencies[rg.rand.Intn(n)]
+	return currencies[rg.rand.Intn(n)].Code
 }
 
 func (rg *randomGenerator) RandomEmail() string {